@@ -0,0 +1,449 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+	infrav1alpha3 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+const (
+	// DataAnnotation is the annotation that conversion webhooks use to retain
+	// the v1alpha3 fields that cannot be represented in v1alpha2.
+	DataAnnotation = "infrastructure.cluster.x-k8s.io/conversion-data"
+)
+
+// ConvertTo converts this AWSCluster to the Hub version (v1alpha3).
+func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1alpha3.AWSCluster)
+	if err := Convert_v1alpha2_AWSCluster_To_v1alpha3_AWSCluster(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &infrav1alpha3.AWSCluster{}
+	if ok, err := unmarshalData(src, dst, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.ImageLookupOrg = restored.Spec.ImageLookupOrg
+	dst.Spec.IdentityRef = restored.Spec.IdentityRef
+	dst.Spec.RoleIdentity = restored.Spec.RoleIdentity
+	dst.Spec.Bastion = restored.Spec.Bastion
+	dst.Spec.APIServerAllowedCIDRBlocks = restored.Spec.APIServerAllowedCIDRBlocks
+	dst.Spec.NodePortAllowedCIDRBlocks = restored.Spec.NodePortAllowedCIDRBlocks
+	dst.Spec.NetworkSpec.VPC.AvailabilityZoneUsageLimit = restored.Spec.NetworkSpec.VPC.AvailabilityZoneUsageLimit
+	dst.Spec.NetworkSpec.VPC.IPv6 = restored.Spec.NetworkSpec.VPC.IPv6
+	dst.Spec.NetworkSpec.VPC.Endpoints = restored.Spec.NetworkSpec.VPC.Endpoints
+	dst.Spec.NetworkSpec.VPC.SecondaryCidrBlocks = restored.Spec.NetworkSpec.VPC.SecondaryCidrBlocks
+	dst.Spec.NetworkSpec.CNI = restored.Spec.NetworkSpec.CNI
+	dst.Spec.NetworkSpec.SecurityGroupOverrides = restored.Spec.NetworkSpec.SecurityGroupOverrides
+	dst.Spec.NetworkSpec.Isolated = restored.Spec.NetworkSpec.Isolated
+	dst.Spec.NetworkSpec.NatGatewayStrategy = restored.Spec.NetworkSpec.NatGatewayStrategy
+	dst.Spec.NetworkSpec.TransitGateway = restored.Spec.NetworkSpec.TransitGateway
+	restoreSubnets(restored.Spec.NetworkSpec.Subnets, dst.Spec.NetworkSpec.Subnets)
+
+	if restored.Spec.ControlPlaneLoadBalancer != nil {
+		if dst.Spec.ControlPlaneLoadBalancer == nil {
+			dst.Spec.ControlPlaneLoadBalancer = &infrav1alpha3.AWSLoadBalancerSpec{}
+		}
+		dst.Spec.ControlPlaneLoadBalancer.LoadBalancerType = restored.Spec.ControlPlaneLoadBalancer.LoadBalancerType
+		dst.Spec.ControlPlaneLoadBalancer.CrossZoneLoadBalancing = restored.Spec.ControlPlaneLoadBalancer.CrossZoneLoadBalancing
+		dst.Spec.ControlPlaneLoadBalancer.ConnectionDrainingTimeoutSeconds = restored.Spec.ControlPlaneLoadBalancer.ConnectionDrainingTimeoutSeconds
+		dst.Spec.ControlPlaneLoadBalancer.AccessLog = restored.Spec.ControlPlaneLoadBalancer.AccessLog
+	}
+
+	dst.Status.Network.APIServerELB.ARN = restored.Status.Network.APIServerELB.ARN
+	dst.Status.Network.APIServerELB.LoadBalancerType = restored.Status.Network.APIServerELB.LoadBalancerType
+	dst.Status.Network.APIServerELB.AvailabilityZones = restored.Status.Network.APIServerELB.AvailabilityZones
+	dst.Status.Network.APIServerELB.Attributes.CrossZoneLoadBalancing = restored.Status.Network.APIServerELB.Attributes.CrossZoneLoadBalancing
+	dst.Status.Network.APIServerELB.Attributes.ConnectionDrainingTimeout = restored.Status.Network.APIServerELB.Attributes.ConnectionDrainingTimeout
+	dst.Status.Network.APIServerELB.Attributes.AccessLog = restored.Status.Network.APIServerELB.Attributes.AccessLog
+	dst.Status.Conditions = restored.Status.Conditions
+
+	restoreInstance(&restored.Status.Bastion, &dst.Status.Bastion)
+	restoreSecurityGroups(restored.Status.Network.SecurityGroups, dst.Status.Network.SecurityGroups)
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha3) to this version.
+func (dst *AWSCluster) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1alpha3.AWSCluster)
+	if err := Convert_v1alpha3_AWSCluster_To_v1alpha2_AWSCluster(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion.
+	return marshalData(src, dst)
+}
+
+// ConvertTo converts this AWSClusterList to the Hub version (v1alpha3).
+func (src *AWSClusterList) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1alpha3.AWSClusterList)
+	return Convert_v1alpha2_AWSClusterList_To_v1alpha3_AWSClusterList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1alpha3) to this version.
+func (dst *AWSClusterList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1alpha3.AWSClusterList)
+	return Convert_v1alpha3_AWSClusterList_To_v1alpha2_AWSClusterList(src, dst, nil)
+}
+
+// ConvertTo converts this AWSMachine to the Hub version (v1alpha3).
+func (src *AWSMachine) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1alpha3.AWSMachine)
+	if err := Convert_v1alpha2_AWSMachine_To_v1alpha3_AWSMachine(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &infrav1alpha3.AWSMachine{}
+	if ok, err := unmarshalData(src, dst, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.SpotMarketOptions = restored.Spec.SpotMarketOptions
+	dst.Spec.RootVolume = restored.Spec.RootVolume
+	dst.Spec.NonRootVolumes = restored.Spec.NonRootVolumes
+	dst.Status.InstanceLifecycle = restored.Status.InstanceLifecycle
+	dst.Status.Conditions = restored.Status.Conditions
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha3) to this version.
+func (dst *AWSMachine) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1alpha3.AWSMachine)
+	if err := Convert_v1alpha3_AWSMachine_To_v1alpha2_AWSMachine(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion.
+	return marshalData(src, dst)
+}
+
+// ConvertTo converts this AWSMachineList to the Hub version (v1alpha3).
+func (src *AWSMachineList) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1alpha3.AWSMachineList)
+	return Convert_v1alpha2_AWSMachineList_To_v1alpha3_AWSMachineList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1alpha3) to this version.
+func (dst *AWSMachineList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1alpha3.AWSMachineList)
+	return Convert_v1alpha3_AWSMachineList_To_v1alpha2_AWSMachineList(src, dst, nil)
+}
+
+// ConvertTo converts this AWSMachineTemplate to the Hub version (v1alpha3).
+func (src *AWSMachineTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1alpha3.AWSMachineTemplate)
+	if err := Convert_v1alpha2_AWSMachineTemplate_To_v1alpha3_AWSMachineTemplate(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &infrav1alpha3.AWSMachineTemplate{}
+	if ok, err := unmarshalData(src, dst, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.Template.Spec.SpotMarketOptions = restored.Spec.Template.Spec.SpotMarketOptions
+	dst.Spec.Template.Spec.RootVolume = restored.Spec.Template.Spec.RootVolume
+	dst.Spec.Template.Spec.NonRootVolumes = restored.Spec.Template.Spec.NonRootVolumes
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha3) to this version.
+func (dst *AWSMachineTemplate) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1alpha3.AWSMachineTemplate)
+	if err := Convert_v1alpha3_AWSMachineTemplate_To_v1alpha2_AWSMachineTemplate(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion.
+	return marshalData(src, dst)
+}
+
+// ConvertTo converts this AWSMachineTemplateList to the Hub version (v1alpha3).
+func (src *AWSMachineTemplateList) ConvertTo(dstRaw conversion.Hub) error { // nolint
+	dst := dstRaw.(*infrav1alpha3.AWSMachineTemplateList)
+	return Convert_v1alpha2_AWSMachineTemplateList_To_v1alpha3_AWSMachineTemplateList(src, dst, nil)
+}
+
+// ConvertFrom converts from the Hub version (v1alpha3) to this version.
+func (dst *AWSMachineTemplateList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
+	src := srcRaw.(*infrav1alpha3.AWSMachineTemplateList)
+	return Convert_v1alpha3_AWSMachineTemplateList_To_v1alpha2_AWSMachineTemplateList(src, dst, nil)
+}
+
+// Convert_v1alpha3_AWSClusterSpec_To_v1alpha2_AWSClusterSpec converts from the Hub version (v1alpha3) of the AWSClusterSpec to this version.
+// Requires manual conversion as ImageLookupOrg, IdentityRef, RoleIdentity, Bastion, APIServerAllowedCIDRBlocks
+// and NodePortAllowedCIDRBlocks do not exist in v1alpha2.
+func Convert_v1alpha3_AWSClusterSpec_To_v1alpha2_AWSClusterSpec(in *infrav1alpha3.AWSClusterSpec, out *AWSClusterSpec, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1alpha3_AWSClusterSpec_To_v1alpha2_AWSClusterSpec(in, out, s)
+}
+
+// Convert_v1alpha3_AWSClusterStatus_To_v1alpha2_AWSClusterStatus converts from the Hub version (v1alpha3) of the AWSClusterStatus to this version.
+// Requires manual conversion as Conditions does not exist in v1alpha2.
+func Convert_v1alpha3_AWSClusterStatus_To_v1alpha2_AWSClusterStatus(in *infrav1alpha3.AWSClusterStatus, out *AWSClusterStatus, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1alpha3_AWSClusterStatus_To_v1alpha2_AWSClusterStatus(in, out, s)
+}
+
+// Convert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec converts from the Hub version (v1alpha3) of the AWSLoadBalancerSpec to this version.
+// Requires manual conversion as LoadBalancerType, CrossZoneLoadBalancing, ConnectionDrainingTimeoutSeconds
+// and AccessLog do not exist in v1alpha2.
+func Convert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec(in *infrav1alpha3.AWSLoadBalancerSpec, out *AWSLoadBalancerSpec, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec(in, out, s)
+}
+
+// Convert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec converts from the Hub version (v1alpha3) of the VPCSpec to this version.
+// Requires manual conversion as AvailabilityZoneUsageLimit, IPv6, Endpoints and SecondaryCidrBlocks do not exist in v1alpha2.
+func Convert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(in *infrav1alpha3.VPCSpec, out *VPCSpec, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(in, out, s)
+}
+
+// Convert_v1alpha3_AWSMachineSpec_To_v1alpha2_AWSMachineSpec converts from the Hub version (v1alpha3) of the AWSMachineSpec to this version.
+// Requires manual conversion as SpotMarketOptions, RootVolume and NonRootVolumes do not exist in v1alpha2.
+func Convert_v1alpha3_AWSMachineSpec_To_v1alpha2_AWSMachineSpec(in *infrav1alpha3.AWSMachineSpec, out *AWSMachineSpec, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1alpha3_AWSMachineSpec_To_v1alpha2_AWSMachineSpec(in, out, s)
+}
+
+// Convert_v1alpha3_AWSMachineStatus_To_v1alpha2_AWSMachineStatus converts from the Hub version (v1alpha3) of the AWSMachineStatus to this version.
+// Requires manual conversion as InstanceLifecycle and Conditions do not exist in v1alpha2.
+func Convert_v1alpha3_AWSMachineStatus_To_v1alpha2_AWSMachineStatus(in *infrav1alpha3.AWSMachineStatus, out *AWSMachineStatus, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1alpha3_AWSMachineStatus_To_v1alpha2_AWSMachineStatus(in, out, s)
+}
+
+// Convert_v1alpha3_ClassicELB_To_v1alpha2_ClassicELB converts from the Hub version (v1alpha3) of the ClassicELB to this version.
+// Requires manual conversion as ARN, LoadBalancerType and AvailabilityZones do not exist in v1alpha2.
+func Convert_v1alpha3_ClassicELB_To_v1alpha2_ClassicELB(in *infrav1alpha3.ClassicELB, out *ClassicELB, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1alpha3_ClassicELB_To_v1alpha2_ClassicELB(in, out, s)
+}
+
+// Convert_v1alpha3_ClassicELBAttributes_To_v1alpha2_ClassicELBAttributes converts from the Hub version (v1alpha3) of the ClassicELBAttributes to this version.
+// Requires manual conversion as CrossZoneLoadBalancing, ConnectionDrainingTimeout and AccessLog do not exist in v1alpha2.
+func Convert_v1alpha3_ClassicELBAttributes_To_v1alpha2_ClassicELBAttributes(in *infrav1alpha3.ClassicELBAttributes, out *ClassicELBAttributes, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1alpha3_ClassicELBAttributes_To_v1alpha2_ClassicELBAttributes(in, out, s)
+}
+
+// Convert_v1alpha3_Instance_To_v1alpha2_Instance converts from the Hub version (v1alpha3) of the Instance to this version.
+// Requires manual conversion as StateReason, Lifecycle, SpotMarketOptions, SpotInstanceRequestID, RootVolume
+// and NonRootVolumes do not exist in v1alpha2.
+func Convert_v1alpha3_Instance_To_v1alpha2_Instance(in *infrav1alpha3.Instance, out *Instance, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1alpha3_Instance_To_v1alpha2_Instance(in, out, s)
+}
+
+// Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec converts from the Hub version (v1alpha3) of the SubnetSpec to this version.
+// Requires manual conversion as IPv6CidrBlock, IsPod and Routes do not exist in v1alpha2.
+func Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(in *infrav1alpha3.SubnetSpec, out *SubnetSpec, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(in, out, s)
+}
+
+// Convert_v1alpha3_IngressRule_To_v1alpha2_IngressRule converts from the Hub version (v1alpha3) of the IngressRule to this version.
+// Requires manual conversion as IPv6CidrBlocks does not exist in v1alpha2.
+func Convert_v1alpha3_IngressRule_To_v1alpha2_IngressRule(in *infrav1alpha3.IngressRule, out *IngressRule, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1alpha3_IngressRule_To_v1alpha2_IngressRule(in, out, s)
+}
+
+// Convert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec converts this NetworkSpec to the Hub version (v1alpha3).
+// Requires manual conversion as the subnets are pointers to types that no longer share the same memory layout.
+func Convert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(in *NetworkSpec, out *infrav1alpha3.NetworkSpec, s apiconversion.Scope) error { // nolint
+	if err := Convert_v1alpha2_VPCSpec_To_v1alpha3_VPCSpec(&in.VPC, &out.VPC, s); err != nil {
+		return err
+	}
+
+	out.Subnets = nil
+	if in.Subnets != nil {
+		out.Subnets = make(infrav1alpha3.Subnets, len(in.Subnets))
+		for i, sn := range in.Subnets {
+			if sn == nil {
+				continue
+			}
+			out.Subnets[i] = &infrav1alpha3.SubnetSpec{}
+			if err := Convert_v1alpha2_SubnetSpec_To_v1alpha3_SubnetSpec(sn, out.Subnets[i], s); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Convert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec converts from the Hub version (v1alpha3) of the NetworkSpec to this version.
+// Requires manual conversion as the subnets are pointers to types that no longer share the same memory layout,
+// and CNI, SecurityGroupOverrides, Isolated, NatGatewayStrategy and TransitGateway do not exist in v1alpha2.
+func Convert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec(in *infrav1alpha3.NetworkSpec, out *NetworkSpec, s apiconversion.Scope) error { // nolint
+	if err := Convert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(&in.VPC, &out.VPC, s); err != nil {
+		return err
+	}
+
+	out.Subnets = nil
+	if in.Subnets != nil {
+		out.Subnets = make(Subnets, len(in.Subnets))
+		for i, sn := range in.Subnets {
+			if sn == nil {
+				continue
+			}
+			out.Subnets[i] = &SubnetSpec{}
+			if err := Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(sn, out.Subnets[i], s); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Convert_v1alpha2_SecurityGroup_To_v1alpha3_SecurityGroup converts this SecurityGroup to the Hub version (v1alpha3).
+// Requires manual conversion as the ingress rules are pointers to types that no longer share the same memory layout.
+func Convert_v1alpha2_SecurityGroup_To_v1alpha3_SecurityGroup(in *SecurityGroup, out *infrav1alpha3.SecurityGroup, s apiconversion.Scope) error { // nolint
+	out.ID = in.ID
+	out.Name = in.Name
+	out.Tags = infrav1alpha3.Tags(in.Tags)
+
+	out.IngressRules = nil
+	if in.IngressRules != nil {
+		out.IngressRules = make(infrav1alpha3.IngressRules, len(in.IngressRules))
+		for i, rule := range in.IngressRules {
+			if rule == nil {
+				continue
+			}
+			out.IngressRules[i] = &infrav1alpha3.IngressRule{}
+			if err := Convert_v1alpha2_IngressRule_To_v1alpha3_IngressRule(rule, out.IngressRules[i], s); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Convert_v1alpha3_SecurityGroup_To_v1alpha2_SecurityGroup converts from the Hub version (v1alpha3) of the SecurityGroup to this version.
+// Requires manual conversion as the ingress rules are pointers to types that no longer share the same memory layout.
+func Convert_v1alpha3_SecurityGroup_To_v1alpha2_SecurityGroup(in *infrav1alpha3.SecurityGroup, out *SecurityGroup, s apiconversion.Scope) error { // nolint
+	out.ID = in.ID
+	out.Name = in.Name
+	out.Tags = Tags(in.Tags)
+
+	out.IngressRules = nil
+	if in.IngressRules != nil {
+		out.IngressRules = make(IngressRules, len(in.IngressRules))
+		for i, rule := range in.IngressRules {
+			if rule == nil {
+				continue
+			}
+			out.IngressRules[i] = &IngressRule{}
+			if err := Convert_v1alpha3_IngressRule_To_v1alpha2_IngressRule(rule, out.IngressRules[i], s); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// restoreSubnets restores the IPv6 CIDR blocks, the pod flag and the routes of the subnets, matched by ID or CIDR block.
+func restoreSubnets(restored, dst infrav1alpha3.Subnets) {
+	for _, sn := range dst {
+		for _, rsn := range restored {
+			if (sn.ID != "" && sn.ID == rsn.ID) || (sn.ID == "" && sn.CidrBlock == rsn.CidrBlock) {
+				sn.IPv6CidrBlock = rsn.IPv6CidrBlock
+				sn.IsPod = rsn.IsPod
+				sn.Routes = rsn.Routes
+				break
+			}
+		}
+	}
+}
+
+// restoreSecurityGroups restores the IPv6 CIDR blocks of the ingress rules of the security groups.
+func restoreSecurityGroups(restored, dst map[infrav1alpha3.SecurityGroupRole]infrav1alpha3.SecurityGroup) {
+	for role, sg := range dst {
+		rsg, ok := restored[role]
+		if !ok || len(rsg.IngressRules) != len(sg.IngressRules) {
+			continue
+		}
+		for i := range sg.IngressRules {
+			sg.IngressRules[i].IPv6CidrBlocks = rsg.IngressRules[i].IPv6CidrBlocks
+		}
+	}
+}
+
+// restoreInstance restores the Instance fields that do not exist in v1alpha2.
+func restoreInstance(restored, dst *infrav1alpha3.Instance) {
+	dst.StateReason = restored.StateReason
+	dst.Lifecycle = restored.Lifecycle
+	dst.SpotMarketOptions = restored.SpotMarketOptions
+	dst.SpotInstanceRequestID = restored.SpotInstanceRequestID
+	dst.RootVolume = restored.RootVolume
+	dst.NonRootVolumes = restored.NonRootVolumes
+}
+
+// marshalData stores the source object as json data in the destination object annotations map.
+// Object metadata is not stored as it is already converted.
+func marshalData(src metav1.Object, dst metav1.Object) error {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(src)
+	if err != nil {
+		return err
+	}
+	delete(u, "metadata")
+
+	data, err := json.Marshal(u)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal conversion data")
+	}
+
+	annotations := dst.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[DataAnnotation] = string(data)
+	dst.SetAnnotations(annotations)
+	return nil
+}
+
+// unmarshalData tries to retrieve the data stored by marshalData from the annotations of the source object
+// and unmarshal it into the restored object. The annotation is removed from both the source and the
+// converted object. It returns false if no data was stored.
+func unmarshalData(src metav1.Object, converted metav1.Object, restored interface{}) (bool, error) {
+	data, ok := src.GetAnnotations()[DataAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), restored); err != nil {
+		return false, errors.Wrap(err, "failed to unmarshal conversion data")
+	}
+
+	for _, obj := range []metav1.Object{src, converted} {
+		annotations := obj.GetAnnotations()
+		delete(annotations, DataAnnotation)
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		obj.SetAnnotations(annotations)
+	}
+	return true, nil
+}
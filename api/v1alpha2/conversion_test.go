@@ -0,0 +1,273 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1alpha3 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+func TestAWSClusterConversion(t *testing.T) {
+	internal := infrav1alpha3.ClassicELBSchemeInternal
+	zoneLimit := 2
+	drainingTimeout := int64(300)
+	hub := &infrav1alpha3.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster",
+			Namespace:   "default",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: infrav1alpha3.AWSClusterSpec{
+			Region:         "us-east-1",
+			SSHKeyName:     "default",
+			ImageLookupOrg: "123456789012",
+			IdentityRef:    &corev1.LocalObjectReference{Name: "team-a"},
+			RoleIdentity: &infrav1alpha3.AWSRoleIdentity{
+				AWSRoleSpec: infrav1alpha3.AWSRoleSpec{
+					RoleARN:           "arn:aws:iam::123456789012:role/team-a",
+					ExternalID:        "external",
+					SessionTags:       map[string]string{"team": "a"},
+					TransitiveTagKeys: []string{"team"},
+				},
+			},
+			ControlPlaneLoadBalancer: &infrav1alpha3.AWSLoadBalancerSpec{
+				Scheme:                           &internal,
+				LoadBalancerType:                 infrav1alpha3.LoadBalancerTypeNetwork,
+				CrossZoneLoadBalancing:           true,
+				ConnectionDrainingTimeoutSeconds: &drainingTimeout,
+				AccessLog:                        &infrav1alpha3.ClassicELBAccessLog{S3BucketName: "logs", EmitInterval: 5},
+			},
+			APIServerAllowedCIDRBlocks: []string{"192.168.0.0/16"},
+			NodePortAllowedCIDRBlocks:  []string{"192.168.0.0/16", "2001:db8::/32"},
+			Bastion: infrav1alpha3.Bastion{
+				InstanceType:      "t3.small",
+				AMI:               "ami-bastion",
+				AllowedCIDRBlocks: []string{"192.168.0.0/16"},
+			},
+			NetworkSpec: infrav1alpha3.NetworkSpec{
+				VPC: infrav1alpha3.VPCSpec{
+					CidrBlock:                  "10.0.0.0/16",
+					SecondaryCidrBlocks:        []string{"100.64.0.0/16"},
+					AvailabilityZoneUsageLimit: &zoneLimit,
+					IPv6:                       &infrav1alpha3.IPv6{CidrBlock: "2001:db8::/56"},
+					Endpoints: []infrav1alpha3.VPCEndpointSpec{
+						{ServiceName: "s3", Type: infrav1alpha3.VPCEndpointTypeGateway},
+					},
+				},
+				Isolated:           true,
+				NatGatewayStrategy: infrav1alpha3.NatGatewayStrategyNone,
+				TransitGateway: &infrav1alpha3.TransitGatewaySpec{
+					ID:     "tgw-1",
+					Routes: []string{"10.100.0.0/16"},
+				},
+				SecurityGroupOverrides: map[infrav1alpha3.SecurityGroupRole]string{
+					infrav1alpha3.SecurityGroupLB: "sg-lb",
+				},
+				CNI: &infrav1alpha3.CNISpec{
+					CNIIngressRules: infrav1alpha3.CNIIngressRules{
+						{Description: "vxlan (cilium)", Protocol: infrav1alpha3.SecurityGroupProtocolUDP, FromPort: 8472, ToPort: 8472},
+					},
+				},
+				Subnets: infrav1alpha3.Subnets{
+					{ID: "subnet-1", CidrBlock: "10.0.0.0/24", IPv6CidrBlock: "2001:db8::/64", IsPublic: true},
+					{
+						CidrBlock:     "10.0.1.0/24",
+						IPv6CidrBlock: "2001:db8:0:1::/64",
+						Routes:        []infrav1alpha3.RouteSpec{{DestinationCidrBlock: "10.200.0.0/16", VPCPeeringConnectionID: "pcx-1"}},
+					},
+					{CidrBlock: "100.64.0.0/18", IsPod: true},
+				},
+			},
+		},
+		Status: infrav1alpha3.AWSClusterStatus{
+			Ready: true,
+			Network: infrav1alpha3.Network{
+				APIServerELB: infrav1alpha3.ClassicELB{
+					Name:              "cluster-apiserver",
+					DNSName:           "cluster-apiserver.example.com",
+					ARN:               "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/cluster-apiserver/1",
+					LoadBalancerType:  infrav1alpha3.LoadBalancerTypeNetwork,
+					AvailabilityZones: []string{"us-east-1a"},
+					Attributes: infrav1alpha3.ClassicELBAttributes{
+						IdleTimeout:               10 * time.Minute,
+						CrossZoneLoadBalancing:    true,
+						ConnectionDrainingTimeout: 5 * time.Minute,
+						AccessLog:                 &infrav1alpha3.ClassicELBAccessLog{S3BucketName: "logs", EmitInterval: 5},
+					},
+				},
+				SecurityGroups: map[infrav1alpha3.SecurityGroupRole]infrav1alpha3.SecurityGroup{
+					infrav1alpha3.SecurityGroupBastion: {
+						ID:   "sg-1",
+						Name: "cluster-bastion",
+						IngressRules: infrav1alpha3.IngressRules{
+							{
+								Description:    "SSH",
+								Protocol:       infrav1alpha3.SecurityGroupProtocolTCP,
+								FromPort:       22,
+								ToPort:         22,
+								CidrBlocks:     []string{"0.0.0.0/0"},
+								IPv6CidrBlocks: []string{"::/0"},
+							},
+						},
+					},
+				},
+			},
+			Conditions: infrav1alpha3.Conditions{
+				{Type: infrav1alpha3.VPCReadyCondition, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+
+	spoke := &AWSCluster{}
+	if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+		t.Fatalf("failed to convert from hub: %v", err)
+	}
+
+	if spoke.Spec.Region != "us-east-1" || spoke.Spec.ControlPlaneLoadBalancer == nil || *spoke.Spec.ControlPlaneLoadBalancer.Scheme != ClassicELBSchemeInternal {
+		t.Fatalf("unexpected v1alpha2 spec: %+v", spoke.Spec)
+	}
+	if spoke.Status.Network.APIServerELB.DNSName != "cluster-apiserver.example.com" {
+		t.Fatalf("unexpected v1alpha2 status: %+v", spoke.Status)
+	}
+	if _, ok := spoke.Annotations[DataAnnotation]; !ok {
+		t.Fatalf("expected v1alpha3 data to be preserved in the %s annotation", DataAnnotation)
+	}
+
+	restored := &infrav1alpha3.AWSCluster{}
+	if err := spoke.ConvertTo(restored); err != nil {
+		t.Fatalf("failed to convert to hub: %v", err)
+	}
+
+	if !reflect.DeepEqual(hub, restored) {
+		t.Fatalf("expected round trip to preserve the AWSCluster.\nexpected: %+v\ngot: %+v", hub, restored)
+	}
+}
+
+func TestAWSClusterConversionWithoutData(t *testing.T) {
+	spoke := &AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec: AWSClusterSpec{
+			Region: "us-west-2",
+		},
+	}
+
+	hub := &infrav1alpha3.AWSCluster{}
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatalf("failed to convert to hub: %v", err)
+	}
+
+	if hub.Spec.Region != "us-west-2" || hub.Spec.ControlPlaneLoadBalancer != nil || hub.Spec.ImageLookupOrg != "" {
+		t.Fatalf("unexpected v1alpha3 spec: %+v", hub.Spec)
+	}
+}
+
+func TestAWSMachineConversion(t *testing.T) {
+	maxPrice := "0.05"
+	spot := infrav1alpha3.InstanceLifecycleSpot
+	encrypted := true
+	hub := &infrav1alpha3.AWSMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine",
+			Namespace: "default",
+		},
+		Spec: infrav1alpha3.AWSMachineSpec{
+			InstanceType:   "m5.large",
+			ImageLookupOrg: "123456789012",
+			AdditionalTags: infrav1alpha3.Tags{"a": "b"},
+			SpotMarketOptions: &infrav1alpha3.SpotMarketOptions{
+				MaxPrice:             &maxPrice,
+				InterruptionBehavior: infrav1alpha3.SpotInterruptionBehaviorStop,
+			},
+			RootVolume: &infrav1alpha3.Volume{
+				Size:      20,
+				Type:      infrav1alpha3.VolumeTypeGP3,
+				Encrypted: &encrypted,
+				KMSKeyID:  "alias/ebs",
+			},
+			NonRootVolumes: []infrav1alpha3.Volume{
+				{DeviceName: "/dev/sdb", Size: 100, Type: infrav1alpha3.VolumeTypeIO1, IOPS: 1000},
+			},
+		},
+		Status: infrav1alpha3.AWSMachineStatus{
+			Ready:             true,
+			InstanceLifecycle: &spot,
+			Conditions: infrav1alpha3.Conditions{
+				{
+					Type:     infrav1alpha3.InstanceReadyCondition,
+					Status:   corev1.ConditionFalse,
+					Severity: infrav1alpha3.ConditionSeverityInfo,
+					Reason:   infrav1alpha3.InstanceNotReadyReason,
+				},
+			},
+		},
+	}
+
+	spoke := &AWSMachine{}
+	if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+		t.Fatalf("failed to convert from hub: %v", err)
+	}
+
+	if spoke.Spec.InstanceType != "m5.large" || spoke.Spec.ImageLookupOrg != "123456789012" {
+		t.Fatalf("unexpected v1alpha2 spec: %+v", spoke.Spec)
+	}
+
+	restored := &infrav1alpha3.AWSMachine{}
+	if err := spoke.ConvertTo(restored); err != nil {
+		t.Fatalf("failed to convert to hub: %v", err)
+	}
+
+	if !reflect.DeepEqual(hub, restored) {
+		t.Fatalf("expected round trip to preserve the AWSMachine.\nexpected: %+v\ngot: %+v", hub, restored)
+	}
+}
+
+func TestAWSMachineTemplateConversion(t *testing.T) {
+	hub := &infrav1alpha3.AWSMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "template",
+			Namespace: "default",
+		},
+		Spec: infrav1alpha3.AWSMachineTemplateSpec{
+			Template: infrav1alpha3.AWSMachineTemplateResource{
+				Spec: infrav1alpha3.AWSMachineSpec{
+					InstanceType:      "m5.large",
+					SpotMarketOptions: &infrav1alpha3.SpotMarketOptions{},
+					NonRootVolumes:    []infrav1alpha3.Volume{{DeviceName: "/dev/sdb", Size: 20}},
+				},
+			},
+		},
+	}
+
+	spoke := &AWSMachineTemplate{}
+	if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+		t.Fatalf("failed to convert from hub: %v", err)
+	}
+
+	restored := &infrav1alpha3.AWSMachineTemplate{}
+	if err := spoke.ConvertTo(restored); err != nil {
+		t.Fatalf("failed to convert to hub: %v", err)
+	}
+
+	if !reflect.DeepEqual(hub, restored) {
+		t.Fatalf("expected round trip to preserve the AWSMachineTemplate.\nexpected: %+v\ngot: %+v", hub, restored)
+	}
+}
@@ -0,0 +1,1331 @@
+// +build !ignore_autogenerated
+
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by conversion-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	time "time"
+	unsafe "unsafe"
+
+	v1 "k8s.io/api/core/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	v1alpha3 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	errors "sigs.k8s.io/cluster-api/errors"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*APIEndpoint)(nil), (*v1alpha3.APIEndpoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_APIEndpoint_To_v1alpha3_APIEndpoint(a.(*APIEndpoint), b.(*v1alpha3.APIEndpoint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.APIEndpoint)(nil), (*APIEndpoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_APIEndpoint_To_v1alpha2_APIEndpoint(a.(*v1alpha3.APIEndpoint), b.(*APIEndpoint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSCluster)(nil), (*v1alpha3.AWSCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSCluster_To_v1alpha3_AWSCluster(a.(*AWSCluster), b.(*v1alpha3.AWSCluster), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSCluster)(nil), (*AWSCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSCluster_To_v1alpha2_AWSCluster(a.(*v1alpha3.AWSCluster), b.(*AWSCluster), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSClusterList)(nil), (*v1alpha3.AWSClusterList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSClusterList_To_v1alpha3_AWSClusterList(a.(*AWSClusterList), b.(*v1alpha3.AWSClusterList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSClusterList)(nil), (*AWSClusterList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSClusterList_To_v1alpha2_AWSClusterList(a.(*v1alpha3.AWSClusterList), b.(*AWSClusterList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSClusterSpec)(nil), (*v1alpha3.AWSClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSClusterSpec_To_v1alpha3_AWSClusterSpec(a.(*AWSClusterSpec), b.(*v1alpha3.AWSClusterSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSClusterSpec)(nil), (*AWSClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSClusterSpec_To_v1alpha2_AWSClusterSpec(a.(*v1alpha3.AWSClusterSpec), b.(*AWSClusterSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSClusterStatus)(nil), (*v1alpha3.AWSClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSClusterStatus_To_v1alpha3_AWSClusterStatus(a.(*AWSClusterStatus), b.(*v1alpha3.AWSClusterStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSClusterStatus)(nil), (*AWSClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSClusterStatus_To_v1alpha2_AWSClusterStatus(a.(*v1alpha3.AWSClusterStatus), b.(*AWSClusterStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSLoadBalancerSpec)(nil), (*v1alpha3.AWSLoadBalancerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSLoadBalancerSpec_To_v1alpha3_AWSLoadBalancerSpec(a.(*AWSLoadBalancerSpec), b.(*v1alpha3.AWSLoadBalancerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSLoadBalancerSpec)(nil), (*AWSLoadBalancerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec(a.(*v1alpha3.AWSLoadBalancerSpec), b.(*AWSLoadBalancerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSMachine)(nil), (*v1alpha3.AWSMachine)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSMachine_To_v1alpha3_AWSMachine(a.(*AWSMachine), b.(*v1alpha3.AWSMachine), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSMachine)(nil), (*AWSMachine)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSMachine_To_v1alpha2_AWSMachine(a.(*v1alpha3.AWSMachine), b.(*AWSMachine), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSMachineList)(nil), (*v1alpha3.AWSMachineList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSMachineList_To_v1alpha3_AWSMachineList(a.(*AWSMachineList), b.(*v1alpha3.AWSMachineList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSMachineList)(nil), (*AWSMachineList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSMachineList_To_v1alpha2_AWSMachineList(a.(*v1alpha3.AWSMachineList), b.(*AWSMachineList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSMachineSpec)(nil), (*v1alpha3.AWSMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSMachineSpec_To_v1alpha3_AWSMachineSpec(a.(*AWSMachineSpec), b.(*v1alpha3.AWSMachineSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSMachineSpec)(nil), (*AWSMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSMachineSpec_To_v1alpha2_AWSMachineSpec(a.(*v1alpha3.AWSMachineSpec), b.(*AWSMachineSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSMachineStatus)(nil), (*v1alpha3.AWSMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSMachineStatus_To_v1alpha3_AWSMachineStatus(a.(*AWSMachineStatus), b.(*v1alpha3.AWSMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSMachineStatus)(nil), (*AWSMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSMachineStatus_To_v1alpha2_AWSMachineStatus(a.(*v1alpha3.AWSMachineStatus), b.(*AWSMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSMachineTemplate)(nil), (*v1alpha3.AWSMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSMachineTemplate_To_v1alpha3_AWSMachineTemplate(a.(*AWSMachineTemplate), b.(*v1alpha3.AWSMachineTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSMachineTemplate)(nil), (*AWSMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSMachineTemplate_To_v1alpha2_AWSMachineTemplate(a.(*v1alpha3.AWSMachineTemplate), b.(*AWSMachineTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSMachineTemplateList)(nil), (*v1alpha3.AWSMachineTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSMachineTemplateList_To_v1alpha3_AWSMachineTemplateList(a.(*AWSMachineTemplateList), b.(*v1alpha3.AWSMachineTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSMachineTemplateList)(nil), (*AWSMachineTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSMachineTemplateList_To_v1alpha2_AWSMachineTemplateList(a.(*v1alpha3.AWSMachineTemplateList), b.(*AWSMachineTemplateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSMachineTemplateResource)(nil), (*v1alpha3.AWSMachineTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSMachineTemplateResource_To_v1alpha3_AWSMachineTemplateResource(a.(*AWSMachineTemplateResource), b.(*v1alpha3.AWSMachineTemplateResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSMachineTemplateResource)(nil), (*AWSMachineTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSMachineTemplateResource_To_v1alpha2_AWSMachineTemplateResource(a.(*v1alpha3.AWSMachineTemplateResource), b.(*AWSMachineTemplateResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSMachineTemplateSpec)(nil), (*v1alpha3.AWSMachineTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSMachineTemplateSpec_To_v1alpha3_AWSMachineTemplateSpec(a.(*AWSMachineTemplateSpec), b.(*v1alpha3.AWSMachineTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSMachineTemplateSpec)(nil), (*AWSMachineTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSMachineTemplateSpec_To_v1alpha2_AWSMachineTemplateSpec(a.(*v1alpha3.AWSMachineTemplateSpec), b.(*AWSMachineTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSResourceReference)(nil), (*v1alpha3.AWSResourceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSResourceReference_To_v1alpha3_AWSResourceReference(a.(*AWSResourceReference), b.(*v1alpha3.AWSResourceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSResourceReference)(nil), (*AWSResourceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(a.(*v1alpha3.AWSResourceReference), b.(*AWSResourceReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BuildParams)(nil), (*v1alpha3.BuildParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BuildParams_To_v1alpha3_BuildParams(a.(*BuildParams), b.(*v1alpha3.BuildParams), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.BuildParams)(nil), (*BuildParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_BuildParams_To_v1alpha2_BuildParams(a.(*v1alpha3.BuildParams), b.(*BuildParams), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClassicELB)(nil), (*v1alpha3.ClassicELB)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ClassicELB_To_v1alpha3_ClassicELB(a.(*ClassicELB), b.(*v1alpha3.ClassicELB), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ClassicELB)(nil), (*ClassicELB)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClassicELB_To_v1alpha2_ClassicELB(a.(*v1alpha3.ClassicELB), b.(*ClassicELB), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClassicELBAttributes)(nil), (*v1alpha3.ClassicELBAttributes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ClassicELBAttributes_To_v1alpha3_ClassicELBAttributes(a.(*ClassicELBAttributes), b.(*v1alpha3.ClassicELBAttributes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ClassicELBAttributes)(nil), (*ClassicELBAttributes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClassicELBAttributes_To_v1alpha2_ClassicELBAttributes(a.(*v1alpha3.ClassicELBAttributes), b.(*ClassicELBAttributes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClassicELBHealthCheck)(nil), (*v1alpha3.ClassicELBHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ClassicELBHealthCheck_To_v1alpha3_ClassicELBHealthCheck(a.(*ClassicELBHealthCheck), b.(*v1alpha3.ClassicELBHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ClassicELBHealthCheck)(nil), (*ClassicELBHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClassicELBHealthCheck_To_v1alpha2_ClassicELBHealthCheck(a.(*v1alpha3.ClassicELBHealthCheck), b.(*ClassicELBHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClassicELBListener)(nil), (*v1alpha3.ClassicELBListener)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ClassicELBListener_To_v1alpha3_ClassicELBListener(a.(*ClassicELBListener), b.(*v1alpha3.ClassicELBListener), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ClassicELBListener)(nil), (*ClassicELBListener)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClassicELBListener_To_v1alpha2_ClassicELBListener(a.(*v1alpha3.ClassicELBListener), b.(*ClassicELBListener), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Filter)(nil), (*v1alpha3.Filter)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_Filter_To_v1alpha3_Filter(a.(*Filter), b.(*v1alpha3.Filter), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.Filter)(nil), (*Filter)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_Filter_To_v1alpha2_Filter(a.(*v1alpha3.Filter), b.(*Filter), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*IngressRule)(nil), (*v1alpha3.IngressRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_IngressRule_To_v1alpha3_IngressRule(a.(*IngressRule), b.(*v1alpha3.IngressRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.IngressRule)(nil), (*IngressRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_IngressRule_To_v1alpha2_IngressRule(a.(*v1alpha3.IngressRule), b.(*IngressRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Instance)(nil), (*v1alpha3.Instance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_Instance_To_v1alpha3_Instance(a.(*Instance), b.(*v1alpha3.Instance), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.Instance)(nil), (*Instance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_Instance_To_v1alpha2_Instance(a.(*v1alpha3.Instance), b.(*Instance), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Network)(nil), (*v1alpha3.Network)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_Network_To_v1alpha3_Network(a.(*Network), b.(*v1alpha3.Network), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.Network)(nil), (*Network)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_Network_To_v1alpha2_Network(a.(*v1alpha3.Network), b.(*Network), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkSpec)(nil), (*v1alpha3.NetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(a.(*NetworkSpec), b.(*v1alpha3.NetworkSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.NetworkSpec)(nil), (*NetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec(a.(*v1alpha3.NetworkSpec), b.(*NetworkSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RouteTable)(nil), (*v1alpha3.RouteTable)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_RouteTable_To_v1alpha3_RouteTable(a.(*RouteTable), b.(*v1alpha3.RouteTable), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.RouteTable)(nil), (*RouteTable)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_RouteTable_To_v1alpha2_RouteTable(a.(*v1alpha3.RouteTable), b.(*RouteTable), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SecurityGroup)(nil), (*v1alpha3.SecurityGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_SecurityGroup_To_v1alpha3_SecurityGroup(a.(*SecurityGroup), b.(*v1alpha3.SecurityGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.SecurityGroup)(nil), (*SecurityGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SecurityGroup_To_v1alpha2_SecurityGroup(a.(*v1alpha3.SecurityGroup), b.(*SecurityGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SubnetSpec)(nil), (*v1alpha3.SubnetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_SubnetSpec_To_v1alpha3_SubnetSpec(a.(*SubnetSpec), b.(*v1alpha3.SubnetSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.SubnetSpec)(nil), (*SubnetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(a.(*v1alpha3.SubnetSpec), b.(*SubnetSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VPCSpec)(nil), (*v1alpha3.VPCSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VPCSpec_To_v1alpha3_VPCSpec(a.(*VPCSpec), b.(*v1alpha3.VPCSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.VPCSpec)(nil), (*VPCSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(a.(*v1alpha3.VPCSpec), b.(*VPCSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*NetworkSpec)(nil), (*v1alpha3.NetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(a.(*NetworkSpec), b.(*v1alpha3.NetworkSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*SecurityGroup)(nil), (*v1alpha3.SecurityGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_SecurityGroup_To_v1alpha3_SecurityGroup(a.(*SecurityGroup), b.(*v1alpha3.SecurityGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.AWSClusterSpec)(nil), (*AWSClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSClusterSpec_To_v1alpha2_AWSClusterSpec(a.(*v1alpha3.AWSClusterSpec), b.(*AWSClusterSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.AWSClusterStatus)(nil), (*AWSClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSClusterStatus_To_v1alpha2_AWSClusterStatus(a.(*v1alpha3.AWSClusterStatus), b.(*AWSClusterStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.AWSLoadBalancerSpec)(nil), (*AWSLoadBalancerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec(a.(*v1alpha3.AWSLoadBalancerSpec), b.(*AWSLoadBalancerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.AWSMachineSpec)(nil), (*AWSMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSMachineSpec_To_v1alpha2_AWSMachineSpec(a.(*v1alpha3.AWSMachineSpec), b.(*AWSMachineSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.AWSMachineStatus)(nil), (*AWSMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSMachineStatus_To_v1alpha2_AWSMachineStatus(a.(*v1alpha3.AWSMachineStatus), b.(*AWSMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.ClassicELBAttributes)(nil), (*ClassicELBAttributes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClassicELBAttributes_To_v1alpha2_ClassicELBAttributes(a.(*v1alpha3.ClassicELBAttributes), b.(*ClassicELBAttributes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.ClassicELB)(nil), (*ClassicELB)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClassicELB_To_v1alpha2_ClassicELB(a.(*v1alpha3.ClassicELB), b.(*ClassicELB), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.IngressRule)(nil), (*IngressRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_IngressRule_To_v1alpha2_IngressRule(a.(*v1alpha3.IngressRule), b.(*IngressRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.Instance)(nil), (*Instance)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_Instance_To_v1alpha2_Instance(a.(*v1alpha3.Instance), b.(*Instance), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.NetworkSpec)(nil), (*NetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec(a.(*v1alpha3.NetworkSpec), b.(*NetworkSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.SecurityGroup)(nil), (*SecurityGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SecurityGroup_To_v1alpha2_SecurityGroup(a.(*v1alpha3.SecurityGroup), b.(*SecurityGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.SubnetSpec)(nil), (*SubnetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(a.(*v1alpha3.SubnetSpec), b.(*SubnetSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.VPCSpec)(nil), (*VPCSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(a.(*v1alpha3.VPCSpec), b.(*VPCSpec), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha2_APIEndpoint_To_v1alpha3_APIEndpoint(in *APIEndpoint, out *v1alpha3.APIEndpoint, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_v1alpha2_APIEndpoint_To_v1alpha3_APIEndpoint is an autogenerated conversion function.
+func Convert_v1alpha2_APIEndpoint_To_v1alpha3_APIEndpoint(in *APIEndpoint, out *v1alpha3.APIEndpoint, s conversion.Scope) error {
+	return autoConvert_v1alpha2_APIEndpoint_To_v1alpha3_APIEndpoint(in, out, s)
+}
+
+func autoConvert_v1alpha3_APIEndpoint_To_v1alpha2_APIEndpoint(in *v1alpha3.APIEndpoint, out *APIEndpoint, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_v1alpha3_APIEndpoint_To_v1alpha2_APIEndpoint is an autogenerated conversion function.
+func Convert_v1alpha3_APIEndpoint_To_v1alpha2_APIEndpoint(in *v1alpha3.APIEndpoint, out *APIEndpoint, s conversion.Scope) error {
+	return autoConvert_v1alpha3_APIEndpoint_To_v1alpha2_APIEndpoint(in, out, s)
+}
+
+func autoConvert_v1alpha2_AWSCluster_To_v1alpha3_AWSCluster(in *AWSCluster, out *v1alpha3.AWSCluster, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha2_AWSClusterSpec_To_v1alpha3_AWSClusterSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha2_AWSClusterStatus_To_v1alpha3_AWSClusterStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AWSCluster_To_v1alpha3_AWSCluster is an autogenerated conversion function.
+func Convert_v1alpha2_AWSCluster_To_v1alpha3_AWSCluster(in *AWSCluster, out *v1alpha3.AWSCluster, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSCluster_To_v1alpha3_AWSCluster(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSCluster_To_v1alpha2_AWSCluster(in *v1alpha3.AWSCluster, out *AWSCluster, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha3_AWSClusterSpec_To_v1alpha2_AWSClusterSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha3_AWSClusterStatus_To_v1alpha2_AWSClusterStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_AWSCluster_To_v1alpha2_AWSCluster is an autogenerated conversion function.
+func Convert_v1alpha3_AWSCluster_To_v1alpha2_AWSCluster(in *v1alpha3.AWSCluster, out *AWSCluster, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AWSCluster_To_v1alpha2_AWSCluster(in, out, s)
+}
+
+func autoConvert_v1alpha2_AWSClusterList_To_v1alpha3_AWSClusterList(in *AWSClusterList, out *v1alpha3.AWSClusterList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha3.AWSCluster, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_AWSCluster_To_v1alpha3_AWSCluster(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AWSClusterList_To_v1alpha3_AWSClusterList is an autogenerated conversion function.
+func Convert_v1alpha2_AWSClusterList_To_v1alpha3_AWSClusterList(in *AWSClusterList, out *v1alpha3.AWSClusterList, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSClusterList_To_v1alpha3_AWSClusterList(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSClusterList_To_v1alpha2_AWSClusterList(in *v1alpha3.AWSClusterList, out *AWSClusterList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AWSCluster, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_AWSCluster_To_v1alpha2_AWSCluster(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_AWSClusterList_To_v1alpha2_AWSClusterList is an autogenerated conversion function.
+func Convert_v1alpha3_AWSClusterList_To_v1alpha2_AWSClusterList(in *v1alpha3.AWSClusterList, out *AWSClusterList, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AWSClusterList_To_v1alpha2_AWSClusterList(in, out, s)
+}
+
+func autoConvert_v1alpha2_AWSClusterSpec_To_v1alpha3_AWSClusterSpec(in *AWSClusterSpec, out *v1alpha3.AWSClusterSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(&in.NetworkSpec, &out.NetworkSpec, s); err != nil {
+		return err
+	}
+	out.Region = in.Region
+	out.SSHKeyName = in.SSHKeyName
+	out.AdditionalTags = *(*v1alpha3.Tags)(unsafe.Pointer(&in.AdditionalTags))
+	if in.ControlPlaneLoadBalancer != nil {
+		in, out := &in.ControlPlaneLoadBalancer, &out.ControlPlaneLoadBalancer
+		*out = new(v1alpha3.AWSLoadBalancerSpec)
+		if err := Convert_v1alpha2_AWSLoadBalancerSpec_To_v1alpha3_AWSLoadBalancerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ControlPlaneLoadBalancer = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AWSClusterSpec_To_v1alpha3_AWSClusterSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AWSClusterSpec_To_v1alpha3_AWSClusterSpec(in *AWSClusterSpec, out *v1alpha3.AWSClusterSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSClusterSpec_To_v1alpha3_AWSClusterSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSClusterSpec_To_v1alpha2_AWSClusterSpec(in *v1alpha3.AWSClusterSpec, out *AWSClusterSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec(&in.NetworkSpec, &out.NetworkSpec, s); err != nil {
+		return err
+	}
+	out.Region = in.Region
+	out.SSHKeyName = in.SSHKeyName
+	out.AdditionalTags = *(*Tags)(unsafe.Pointer(&in.AdditionalTags))
+	if in.ControlPlaneLoadBalancer != nil {
+		in, out := &in.ControlPlaneLoadBalancer, &out.ControlPlaneLoadBalancer
+		*out = new(AWSLoadBalancerSpec)
+		if err := Convert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ControlPlaneLoadBalancer = nil
+	}
+	// WARNING: in.APIServerAllowedCIDRBlocks requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodePortAllowedCIDRBlocks requires manual conversion: does not exist in peer-type
+	// WARNING: in.Bastion requires manual conversion: does not exist in peer-type
+	// WARNING: in.ImageLookupOrg requires manual conversion: does not exist in peer-type
+	// WARNING: in.IdentityRef requires manual conversion: does not exist in peer-type
+	// WARNING: in.RoleIdentity requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha2_AWSClusterStatus_To_v1alpha3_AWSClusterStatus(in *AWSClusterStatus, out *v1alpha3.AWSClusterStatus, s conversion.Scope) error {
+	if err := Convert_v1alpha2_Network_To_v1alpha3_Network(&in.Network, &out.Network, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha2_Instance_To_v1alpha3_Instance(&in.Bastion, &out.Bastion, s); err != nil {
+		return err
+	}
+	out.Ready = in.Ready
+	out.APIEndpoints = *(*[]v1alpha3.APIEndpoint)(unsafe.Pointer(&in.APIEndpoints))
+	return nil
+}
+
+// Convert_v1alpha2_AWSClusterStatus_To_v1alpha3_AWSClusterStatus is an autogenerated conversion function.
+func Convert_v1alpha2_AWSClusterStatus_To_v1alpha3_AWSClusterStatus(in *AWSClusterStatus, out *v1alpha3.AWSClusterStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSClusterStatus_To_v1alpha3_AWSClusterStatus(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSClusterStatus_To_v1alpha2_AWSClusterStatus(in *v1alpha3.AWSClusterStatus, out *AWSClusterStatus, s conversion.Scope) error {
+	if err := Convert_v1alpha3_Network_To_v1alpha2_Network(&in.Network, &out.Network, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha3_Instance_To_v1alpha2_Instance(&in.Bastion, &out.Bastion, s); err != nil {
+		return err
+	}
+	out.Ready = in.Ready
+	out.APIEndpoints = *(*[]APIEndpoint)(unsafe.Pointer(&in.APIEndpoints))
+	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha2_AWSLoadBalancerSpec_To_v1alpha3_AWSLoadBalancerSpec(in *AWSLoadBalancerSpec, out *v1alpha3.AWSLoadBalancerSpec, s conversion.Scope) error {
+	out.Scheme = (*v1alpha3.ClassicELBScheme)(unsafe.Pointer(in.Scheme))
+	return nil
+}
+
+// Convert_v1alpha2_AWSLoadBalancerSpec_To_v1alpha3_AWSLoadBalancerSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AWSLoadBalancerSpec_To_v1alpha3_AWSLoadBalancerSpec(in *AWSLoadBalancerSpec, out *v1alpha3.AWSLoadBalancerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSLoadBalancerSpec_To_v1alpha3_AWSLoadBalancerSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec(in *v1alpha3.AWSLoadBalancerSpec, out *AWSLoadBalancerSpec, s conversion.Scope) error {
+	out.Scheme = (*ClassicELBScheme)(unsafe.Pointer(in.Scheme))
+	// WARNING: in.LoadBalancerType requires manual conversion: does not exist in peer-type
+	// WARNING: in.CrossZoneLoadBalancing requires manual conversion: does not exist in peer-type
+	// WARNING: in.ConnectionDrainingTimeoutSeconds requires manual conversion: does not exist in peer-type
+	// WARNING: in.AccessLog requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha2_AWSMachine_To_v1alpha3_AWSMachine(in *AWSMachine, out *v1alpha3.AWSMachine, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha2_AWSMachineSpec_To_v1alpha3_AWSMachineSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha2_AWSMachineStatus_To_v1alpha3_AWSMachineStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AWSMachine_To_v1alpha3_AWSMachine is an autogenerated conversion function.
+func Convert_v1alpha2_AWSMachine_To_v1alpha3_AWSMachine(in *AWSMachine, out *v1alpha3.AWSMachine, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSMachine_To_v1alpha3_AWSMachine(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSMachine_To_v1alpha2_AWSMachine(in *v1alpha3.AWSMachine, out *AWSMachine, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha3_AWSMachineSpec_To_v1alpha2_AWSMachineSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha3_AWSMachineStatus_To_v1alpha2_AWSMachineStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_AWSMachine_To_v1alpha2_AWSMachine is an autogenerated conversion function.
+func Convert_v1alpha3_AWSMachine_To_v1alpha2_AWSMachine(in *v1alpha3.AWSMachine, out *AWSMachine, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AWSMachine_To_v1alpha2_AWSMachine(in, out, s)
+}
+
+func autoConvert_v1alpha2_AWSMachineList_To_v1alpha3_AWSMachineList(in *AWSMachineList, out *v1alpha3.AWSMachineList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha3.AWSMachine, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_AWSMachine_To_v1alpha3_AWSMachine(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AWSMachineList_To_v1alpha3_AWSMachineList is an autogenerated conversion function.
+func Convert_v1alpha2_AWSMachineList_To_v1alpha3_AWSMachineList(in *AWSMachineList, out *v1alpha3.AWSMachineList, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSMachineList_To_v1alpha3_AWSMachineList(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSMachineList_To_v1alpha2_AWSMachineList(in *v1alpha3.AWSMachineList, out *AWSMachineList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AWSMachine, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_AWSMachine_To_v1alpha2_AWSMachine(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_AWSMachineList_To_v1alpha2_AWSMachineList is an autogenerated conversion function.
+func Convert_v1alpha3_AWSMachineList_To_v1alpha2_AWSMachineList(in *v1alpha3.AWSMachineList, out *AWSMachineList, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AWSMachineList_To_v1alpha2_AWSMachineList(in, out, s)
+}
+
+func autoConvert_v1alpha2_AWSMachineSpec_To_v1alpha3_AWSMachineSpec(in *AWSMachineSpec, out *v1alpha3.AWSMachineSpec, s conversion.Scope) error {
+	out.ProviderID = (*string)(unsafe.Pointer(in.ProviderID))
+	if err := Convert_v1alpha2_AWSResourceReference_To_v1alpha3_AWSResourceReference(&in.AMI, &out.AMI, s); err != nil {
+		return err
+	}
+	out.ImageLookupOrg = in.ImageLookupOrg
+	out.InstanceType = in.InstanceType
+	out.AdditionalTags = *(*v1alpha3.Tags)(unsafe.Pointer(&in.AdditionalTags))
+	out.IAMInstanceProfile = in.IAMInstanceProfile
+	out.PublicIP = (*bool)(unsafe.Pointer(in.PublicIP))
+	out.AdditionalSecurityGroups = *(*[]v1alpha3.AWSResourceReference)(unsafe.Pointer(&in.AdditionalSecurityGroups))
+	out.AvailabilityZone = (*string)(unsafe.Pointer(in.AvailabilityZone))
+	out.Subnet = (*v1alpha3.AWSResourceReference)(unsafe.Pointer(in.Subnet))
+	out.SSHKeyName = in.SSHKeyName
+	out.RootDeviceSize = in.RootDeviceSize
+	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
+	return nil
+}
+
+// Convert_v1alpha2_AWSMachineSpec_To_v1alpha3_AWSMachineSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AWSMachineSpec_To_v1alpha3_AWSMachineSpec(in *AWSMachineSpec, out *v1alpha3.AWSMachineSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSMachineSpec_To_v1alpha3_AWSMachineSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSMachineSpec_To_v1alpha2_AWSMachineSpec(in *v1alpha3.AWSMachineSpec, out *AWSMachineSpec, s conversion.Scope) error {
+	out.ProviderID = (*string)(unsafe.Pointer(in.ProviderID))
+	if err := Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(&in.AMI, &out.AMI, s); err != nil {
+		return err
+	}
+	out.ImageLookupOrg = in.ImageLookupOrg
+	out.InstanceType = in.InstanceType
+	out.AdditionalTags = *(*Tags)(unsafe.Pointer(&in.AdditionalTags))
+	out.IAMInstanceProfile = in.IAMInstanceProfile
+	out.PublicIP = (*bool)(unsafe.Pointer(in.PublicIP))
+	out.AdditionalSecurityGroups = *(*[]AWSResourceReference)(unsafe.Pointer(&in.AdditionalSecurityGroups))
+	out.AvailabilityZone = (*string)(unsafe.Pointer(in.AvailabilityZone))
+	out.Subnet = (*AWSResourceReference)(unsafe.Pointer(in.Subnet))
+	out.SSHKeyName = in.SSHKeyName
+	out.RootDeviceSize = in.RootDeviceSize
+	// WARNING: in.RootVolume requires manual conversion: does not exist in peer-type
+	// WARNING: in.NonRootVolumes requires manual conversion: does not exist in peer-type
+	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
+	// WARNING: in.SpotMarketOptions requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha2_AWSMachineStatus_To_v1alpha3_AWSMachineStatus(in *AWSMachineStatus, out *v1alpha3.AWSMachineStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.Addresses = *(*[]v1.NodeAddress)(unsafe.Pointer(&in.Addresses))
+	out.InstanceState = (*v1alpha3.InstanceState)(unsafe.Pointer(in.InstanceState))
+	out.ErrorReason = (*errors.MachineStatusError)(unsafe.Pointer(in.ErrorReason))
+	out.ErrorMessage = (*string)(unsafe.Pointer(in.ErrorMessage))
+	return nil
+}
+
+// Convert_v1alpha2_AWSMachineStatus_To_v1alpha3_AWSMachineStatus is an autogenerated conversion function.
+func Convert_v1alpha2_AWSMachineStatus_To_v1alpha3_AWSMachineStatus(in *AWSMachineStatus, out *v1alpha3.AWSMachineStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSMachineStatus_To_v1alpha3_AWSMachineStatus(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSMachineStatus_To_v1alpha2_AWSMachineStatus(in *v1alpha3.AWSMachineStatus, out *AWSMachineStatus, s conversion.Scope) error {
+	out.Ready = in.Ready
+	out.Addresses = *(*[]v1.NodeAddress)(unsafe.Pointer(&in.Addresses))
+	out.InstanceState = (*InstanceState)(unsafe.Pointer(in.InstanceState))
+	// WARNING: in.InstanceLifecycle requires manual conversion: does not exist in peer-type
+	out.ErrorReason = (*errors.MachineStatusError)(unsafe.Pointer(in.ErrorReason))
+	out.ErrorMessage = (*string)(unsafe.Pointer(in.ErrorMessage))
+	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha2_AWSMachineTemplate_To_v1alpha3_AWSMachineTemplate(in *AWSMachineTemplate, out *v1alpha3.AWSMachineTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha2_AWSMachineTemplateSpec_To_v1alpha3_AWSMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AWSMachineTemplate_To_v1alpha3_AWSMachineTemplate is an autogenerated conversion function.
+func Convert_v1alpha2_AWSMachineTemplate_To_v1alpha3_AWSMachineTemplate(in *AWSMachineTemplate, out *v1alpha3.AWSMachineTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSMachineTemplate_To_v1alpha3_AWSMachineTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSMachineTemplate_To_v1alpha2_AWSMachineTemplate(in *v1alpha3.AWSMachineTemplate, out *AWSMachineTemplate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha3_AWSMachineTemplateSpec_To_v1alpha2_AWSMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_AWSMachineTemplate_To_v1alpha2_AWSMachineTemplate is an autogenerated conversion function.
+func Convert_v1alpha3_AWSMachineTemplate_To_v1alpha2_AWSMachineTemplate(in *v1alpha3.AWSMachineTemplate, out *AWSMachineTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AWSMachineTemplate_To_v1alpha2_AWSMachineTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha2_AWSMachineTemplateList_To_v1alpha3_AWSMachineTemplateList(in *AWSMachineTemplateList, out *v1alpha3.AWSMachineTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha3.AWSMachineTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_AWSMachineTemplate_To_v1alpha3_AWSMachineTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AWSMachineTemplateList_To_v1alpha3_AWSMachineTemplateList is an autogenerated conversion function.
+func Convert_v1alpha2_AWSMachineTemplateList_To_v1alpha3_AWSMachineTemplateList(in *AWSMachineTemplateList, out *v1alpha3.AWSMachineTemplateList, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSMachineTemplateList_To_v1alpha3_AWSMachineTemplateList(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSMachineTemplateList_To_v1alpha2_AWSMachineTemplateList(in *v1alpha3.AWSMachineTemplateList, out *AWSMachineTemplateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AWSMachineTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_AWSMachineTemplate_To_v1alpha2_AWSMachineTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_AWSMachineTemplateList_To_v1alpha2_AWSMachineTemplateList is an autogenerated conversion function.
+func Convert_v1alpha3_AWSMachineTemplateList_To_v1alpha2_AWSMachineTemplateList(in *v1alpha3.AWSMachineTemplateList, out *AWSMachineTemplateList, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AWSMachineTemplateList_To_v1alpha2_AWSMachineTemplateList(in, out, s)
+}
+
+func autoConvert_v1alpha2_AWSMachineTemplateResource_To_v1alpha3_AWSMachineTemplateResource(in *AWSMachineTemplateResource, out *v1alpha3.AWSMachineTemplateResource, s conversion.Scope) error {
+	if err := Convert_v1alpha2_AWSMachineSpec_To_v1alpha3_AWSMachineSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AWSMachineTemplateResource_To_v1alpha3_AWSMachineTemplateResource is an autogenerated conversion function.
+func Convert_v1alpha2_AWSMachineTemplateResource_To_v1alpha3_AWSMachineTemplateResource(in *AWSMachineTemplateResource, out *v1alpha3.AWSMachineTemplateResource, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSMachineTemplateResource_To_v1alpha3_AWSMachineTemplateResource(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSMachineTemplateResource_To_v1alpha2_AWSMachineTemplateResource(in *v1alpha3.AWSMachineTemplateResource, out *AWSMachineTemplateResource, s conversion.Scope) error {
+	if err := Convert_v1alpha3_AWSMachineSpec_To_v1alpha2_AWSMachineSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_AWSMachineTemplateResource_To_v1alpha2_AWSMachineTemplateResource is an autogenerated conversion function.
+func Convert_v1alpha3_AWSMachineTemplateResource_To_v1alpha2_AWSMachineTemplateResource(in *v1alpha3.AWSMachineTemplateResource, out *AWSMachineTemplateResource, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AWSMachineTemplateResource_To_v1alpha2_AWSMachineTemplateResource(in, out, s)
+}
+
+func autoConvert_v1alpha2_AWSMachineTemplateSpec_To_v1alpha3_AWSMachineTemplateSpec(in *AWSMachineTemplateSpec, out *v1alpha3.AWSMachineTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha2_AWSMachineTemplateResource_To_v1alpha3_AWSMachineTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AWSMachineTemplateSpec_To_v1alpha3_AWSMachineTemplateSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AWSMachineTemplateSpec_To_v1alpha3_AWSMachineTemplateSpec(in *AWSMachineTemplateSpec, out *v1alpha3.AWSMachineTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSMachineTemplateSpec_To_v1alpha3_AWSMachineTemplateSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSMachineTemplateSpec_To_v1alpha2_AWSMachineTemplateSpec(in *v1alpha3.AWSMachineTemplateSpec, out *AWSMachineTemplateSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha3_AWSMachineTemplateResource_To_v1alpha2_AWSMachineTemplateResource(&in.Template, &out.Template, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_AWSMachineTemplateSpec_To_v1alpha2_AWSMachineTemplateSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AWSMachineTemplateSpec_To_v1alpha2_AWSMachineTemplateSpec(in *v1alpha3.AWSMachineTemplateSpec, out *AWSMachineTemplateSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AWSMachineTemplateSpec_To_v1alpha2_AWSMachineTemplateSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_AWSResourceReference_To_v1alpha3_AWSResourceReference(in *AWSResourceReference, out *v1alpha3.AWSResourceReference, s conversion.Scope) error {
+	out.ID = (*string)(unsafe.Pointer(in.ID))
+	out.ARN = (*string)(unsafe.Pointer(in.ARN))
+	out.Filters = *(*[]v1alpha3.Filter)(unsafe.Pointer(&in.Filters))
+	return nil
+}
+
+// Convert_v1alpha2_AWSResourceReference_To_v1alpha3_AWSResourceReference is an autogenerated conversion function.
+func Convert_v1alpha2_AWSResourceReference_To_v1alpha3_AWSResourceReference(in *AWSResourceReference, out *v1alpha3.AWSResourceReference, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSResourceReference_To_v1alpha3_AWSResourceReference(in, out, s)
+}
+
+func autoConvert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(in *v1alpha3.AWSResourceReference, out *AWSResourceReference, s conversion.Scope) error {
+	out.ID = (*string)(unsafe.Pointer(in.ID))
+	out.ARN = (*string)(unsafe.Pointer(in.ARN))
+	out.Filters = *(*[]Filter)(unsafe.Pointer(&in.Filters))
+	return nil
+}
+
+// Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference is an autogenerated conversion function.
+func Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(in *v1alpha3.AWSResourceReference, out *AWSResourceReference, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(in, out, s)
+}
+
+func autoConvert_v1alpha2_BuildParams_To_v1alpha3_BuildParams(in *BuildParams, out *v1alpha3.BuildParams, s conversion.Scope) error {
+	out.Lifecycle = v1alpha3.ResourceLifecycle(in.Lifecycle)
+	out.ClusterName = in.ClusterName
+	out.ResourceID = in.ResourceID
+	out.Name = (*string)(unsafe.Pointer(in.Name))
+	out.Role = (*string)(unsafe.Pointer(in.Role))
+	out.Additional = *(*v1alpha3.Tags)(unsafe.Pointer(&in.Additional))
+	return nil
+}
+
+// Convert_v1alpha2_BuildParams_To_v1alpha3_BuildParams is an autogenerated conversion function.
+func Convert_v1alpha2_BuildParams_To_v1alpha3_BuildParams(in *BuildParams, out *v1alpha3.BuildParams, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BuildParams_To_v1alpha3_BuildParams(in, out, s)
+}
+
+func autoConvert_v1alpha3_BuildParams_To_v1alpha2_BuildParams(in *v1alpha3.BuildParams, out *BuildParams, s conversion.Scope) error {
+	out.Lifecycle = ResourceLifecycle(in.Lifecycle)
+	out.ClusterName = in.ClusterName
+	out.ResourceID = in.ResourceID
+	out.Name = (*string)(unsafe.Pointer(in.Name))
+	out.Role = (*string)(unsafe.Pointer(in.Role))
+	out.Additional = *(*Tags)(unsafe.Pointer(&in.Additional))
+	return nil
+}
+
+// Convert_v1alpha3_BuildParams_To_v1alpha2_BuildParams is an autogenerated conversion function.
+func Convert_v1alpha3_BuildParams_To_v1alpha2_BuildParams(in *v1alpha3.BuildParams, out *BuildParams, s conversion.Scope) error {
+	return autoConvert_v1alpha3_BuildParams_To_v1alpha2_BuildParams(in, out, s)
+}
+
+func autoConvert_v1alpha2_ClassicELB_To_v1alpha3_ClassicELB(in *ClassicELB, out *v1alpha3.ClassicELB, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DNSName = in.DNSName
+	out.Scheme = v1alpha3.ClassicELBScheme(in.Scheme)
+	out.SubnetIDs = *(*[]string)(unsafe.Pointer(&in.SubnetIDs))
+	out.SecurityGroupIDs = *(*[]string)(unsafe.Pointer(&in.SecurityGroupIDs))
+	out.Listeners = *(*[]*v1alpha3.ClassicELBListener)(unsafe.Pointer(&in.Listeners))
+	out.HealthCheck = (*v1alpha3.ClassicELBHealthCheck)(unsafe.Pointer(in.HealthCheck))
+	if err := Convert_v1alpha2_ClassicELBAttributes_To_v1alpha3_ClassicELBAttributes(&in.Attributes, &out.Attributes, s); err != nil {
+		return err
+	}
+	out.Tags = *(*map[string]string)(unsafe.Pointer(&in.Tags))
+	return nil
+}
+
+// Convert_v1alpha2_ClassicELB_To_v1alpha3_ClassicELB is an autogenerated conversion function.
+func Convert_v1alpha2_ClassicELB_To_v1alpha3_ClassicELB(in *ClassicELB, out *v1alpha3.ClassicELB, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ClassicELB_To_v1alpha3_ClassicELB(in, out, s)
+}
+
+func autoConvert_v1alpha3_ClassicELB_To_v1alpha2_ClassicELB(in *v1alpha3.ClassicELB, out *ClassicELB, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DNSName = in.DNSName
+	// WARNING: in.ARN requires manual conversion: does not exist in peer-type
+	// WARNING: in.LoadBalancerType requires manual conversion: does not exist in peer-type
+	out.Scheme = ClassicELBScheme(in.Scheme)
+	// WARNING: in.AvailabilityZones requires manual conversion: does not exist in peer-type
+	out.SubnetIDs = *(*[]string)(unsafe.Pointer(&in.SubnetIDs))
+	out.SecurityGroupIDs = *(*[]string)(unsafe.Pointer(&in.SecurityGroupIDs))
+	out.Listeners = *(*[]*ClassicELBListener)(unsafe.Pointer(&in.Listeners))
+	out.HealthCheck = (*ClassicELBHealthCheck)(unsafe.Pointer(in.HealthCheck))
+	if err := Convert_v1alpha3_ClassicELBAttributes_To_v1alpha2_ClassicELBAttributes(&in.Attributes, &out.Attributes, s); err != nil {
+		return err
+	}
+	out.Tags = *(*map[string]string)(unsafe.Pointer(&in.Tags))
+	return nil
+}
+
+func autoConvert_v1alpha2_ClassicELBAttributes_To_v1alpha3_ClassicELBAttributes(in *ClassicELBAttributes, out *v1alpha3.ClassicELBAttributes, s conversion.Scope) error {
+	out.IdleTimeout = time.Duration(in.IdleTimeout)
+	return nil
+}
+
+// Convert_v1alpha2_ClassicELBAttributes_To_v1alpha3_ClassicELBAttributes is an autogenerated conversion function.
+func Convert_v1alpha2_ClassicELBAttributes_To_v1alpha3_ClassicELBAttributes(in *ClassicELBAttributes, out *v1alpha3.ClassicELBAttributes, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ClassicELBAttributes_To_v1alpha3_ClassicELBAttributes(in, out, s)
+}
+
+func autoConvert_v1alpha3_ClassicELBAttributes_To_v1alpha2_ClassicELBAttributes(in *v1alpha3.ClassicELBAttributes, out *ClassicELBAttributes, s conversion.Scope) error {
+	out.IdleTimeout = time.Duration(in.IdleTimeout)
+	// WARNING: in.CrossZoneLoadBalancing requires manual conversion: does not exist in peer-type
+	// WARNING: in.ConnectionDrainingTimeout requires manual conversion: does not exist in peer-type
+	// WARNING: in.AccessLog requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha2_ClassicELBHealthCheck_To_v1alpha3_ClassicELBHealthCheck(in *ClassicELBHealthCheck, out *v1alpha3.ClassicELBHealthCheck, s conversion.Scope) error {
+	out.Target = in.Target
+	out.Interval = time.Duration(in.Interval)
+	out.Timeout = time.Duration(in.Timeout)
+	out.HealthyThreshold = in.HealthyThreshold
+	out.UnhealthyThreshold = in.UnhealthyThreshold
+	return nil
+}
+
+// Convert_v1alpha2_ClassicELBHealthCheck_To_v1alpha3_ClassicELBHealthCheck is an autogenerated conversion function.
+func Convert_v1alpha2_ClassicELBHealthCheck_To_v1alpha3_ClassicELBHealthCheck(in *ClassicELBHealthCheck, out *v1alpha3.ClassicELBHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ClassicELBHealthCheck_To_v1alpha3_ClassicELBHealthCheck(in, out, s)
+}
+
+func autoConvert_v1alpha3_ClassicELBHealthCheck_To_v1alpha2_ClassicELBHealthCheck(in *v1alpha3.ClassicELBHealthCheck, out *ClassicELBHealthCheck, s conversion.Scope) error {
+	out.Target = in.Target
+	out.Interval = time.Duration(in.Interval)
+	out.Timeout = time.Duration(in.Timeout)
+	out.HealthyThreshold = in.HealthyThreshold
+	out.UnhealthyThreshold = in.UnhealthyThreshold
+	return nil
+}
+
+// Convert_v1alpha3_ClassicELBHealthCheck_To_v1alpha2_ClassicELBHealthCheck is an autogenerated conversion function.
+func Convert_v1alpha3_ClassicELBHealthCheck_To_v1alpha2_ClassicELBHealthCheck(in *v1alpha3.ClassicELBHealthCheck, out *ClassicELBHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ClassicELBHealthCheck_To_v1alpha2_ClassicELBHealthCheck(in, out, s)
+}
+
+func autoConvert_v1alpha2_ClassicELBListener_To_v1alpha3_ClassicELBListener(in *ClassicELBListener, out *v1alpha3.ClassicELBListener, s conversion.Scope) error {
+	out.Protocol = v1alpha3.ClassicELBProtocol(in.Protocol)
+	out.Port = in.Port
+	out.InstanceProtocol = v1alpha3.ClassicELBProtocol(in.InstanceProtocol)
+	out.InstancePort = in.InstancePort
+	return nil
+}
+
+// Convert_v1alpha2_ClassicELBListener_To_v1alpha3_ClassicELBListener is an autogenerated conversion function.
+func Convert_v1alpha2_ClassicELBListener_To_v1alpha3_ClassicELBListener(in *ClassicELBListener, out *v1alpha3.ClassicELBListener, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ClassicELBListener_To_v1alpha3_ClassicELBListener(in, out, s)
+}
+
+func autoConvert_v1alpha3_ClassicELBListener_To_v1alpha2_ClassicELBListener(in *v1alpha3.ClassicELBListener, out *ClassicELBListener, s conversion.Scope) error {
+	out.Protocol = ClassicELBProtocol(in.Protocol)
+	out.Port = in.Port
+	out.InstanceProtocol = ClassicELBProtocol(in.InstanceProtocol)
+	out.InstancePort = in.InstancePort
+	return nil
+}
+
+// Convert_v1alpha3_ClassicELBListener_To_v1alpha2_ClassicELBListener is an autogenerated conversion function.
+func Convert_v1alpha3_ClassicELBListener_To_v1alpha2_ClassicELBListener(in *v1alpha3.ClassicELBListener, out *ClassicELBListener, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ClassicELBListener_To_v1alpha2_ClassicELBListener(in, out, s)
+}
+
+func autoConvert_v1alpha2_Filter_To_v1alpha3_Filter(in *Filter, out *v1alpha3.Filter, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	return nil
+}
+
+// Convert_v1alpha2_Filter_To_v1alpha3_Filter is an autogenerated conversion function.
+func Convert_v1alpha2_Filter_To_v1alpha3_Filter(in *Filter, out *v1alpha3.Filter, s conversion.Scope) error {
+	return autoConvert_v1alpha2_Filter_To_v1alpha3_Filter(in, out, s)
+}
+
+func autoConvert_v1alpha3_Filter_To_v1alpha2_Filter(in *v1alpha3.Filter, out *Filter, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	return nil
+}
+
+// Convert_v1alpha3_Filter_To_v1alpha2_Filter is an autogenerated conversion function.
+func Convert_v1alpha3_Filter_To_v1alpha2_Filter(in *v1alpha3.Filter, out *Filter, s conversion.Scope) error {
+	return autoConvert_v1alpha3_Filter_To_v1alpha2_Filter(in, out, s)
+}
+
+func autoConvert_v1alpha2_IngressRule_To_v1alpha3_IngressRule(in *IngressRule, out *v1alpha3.IngressRule, s conversion.Scope) error {
+	out.Description = in.Description
+	out.Protocol = v1alpha3.SecurityGroupProtocol(in.Protocol)
+	out.FromPort = in.FromPort
+	out.ToPort = in.ToPort
+	out.CidrBlocks = *(*[]string)(unsafe.Pointer(&in.CidrBlocks))
+	out.SourceSecurityGroupIDs = *(*[]string)(unsafe.Pointer(&in.SourceSecurityGroupIDs))
+	return nil
+}
+
+// Convert_v1alpha2_IngressRule_To_v1alpha3_IngressRule is an autogenerated conversion function.
+func Convert_v1alpha2_IngressRule_To_v1alpha3_IngressRule(in *IngressRule, out *v1alpha3.IngressRule, s conversion.Scope) error {
+	return autoConvert_v1alpha2_IngressRule_To_v1alpha3_IngressRule(in, out, s)
+}
+
+func autoConvert_v1alpha3_IngressRule_To_v1alpha2_IngressRule(in *v1alpha3.IngressRule, out *IngressRule, s conversion.Scope) error {
+	out.Description = in.Description
+	out.Protocol = SecurityGroupProtocol(in.Protocol)
+	out.FromPort = in.FromPort
+	out.ToPort = in.ToPort
+	out.CidrBlocks = *(*[]string)(unsafe.Pointer(&in.CidrBlocks))
+	// WARNING: in.IPv6CidrBlocks requires manual conversion: does not exist in peer-type
+	out.SourceSecurityGroupIDs = *(*[]string)(unsafe.Pointer(&in.SourceSecurityGroupIDs))
+	return nil
+}
+
+func autoConvert_v1alpha2_Instance_To_v1alpha3_Instance(in *Instance, out *v1alpha3.Instance, s conversion.Scope) error {
+	out.ID = in.ID
+	out.State = v1alpha3.InstanceState(in.State)
+	out.Type = in.Type
+	out.SubnetID = in.SubnetID
+	out.ImageID = in.ImageID
+	out.SSHKeyName = (*string)(unsafe.Pointer(in.SSHKeyName))
+	out.SecurityGroupIDs = *(*[]string)(unsafe.Pointer(&in.SecurityGroupIDs))
+	out.UserData = (*string)(unsafe.Pointer(in.UserData))
+	out.IAMProfile = in.IAMProfile
+	out.PrivateIP = (*string)(unsafe.Pointer(in.PrivateIP))
+	out.PublicIP = (*string)(unsafe.Pointer(in.PublicIP))
+	out.ENASupport = (*bool)(unsafe.Pointer(in.ENASupport))
+	out.EBSOptimized = (*bool)(unsafe.Pointer(in.EBSOptimized))
+	out.RootDeviceSize = in.RootDeviceSize
+	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
+	out.Tags = *(*map[string]string)(unsafe.Pointer(&in.Tags))
+	return nil
+}
+
+// Convert_v1alpha2_Instance_To_v1alpha3_Instance is an autogenerated conversion function.
+func Convert_v1alpha2_Instance_To_v1alpha3_Instance(in *Instance, out *v1alpha3.Instance, s conversion.Scope) error {
+	return autoConvert_v1alpha2_Instance_To_v1alpha3_Instance(in, out, s)
+}
+
+func autoConvert_v1alpha3_Instance_To_v1alpha2_Instance(in *v1alpha3.Instance, out *Instance, s conversion.Scope) error {
+	out.ID = in.ID
+	out.State = InstanceState(in.State)
+	// WARNING: in.StateReason requires manual conversion: does not exist in peer-type
+	// WARNING: in.Lifecycle requires manual conversion: does not exist in peer-type
+	// WARNING: in.SpotMarketOptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.SpotInstanceRequestID requires manual conversion: does not exist in peer-type
+	out.Type = in.Type
+	out.SubnetID = in.SubnetID
+	out.ImageID = in.ImageID
+	out.SSHKeyName = (*string)(unsafe.Pointer(in.SSHKeyName))
+	out.SecurityGroupIDs = *(*[]string)(unsafe.Pointer(&in.SecurityGroupIDs))
+	out.UserData = (*string)(unsafe.Pointer(in.UserData))
+	out.IAMProfile = in.IAMProfile
+	out.PrivateIP = (*string)(unsafe.Pointer(in.PrivateIP))
+	out.PublicIP = (*string)(unsafe.Pointer(in.PublicIP))
+	out.ENASupport = (*bool)(unsafe.Pointer(in.ENASupport))
+	out.EBSOptimized = (*bool)(unsafe.Pointer(in.EBSOptimized))
+	out.RootDeviceSize = in.RootDeviceSize
+	// WARNING: in.RootVolume requires manual conversion: does not exist in peer-type
+	// WARNING: in.NonRootVolumes requires manual conversion: does not exist in peer-type
+	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
+	out.Tags = *(*map[string]string)(unsafe.Pointer(&in.Tags))
+	return nil
+}
+
+func autoConvert_v1alpha2_Network_To_v1alpha3_Network(in *Network, out *v1alpha3.Network, s conversion.Scope) error {
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make(map[v1alpha3.SecurityGroupRole]v1alpha3.SecurityGroup, len(*in))
+		for key, val := range *in {
+			newVal := new(v1alpha3.SecurityGroup)
+			if err := Convert_v1alpha2_SecurityGroup_To_v1alpha3_SecurityGroup(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[v1alpha3.SecurityGroupRole(key)] = *newVal
+		}
+	} else {
+		out.SecurityGroups = nil
+	}
+	if err := Convert_v1alpha2_ClassicELB_To_v1alpha3_ClassicELB(&in.APIServerELB, &out.APIServerELB, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_Network_To_v1alpha3_Network is an autogenerated conversion function.
+func Convert_v1alpha2_Network_To_v1alpha3_Network(in *Network, out *v1alpha3.Network, s conversion.Scope) error {
+	return autoConvert_v1alpha2_Network_To_v1alpha3_Network(in, out, s)
+}
+
+func autoConvert_v1alpha3_Network_To_v1alpha2_Network(in *v1alpha3.Network, out *Network, s conversion.Scope) error {
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make(map[SecurityGroupRole]SecurityGroup, len(*in))
+		for key, val := range *in {
+			newVal := new(SecurityGroup)
+			if err := Convert_v1alpha3_SecurityGroup_To_v1alpha2_SecurityGroup(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[SecurityGroupRole(key)] = *newVal
+		}
+	} else {
+		out.SecurityGroups = nil
+	}
+	if err := Convert_v1alpha3_ClassicELB_To_v1alpha2_ClassicELB(&in.APIServerELB, &out.APIServerELB, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_Network_To_v1alpha2_Network is an autogenerated conversion function.
+func Convert_v1alpha3_Network_To_v1alpha2_Network(in *v1alpha3.Network, out *Network, s conversion.Scope) error {
+	return autoConvert_v1alpha3_Network_To_v1alpha2_Network(in, out, s)
+}
+
+func autoConvert_v1alpha2_NetworkSpec_To_v1alpha3_NetworkSpec(in *NetworkSpec, out *v1alpha3.NetworkSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha2_VPCSpec_To_v1alpha3_VPCSpec(&in.VPC, &out.VPC, s); err != nil {
+		return err
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(v1alpha3.Subnets, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Subnets = nil
+	}
+	return nil
+}
+
+func autoConvert_v1alpha3_NetworkSpec_To_v1alpha2_NetworkSpec(in *v1alpha3.NetworkSpec, out *NetworkSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(&in.VPC, &out.VPC, s); err != nil {
+		return err
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(Subnets, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Subnets = nil
+	}
+	// WARNING: in.CNI requires manual conversion: does not exist in peer-type
+	// WARNING: in.SecurityGroupOverrides requires manual conversion: does not exist in peer-type
+	// WARNING: in.Isolated requires manual conversion: does not exist in peer-type
+	// WARNING: in.NatGatewayStrategy requires manual conversion: does not exist in peer-type
+	// WARNING: in.TransitGateway requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha2_RouteTable_To_v1alpha3_RouteTable(in *RouteTable, out *v1alpha3.RouteTable, s conversion.Scope) error {
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_v1alpha2_RouteTable_To_v1alpha3_RouteTable is an autogenerated conversion function.
+func Convert_v1alpha2_RouteTable_To_v1alpha3_RouteTable(in *RouteTable, out *v1alpha3.RouteTable, s conversion.Scope) error {
+	return autoConvert_v1alpha2_RouteTable_To_v1alpha3_RouteTable(in, out, s)
+}
+
+func autoConvert_v1alpha3_RouteTable_To_v1alpha2_RouteTable(in *v1alpha3.RouteTable, out *RouteTable, s conversion.Scope) error {
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_v1alpha3_RouteTable_To_v1alpha2_RouteTable is an autogenerated conversion function.
+func Convert_v1alpha3_RouteTable_To_v1alpha2_RouteTable(in *v1alpha3.RouteTable, out *RouteTable, s conversion.Scope) error {
+	return autoConvert_v1alpha3_RouteTable_To_v1alpha2_RouteTable(in, out, s)
+}
+
+func autoConvert_v1alpha2_SecurityGroup_To_v1alpha3_SecurityGroup(in *SecurityGroup, out *v1alpha3.SecurityGroup, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Name = in.Name
+	if in.IngressRules != nil {
+		in, out := &in.IngressRules, &out.IngressRules
+		*out = make(v1alpha3.IngressRules, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IngressRules = nil
+	}
+	out.Tags = *(*v1alpha3.Tags)(unsafe.Pointer(&in.Tags))
+	return nil
+}
+
+func autoConvert_v1alpha3_SecurityGroup_To_v1alpha2_SecurityGroup(in *v1alpha3.SecurityGroup, out *SecurityGroup, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Name = in.Name
+	if in.IngressRules != nil {
+		in, out := &in.IngressRules, &out.IngressRules
+		*out = make(IngressRules, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IngressRules = nil
+	}
+	out.Tags = *(*Tags)(unsafe.Pointer(&in.Tags))
+	return nil
+}
+
+func autoConvert_v1alpha2_SubnetSpec_To_v1alpha3_SubnetSpec(in *SubnetSpec, out *v1alpha3.SubnetSpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.CidrBlock = in.CidrBlock
+	out.AvailabilityZone = in.AvailabilityZone
+	out.IsPublic = in.IsPublic
+	out.RouteTableID = (*string)(unsafe.Pointer(in.RouteTableID))
+	out.NatGatewayID = (*string)(unsafe.Pointer(in.NatGatewayID))
+	out.Tags = *(*v1alpha3.Tags)(unsafe.Pointer(&in.Tags))
+	return nil
+}
+
+// Convert_v1alpha2_SubnetSpec_To_v1alpha3_SubnetSpec is an autogenerated conversion function.
+func Convert_v1alpha2_SubnetSpec_To_v1alpha3_SubnetSpec(in *SubnetSpec, out *v1alpha3.SubnetSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_SubnetSpec_To_v1alpha3_SubnetSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_SubnetSpec_To_v1alpha2_SubnetSpec(in *v1alpha3.SubnetSpec, out *SubnetSpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.CidrBlock = in.CidrBlock
+	// WARNING: in.IPv6CidrBlock requires manual conversion: does not exist in peer-type
+	out.AvailabilityZone = in.AvailabilityZone
+	out.IsPublic = in.IsPublic
+	// WARNING: in.IsPod requires manual conversion: does not exist in peer-type
+	out.RouteTableID = (*string)(unsafe.Pointer(in.RouteTableID))
+	out.NatGatewayID = (*string)(unsafe.Pointer(in.NatGatewayID))
+	// WARNING: in.Routes requires manual conversion: does not exist in peer-type
+	out.Tags = *(*Tags)(unsafe.Pointer(&in.Tags))
+	return nil
+}
+
+func autoConvert_v1alpha2_VPCSpec_To_v1alpha3_VPCSpec(in *VPCSpec, out *v1alpha3.VPCSpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.CidrBlock = in.CidrBlock
+	out.InternetGatewayID = (*string)(unsafe.Pointer(in.InternetGatewayID))
+	out.Tags = *(*v1alpha3.Tags)(unsafe.Pointer(&in.Tags))
+	return nil
+}
+
+// Convert_v1alpha2_VPCSpec_To_v1alpha3_VPCSpec is an autogenerated conversion function.
+func Convert_v1alpha2_VPCSpec_To_v1alpha3_VPCSpec(in *VPCSpec, out *v1alpha3.VPCSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VPCSpec_To_v1alpha3_VPCSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_VPCSpec_To_v1alpha2_VPCSpec(in *v1alpha3.VPCSpec, out *VPCSpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.CidrBlock = in.CidrBlock
+	// WARNING: in.SecondaryCidrBlocks requires manual conversion: does not exist in peer-type
+	out.InternetGatewayID = (*string)(unsafe.Pointer(in.InternetGatewayID))
+	out.Tags = *(*Tags)(unsafe.Pointer(&in.Tags))
+	// WARNING: in.AvailabilityZoneUsageLimit requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPv6 requires manual conversion: does not exist in peer-type
+	// WARNING: in.Endpoints requires manual conversion: does not exist in peer-type
+	return nil
+}
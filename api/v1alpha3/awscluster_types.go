@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha3
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -26,6 +27,17 @@ const (
 	ClusterFinalizer = "awscluster.infrastructure.cluster.x-k8s.io"
 )
 
+const (
+	// AccessKeyIDKey is the key in an identity Secret holding the AWS access key ID.
+	AccessKeyIDKey = "AccessKeyID"
+
+	// SecretAccessKeyKey is the key in an identity Secret holding the AWS secret access key.
+	SecretAccessKeyKey = "SecretAccessKey"
+
+	// SessionTokenKey is the optional key in an identity Secret holding an AWS session token.
+	SessionTokenKey = "SessionToken"
+)
+
 // AWSClusterSpec defines the desired state of AWSCluster
 type AWSClusterSpec struct {
 	// NetworkSpec encapsulates all things related to AWS network.
@@ -46,11 +58,122 @@ type AWSClusterSpec struct {
 	// +optional
 	ControlPlaneLoadBalancer *AWSLoadBalancerSpec `json:"controlPlaneLoadBalancer,omitempty"`
 
+	// APIServerAllowedCIDRBlocks is the list of IPv4 and IPv6 CIDR blocks allowed
+	// to reach the Kubernetes API server (defaults to any address). The VPC and
+	// the public IPs of its NAT gateways are always allowed, so that the cluster
+	// machines can reach the API server through its load balancer.
+	// +optional
+	APIServerAllowedCIDRBlocks []string `json:"apiServerAllowedCIDRBlocks,omitempty"`
+
+	// NodePortAllowedCIDRBlocks is the list of IPv4 and IPv6 CIDR blocks allowed
+	// to reach the NodePort services of the nodes (defaults to any address).
+	// +optional
+	NodePortAllowedCIDRBlocks []string `json:"nodePortAllowedCIDRBlocks,omitempty"`
+
+	// Bastion is optional configuration for the bastion host used to reach the
+	// machines in the private subnets.
+	// +optional
+	Bastion Bastion `json:"bastion,omitempty"`
+
 	// ImageLookupOrg is the AWS Organization ID to look up machine images when a
 	// machine does not specify an AMI. When set, this will be used for all
 	// cluster machines unless a machine specifies a different ImageLookupOrg.
 	// +optional
 	ImageLookupOrg string `json:"imageLookupOrg,omitempty"`
+
+	// IdentityRef is a reference to a Secret in the same namespace as the AWSCluster
+	// holding the static credentials (AccessKeyID, SecretAccessKey and optionally
+	// SessionToken) used to manage this cluster's AWS resources.
+	// When omitted, the controller's own credentials are used.
+	// +optional
+	IdentityRef *corev1.LocalObjectReference `json:"identityRef,omitempty"`
+
+	// RoleIdentity is an IAM role assumed through STS to manage this cluster's
+	// AWS resources. The credentials from IdentityRef, or the controller's own
+	// credentials, are used to assume the role.
+	// +optional
+	RoleIdentity *AWSRoleIdentity `json:"roleIdentity,omitempty"`
+}
+
+// AWSRoleSpec defines an IAM role to assume through STS.
+type AWSRoleSpec struct {
+	// RoleARN is the Amazon Resource Name of the role to assume.
+	RoleARN string `json:"roleARN"`
+
+	// ExternalID is the external ID required by the role's trust policy, if any.
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// SessionName is the name of the role session (defaults to cluster-api-provider-aws).
+	// +optional
+	SessionName string `json:"sessionName,omitempty"`
+
+	// DurationSeconds is how long the assumed role credentials are valid for.
+	// Credentials are renewed automatically before they expire (defaults to 900).
+	// +kubebuilder:validation:Minimum=900
+	// +kubebuilder:validation:Maximum=43200
+	// +optional
+	DurationSeconds int32 `json:"durationSeconds,omitempty"`
+
+	// SessionTags are passed to the role session, where they can be used in
+	// the role's permission policies and are recorded in CloudTrail. The
+	// role's trust policy must allow sts:TagSession.
+	// +optional
+	SessionTags map[string]string `json:"sessionTags,omitempty"`
+
+	// TransitiveTagKeys are the keys of the session tags that are kept when
+	// the session assumes the next role of the chain.
+	// +optional
+	TransitiveTagKeys []string `json:"transitiveTagKeys,omitempty"`
+}
+
+// AWSRoleIdentity defines the role assumed to manage a cluster's AWS resources.
+type AWSRoleIdentity struct {
+	AWSRoleSpec `json:",inline"`
+
+	// SourceRoles is an optional chain of roles assumed in order before RoleARN,
+	// each one using the credentials of the previous role.
+	// +optional
+	SourceRoles []AWSRoleSpec `json:"sourceRoles,omitempty"`
+}
+
+// Bastion defines the bastion host of a cluster.
+type Bastion struct {
+	// Enabled allows this provider to create a bastion host when the cluster
+	// has private subnets. Set it to false to disable the bastion host entirely,
+	// an existing bastion host is then deleted (defaults to true).
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// InstanceType is the EC2 instance type of the bastion host (defaults to t2.micro).
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// AMI is the ID of the image to run on the bastion host. When omitted, the
+	// latest x86_64 image matching ImageLookupOrg and ImageLookupName is used.
+	// +optional
+	AMI string `json:"ami,omitempty"`
+
+	// ImageLookupOrg is the ID of the AWS account owning the bastion host image
+	// when AMI is omitted (defaults to Canonical's account in the partition of
+	// the cluster region).
+	// +optional
+	ImageLookupOrg string `json:"imageLookupOrg,omitempty"`
+
+	// ImageLookupName is the name pattern of the bastion host image when AMI is
+	// omitted (defaults to ubuntu/images/hvm-ssd/ubuntu-bionic-18.04-amd64-server-*).
+	// +optional
+	ImageLookupName string `json:"imageLookupName,omitempty"`
+
+	// AllowedCIDRBlocks is the list of IPv4 and IPv6 CIDR blocks allowed to
+	// reach the bastion host over SSH (defaults to any address).
+	// +optional
+	AllowedCIDRBlocks []string `json:"allowedCIDRBlocks,omitempty"`
+}
+
+// IsEnabled returns true if a bastion host should be created for the cluster.
+func (b *Bastion) IsEnabled() bool {
+	return b.Enabled == nil || *b.Enabled
 }
 
 // AWSLoadBalancerSpec defines the desired state of an AWS load balancer
@@ -58,6 +181,28 @@ type AWSLoadBalancerSpec struct {
 	// Scheme sets the scheme of the load balancer (defaults to Internet-facing)
 	// +optional
 	Scheme *ClassicELBScheme `json:"scheme,omitempty"`
+
+	// LoadBalancerType sets the type of load balancer fronting the API server,
+	// either a classic ELB or a network load balancer (defaults to classic).
+	// +kubebuilder:validation:Enum=classic;network
+	// +optional
+	LoadBalancerType LoadBalancerType `json:"loadBalancerType,omitempty"`
+
+	// CrossZoneLoadBalancing enables cross-zone load balancing on a classic ELB.
+	// +optional
+	CrossZoneLoadBalancing bool `json:"crossZoneLoadBalancing,omitempty"`
+
+	// ConnectionDrainingTimeoutSeconds enables connection draining on a classic
+	// ELB, keeping the connections to the removed control plane instances open
+	// for at most the given number of seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	ConnectionDrainingTimeoutSeconds *int64 `json:"connectionDrainingTimeoutSeconds,omitempty"`
+
+	// AccessLog enables the delivery of the access logs of a classic ELB to S3.
+	// +optional
+	AccessLog *ClassicELBAccessLog `json:"accessLog,omitempty"`
 }
 
 // AWSClusterStatus defines the observed state of AWSCluster
@@ -68,6 +213,10 @@ type AWSClusterStatus struct {
 	// APIEndpoints represents the endpoints to communicate with the control plane.
 	// +optional
 	APIEndpoints []APIEndpoint `json:"apiEndpoints,omitempty"`
+
+	// Conditions defines current service state of the AWSCluster.
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -98,6 +247,16 @@ type AWSClusterList struct {
 	Items           []AWSCluster `json:"items"`
 }
 
+// GetConditions returns the observations of the operational state of the AWSCluster resource.
+func (r *AWSCluster) GetConditions() Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the AWSCluster to the predescribed Conditions.
+func (r *AWSCluster) SetConditions(conditions Conditions) {
+	r.Status.Conditions = conditions
+}
+
 func init() {
 	SchemeBuilder.Register(&AWSCluster{}, &AWSClusterList{})
 }
@@ -0,0 +1,680 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+func (r *AWSCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+func (r *AWSClusterList) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1alpha3-awscluster,mutating=false,failurePolicy=fail,groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,versions=v1alpha3,name=validation.awscluster.infrastructure.x-k8s.io
+
+var _ webhook.Validator = &AWSCluster{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *AWSCluster) ValidateCreate() error {
+	network := r.Spec.NetworkSpec
+	path := field.NewPath("spec", "networkSpec")
+
+	allErrs := r.validateSubnets(false)
+	allErrs = append(allErrs, r.validateAllowedCIDRBlocks()...)
+	allErrs = append(allErrs, r.validateCNI()...)
+	allErrs = append(allErrs, r.validateSecurityGroupOverrides()...)
+	allErrs = append(allErrs, r.validateVPCEndpoints()...)
+	allErrs = append(allErrs, r.validateIsolated()...)
+	allErrs = append(allErrs, r.validateTransitGateway()...)
+	allErrs = append(allErrs, r.validateSecondaryCidrBlocks()...)
+	allErrs = append(allErrs, r.validateSubnetRoutes()...)
+	allErrs = append(allErrs, r.validateControlPlaneLoadBalancer()...)
+
+	// VPC endpoints, secondary CIDR blocks, subnet routes and transit gateway
+	// attachments are only created in the VPCs managed by the provider.
+	if network.VPC.ID != "" && len(network.VPC.Endpoints) > 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("vpc", "endpoints"), "cannot be set for an existing VPC"))
+	}
+	if network.VPC.ID != "" && len(network.VPC.SecondaryCidrBlocks) > 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("vpc", "secondaryCidrBlocks"), "cannot be set for an existing VPC"))
+	}
+	for i, sn := range network.Subnets {
+		if network.VPC.ID != "" && len(sn.Routes) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("subnets").Index(i).Child("routes"), "cannot be set for an existing VPC"))
+		}
+	}
+	if network.VPC.ID != "" && network.TransitGateway != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("transitGateway"), "cannot be set for an existing VPC"))
+	}
+
+	allErrs = append(allErrs, r.validateManagedSubnetRoles()...)
+
+	return aggregateObjErrors(GroupVersion.WithKind("AWSCluster").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *AWSCluster) ValidateUpdate(old runtime.Object) error {
+	oldAWSCluster := old.(*AWSCluster)
+	path := field.NewPath("spec", "networkSpec")
+
+	var allErrs field.ErrorList
+
+	if r.Spec.Region != oldAWSCluster.Spec.Region {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "region"), "field is immutable"))
+	}
+
+	oldVPCID := oldAWSCluster.Spec.NetworkSpec.VPC.ID
+	if oldVPCID != "" && r.Spec.NetworkSpec.VPC.ID != oldVPCID {
+		allErrs = append(allErrs, field.Forbidden(path.Child("vpc", "id"), "field is immutable once set"))
+	}
+
+	if oldVPCID != "" && r.Spec.NetworkSpec.VPC.IsIPv6Enabled() != oldAWSCluster.Spec.NetworkSpec.VPC.IsIPv6Enabled() {
+		allErrs = append(allErrs, field.Forbidden(path.Child("vpc", "ipv6"), "cannot be added or removed once the VPC exists"))
+	}
+
+	if r.Spec.NetworkSpec.Isolated != oldAWSCluster.Spec.NetworkSpec.Isolated {
+		allErrs = append(allErrs, field.Forbidden(path.Child("isolated"), "field is immutable"))
+	}
+
+	// The route tables of the private subnets are not updated when the NAT
+	// gateways change, so the strategy cannot change either.
+	if natGatewayStrategy(r.Spec.NetworkSpec) != natGatewayStrategy(oldAWSCluster.Spec.NetworkSpec) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("natGatewayStrategy"), "field is immutable"))
+	}
+
+	// The attachment is only created, the transit gateway and the subnets it
+	// goes through cannot change once set.
+	if oldTGW := oldAWSCluster.Spec.NetworkSpec.TransitGateway; oldTGW != nil {
+		tgw := r.Spec.NetworkSpec.TransitGateway
+		if tgw == nil || tgw.ID != oldTGW.ID || !reflect.DeepEqual(tgw.SubnetIDs, oldTGW.SubnetIDs) {
+			allErrs = append(allErrs, field.Forbidden(path.Child("transitGateway"), "only the routes can be changed once set"))
+		}
+	}
+
+	// The scheme of a load balancer cannot be modified, it would have to be
+	// recreated with a new DNS name, while the control plane endpoint is fixed.
+	if controlPlaneLoadBalancerScheme(r.Spec) != controlPlaneLoadBalancerScheme(oldAWSCluster.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "controlPlaneLoadBalancer", "scheme"), "field is immutable"))
+	}
+
+	// Changing the type would create a second load balancer with a new DNS name,
+	// leaving the control plane endpoint on the old one.
+	if controlPlaneLoadBalancerType(r.Spec) != controlPlaneLoadBalancerType(oldAWSCluster.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "controlPlaneLoadBalancer", "loadBalancerType"), "field is immutable"))
+	}
+
+	if !reflect.DeepEqual(r.Spec.NetworkSpec.SecurityGroupOverrides, oldAWSCluster.Spec.NetworkSpec.SecurityGroupOverrides) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("securityGroupOverrides"), "field is immutable"))
+	}
+
+	// A secondary CIDR block cannot be disassociated while subnets use it.
+	secondaryCidrBlocks := make(map[string]struct{}, len(r.Spec.NetworkSpec.VPC.SecondaryCidrBlocks))
+	for _, cidr := range r.Spec.NetworkSpec.VPC.SecondaryCidrBlocks {
+		secondaryCidrBlocks[cidr] = struct{}{}
+	}
+	for _, cidr := range oldAWSCluster.Spec.NetworkSpec.VPC.SecondaryCidrBlocks {
+		_, removed, err := net.ParseCIDR(cidr)
+		if _, ok := secondaryCidrBlocks[cidr]; ok || err != nil {
+			continue
+		}
+		for _, sn := range r.Spec.NetworkSpec.Subnets {
+			if _, snCIDR, err := net.ParseCIDR(sn.CidrBlock); err == nil && cidrContains(removed, snCIDR) {
+				allErrs = append(allErrs, field.Forbidden(path.Child("vpc", "secondaryCidrBlocks"), fmt.Sprintf("%q cannot be removed while used by subnet %q", cidr, sn.CidrBlock)))
+			}
+		}
+	}
+
+	ids := make(map[string]struct{}, len(r.Spec.NetworkSpec.Subnets))
+	for _, sn := range r.Spec.NetworkSpec.Subnets {
+		ids[sn.ID] = struct{}{}
+	}
+	for _, sn := range oldAWSCluster.Spec.NetworkSpec.Subnets {
+		if sn.ID == "" {
+			continue
+		}
+		// The subnets created by the provider are deleted when removed from the spec.
+		if _, ok := ids[sn.ID]; !ok && !isManagedSubnet(oldAWSCluster.Spec.NetworkSpec.VPC, sn) {
+			allErrs = append(allErrs, field.Forbidden(path.Child("subnets"), fmt.Sprintf("subnet %q cannot be changed or removed once set", sn.ID)))
+		}
+	}
+
+	// Subnets with an ID have either been created by the provider or exist in
+	// AWS already, only the ones that are still to be created are validated.
+	allErrs = append(allErrs, r.validateSubnets(true)...)
+	allErrs = append(allErrs, r.validateAllowedCIDRBlocks()...)
+	allErrs = append(allErrs, r.validateCNI()...)
+	allErrs = append(allErrs, r.validateVPCEndpoints()...)
+	allErrs = append(allErrs, r.validateIsolated()...)
+	allErrs = append(allErrs, r.validateTransitGateway()...)
+	allErrs = append(allErrs, r.validateSecondaryCidrBlocks()...)
+	allErrs = append(allErrs, r.validateSubnetRoutes()...)
+	allErrs = append(allErrs, r.validateControlPlaneLoadBalancer()...)
+
+	// Managed subnets removed from the spec are deleted, the remaining ones
+	// must still include a public and a private subnet.
+	allErrs = append(allErrs, r.validateManagedSubnetRoles()...)
+
+	return aggregateObjErrors(GroupVersion.WithKind("AWSCluster").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *AWSCluster) ValidateDelete() error {
+	return nil
+}
+
+// validateSubnets checks that the CIDR blocks of the subnets are valid, contained
+// in the VPC CIDR block or one of its secondary blocks and do not overlap, and
+// that their availability zones belong to the cluster region. If pendingOnly is true, subnets that already
+// have an ID are only used to detect overlaps.
+func (r *AWSCluster) validateSubnets(pendingOnly bool) field.ErrorList {
+	var allErrs field.ErrorList
+	path := field.NewPath("spec", "networkSpec")
+	subnets := r.Spec.NetworkSpec.Subnets
+
+	var vpcCIDR *net.IPNet
+	if cidr := r.Spec.NetworkSpec.VPC.CidrBlock; cidr != "" {
+		var err error
+		if _, vpcCIDR, err = net.ParseCIDR(cidr); err != nil && !pendingOnly {
+			allErrs = append(allErrs, field.Invalid(path.Child("vpc", "cidrBlock"), cidr, "must be a valid CIDR block"))
+		}
+	}
+
+	// Subnets can be carved out of the secondary CIDR blocks as well, the
+	// invalid ones are reported by validateSecondaryCidrBlocks.
+	var secondaryCIDRs []*net.IPNet
+	for _, cidr := range r.Spec.NetworkSpec.VPC.SecondaryCidrBlocks {
+		if _, secondaryCIDR, err := net.ParseCIDR(cidr); err == nil {
+			secondaryCIDRs = append(secondaryCIDRs, secondaryCIDR)
+		}
+	}
+
+	// Parse all the CIDR blocks upfront so that subnets are checked for overlaps
+	// against every other subnet, validated or not.
+	cidrs := make([]*net.IPNet, len(subnets))
+	for i, sn := range subnets {
+		_, cidrs[i], _ = net.ParseCIDR(sn.CidrBlock)
+	}
+	validated := func(i int) bool {
+		return !pendingOnly || subnets[i].ID == ""
+	}
+
+	for i, sn := range subnets {
+		if !validated(i) {
+			continue
+		}
+		snPath := path.Child("subnets").Index(i)
+
+		if r.Spec.Region != "" && sn.AvailabilityZone != "" && !strings.HasPrefix(sn.AvailabilityZone, r.Spec.Region) {
+			allErrs = append(allErrs, field.Invalid(snPath.Child("availabilityZone"), sn.AvailabilityZone, fmt.Sprintf("must be in region %q", r.Spec.Region)))
+		}
+
+		if sn.IPv6CidrBlock != "" {
+			allErrs = append(allErrs, r.validateSubnetIPv6CidrBlock(snPath.Child("ipv6CidrBlock"), sn.IPv6CidrBlock)...)
+		}
+
+		if sn.IsPod && sn.IsPublic {
+			allErrs = append(allErrs, field.Forbidden(snPath.Child("isPod"), "cannot be set on a public subnet"))
+		}
+
+		if sn.CidrBlock == "" {
+			continue
+		}
+
+		cidr := cidrs[i]
+		if cidr == nil {
+			allErrs = append(allErrs, field.Invalid(snPath.Child("cidrBlock"), sn.CidrBlock, "must be a valid CIDR block"))
+			continue
+		}
+
+		if vpcCIDR != nil && !cidrContains(vpcCIDR, cidr) {
+			contained := false
+			for _, secondaryCIDR := range secondaryCIDRs {
+				if cidrContains(secondaryCIDR, cidr) {
+					contained = true
+					break
+				}
+			}
+			if !contained && len(secondaryCIDRs) > 0 {
+				allErrs = append(allErrs, field.Invalid(snPath.Child("cidrBlock"), sn.CidrBlock, "must be contained in the VPC CIDR block or in one of its secondary CIDR blocks"))
+			} else if !contained {
+				allErrs = append(allErrs, field.Invalid(snPath.Child("cidrBlock"), sn.CidrBlock, fmt.Sprintf("must be contained in the VPC CIDR block %q", vpcCIDR)))
+			}
+		}
+
+		for j, other := range cidrs {
+			// Overlaps between two validated subnets are only reported once.
+			if j == i || other == nil || (j > i && validated(j)) {
+				continue
+			}
+			if cidrsOverlap(cidr, other) {
+				allErrs = append(allErrs, field.Invalid(snPath.Child("cidrBlock"), sn.CidrBlock, fmt.Sprintf("overlaps with subnet %d (%q)", j, subnets[j].CidrBlock)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateManagedSubnetRoles checks that a managed VPC has both a public and a
+// private subnet to be usable. The provider creates the default subnets when
+// none are given.
+func (r *AWSCluster) validateManagedSubnetRoles() field.ErrorList {
+	var allErrs field.ErrorList
+	network := r.Spec.NetworkSpec
+	path := field.NewPath("spec", "networkSpec", "subnets")
+
+	if !isManagedVPC(network.VPC) || len(network.Subnets) == 0 {
+		return allErrs
+	}
+
+	if len(network.Subnets.FilterPublic()) == 0 && !network.Isolated {
+		allErrs = append(allErrs, field.Required(path, "at least one public subnet is required for a managed VPC"))
+	}
+	if len(network.Subnets.FilterPrivate()) == 0 {
+		allErrs = append(allErrs, field.Required(path, "at least one private subnet is required for a managed VPC"))
+	}
+
+	return allErrs
+}
+
+// validateSubnetIPv6CidrBlock checks that the IPv6 CIDR block of a subnet is a
+// /64 block of the VPC IPv6 CIDR block.
+func (r *AWSCluster) validateSubnetIPv6CidrBlock(path *field.Path, cidrBlock string) field.ErrorList {
+	vpc := r.Spec.NetworkSpec.VPC
+	if !vpc.IsIPv6Enabled() {
+		return field.ErrorList{field.Forbidden(path, "requires IPv6 to be enabled on the VPC")}
+	}
+
+	ip, cidr, err := net.ParseCIDR(cidrBlock)
+	if err != nil || ip.To4() != nil {
+		return field.ErrorList{field.Invalid(path, cidrBlock, "must be a valid IPv6 CIDR block")}
+	}
+
+	if ones, _ := cidr.Mask.Size(); ones != 64 {
+		return field.ErrorList{field.Invalid(path, cidrBlock, "must be a /64 block")}
+	}
+
+	if _, vpcCIDR, err := net.ParseCIDR(vpc.IPv6.CidrBlock); err == nil && !cidrContains(vpcCIDR, cidr) {
+		return field.ErrorList{field.Invalid(path, cidrBlock, fmt.Sprintf("must be contained in the VPC IPv6 CIDR block %q", vpcCIDR))}
+	}
+
+	return nil
+}
+
+// validateAllowedCIDRBlocks checks that the CIDR blocks allowed to reach the
+// bastion host, the API server and the NodePort services are valid.
+func (r *AWSCluster) validateAllowedCIDRBlocks() field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateCIDRBlocks(field.NewPath("spec", "bastion", "allowedCIDRBlocks"), r.Spec.Bastion.AllowedCIDRBlocks)...)
+	allErrs = append(allErrs, validateCIDRBlocks(field.NewPath("spec", "apiServerAllowedCIDRBlocks"), r.Spec.APIServerAllowedCIDRBlocks)...)
+	allErrs = append(allErrs, validateCIDRBlocks(field.NewPath("spec", "nodePortAllowedCIDRBlocks"), r.Spec.NodePortAllowedCIDRBlocks)...)
+	return allErrs
+}
+
+func validateCIDRBlocks(path *field.Path, cidrBlocks []string) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, cidrBlock := range cidrBlocks {
+		if _, _, err := net.ParseCIDR(cidrBlock); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), cidrBlock, "must be a valid CIDR block"))
+		}
+	}
+	return allErrs
+}
+
+// validateTransitGateway checks that the transit gateway has an ID and that
+// its routes are valid CIDR blocks.
+func (r *AWSCluster) validateTransitGateway() field.ErrorList {
+	var allErrs field.ErrorList
+	tgw := r.Spec.NetworkSpec.TransitGateway
+	if tgw == nil {
+		return allErrs
+	}
+	path := field.NewPath("spec", "networkSpec", "transitGateway")
+
+	if tgw.ID == "" {
+		allErrs = append(allErrs, field.Required(path.Child("id"), "must be a transit gateway ID"))
+	}
+	allErrs = append(allErrs, validateCIDRBlocks(path.Child("routes"), tgw.Routes)...)
+
+	return allErrs
+}
+
+// validateControlPlaneLoadBalancer checks that the classic ELB attributes are
+// only set on a classic ELB and that the access logs go to a bucket.
+func (r *AWSCluster) validateControlPlaneLoadBalancer() field.ErrorList {
+	var allErrs field.ErrorList
+	lb := r.Spec.ControlPlaneLoadBalancer
+	if lb == nil {
+		return allErrs
+	}
+	path := field.NewPath("spec", "controlPlaneLoadBalancer")
+
+	if lb.LoadBalancerType == LoadBalancerTypeNetwork {
+		if lb.CrossZoneLoadBalancing {
+			allErrs = append(allErrs, field.Forbidden(path.Child("crossZoneLoadBalancing"), "only supported by classic load balancers, network load balancers always balance across zones"))
+		}
+		if lb.ConnectionDrainingTimeoutSeconds != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("connectionDrainingTimeoutSeconds"), "only supported by classic load balancers"))
+		}
+		if lb.AccessLog != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("accessLog"), "only supported by classic load balancers"))
+		}
+	}
+
+	if timeout := lb.ConnectionDrainingTimeoutSeconds; timeout != nil && (*timeout < 1 || *timeout > 3600) {
+		allErrs = append(allErrs, field.Invalid(path.Child("connectionDrainingTimeoutSeconds"), *timeout, "must be between 1 and 3600 seconds"))
+	}
+
+	if lb.AccessLog != nil {
+		if lb.AccessLog.S3BucketName == "" {
+			allErrs = append(allErrs, field.Required(path.Child("accessLog", "s3BucketName"), "must be the name of an S3 bucket"))
+		}
+		if interval := lb.AccessLog.EmitInterval; interval != 0 && interval != 5 && interval != 60 {
+			allErrs = append(allErrs, field.NotSupported(path.Child("accessLog", "emitInterval"), interval, []string{"5", "60"}))
+		}
+	}
+
+	return allErrs
+}
+
+// validateSubnetRoutes checks that each route of the subnets has a single valid
+// destination and a single target, and does not replace the default routes or
+// the routes managed with the transit gateway or the VPC endpoints.
+func (r *AWSCluster) validateSubnetRoutes() field.ErrorList {
+	var allErrs field.ErrorList
+	network := r.Spec.NetworkSpec
+	path := field.NewPath("spec", "networkSpec", "subnets")
+
+	for i, sn := range network.Subnets {
+		destinations := make(map[string]struct{}, len(sn.Routes))
+		for j, route := range sn.Routes {
+			routePath := path.Index(i).Child("routes").Index(j)
+
+			switch {
+			case route.DestinationCidrBlock == "" && route.DestinationPrefixListID == "":
+				allErrs = append(allErrs, field.Required(routePath, "a destination CIDR block or prefix list is required"))
+			case route.DestinationCidrBlock != "" && route.DestinationPrefixListID != "":
+				allErrs = append(allErrs, field.Invalid(routePath, route.Destination(), "cannot have both a destination CIDR block and prefix list"))
+			case route.DestinationCidrBlock != "":
+				_, cidr, err := net.ParseCIDR(route.DestinationCidrBlock)
+				if err != nil {
+					allErrs = append(allErrs, field.Invalid(routePath.Child("destinationCidrBlock"), route.DestinationCidrBlock, "must be a valid CIDR block"))
+					break
+				}
+				if ones, _ := cidr.Mask.Size(); ones == 0 && hasDefaultRoute(network, sn, cidr.IP.To4() == nil) {
+					allErrs = append(allErrs, field.Forbidden(routePath.Child("destinationCidrBlock"), "the default route of the subnet is managed by the provider"))
+				}
+			}
+
+			if _, ok := destinations[route.Destination()]; ok {
+				allErrs = append(allErrs, field.Duplicate(routePath, route.Destination()))
+			}
+			destinations[route.Destination()] = struct{}{}
+
+			targets := 0
+			for _, target := range []string{route.TransitGatewayID, route.VPCPeeringConnectionID, route.VPCEndpointID, route.NatGatewayID} {
+				if target != "" {
+					targets++
+				}
+			}
+			if targets != 1 {
+				allErrs = append(allErrs, field.Invalid(routePath, route, "must have exactly one target"))
+			}
+
+			if network.TransitGateway != nil && route.TransitGatewayID != "" && route.TransitGatewayID == network.TransitGateway.ID {
+				allErrs = append(allErrs, field.Forbidden(routePath.Child("transitGatewayId"), "routes to the transit gateway of the network are set with its routes"))
+			}
+			if route.VPCEndpointID != "" && route.DestinationPrefixListID != "" {
+				allErrs = append(allErrs, field.Forbidden(routePath.Child("destinationPrefixListId"), "routes to VPC endpoints must have a destination CIDR block"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// hasDefaultRoute returns true if the provider creates a default route in the
+// route table of the subnet, for IPv6 or IPv4 traffic.
+func hasDefaultRoute(network NetworkSpec, sn *SubnetSpec, ipv6 bool) bool {
+	switch {
+	case network.Isolated:
+		return false
+	case ipv6:
+		return network.VPC.IsIPv6Enabled()
+	}
+	return sn.IsPublic || natGatewayStrategy(network) != NatGatewayStrategyNone
+}
+
+// validateSecondaryCidrBlocks checks that the secondary CIDR blocks of the VPC
+// are IPv4 blocks accepted by AWS, between /16 and /28, that overlap neither
+// the VPC CIDR block nor each other.
+func (r *AWSCluster) validateSecondaryCidrBlocks() field.ErrorList {
+	var allErrs field.ErrorList
+	vpc := r.Spec.NetworkSpec.VPC
+	path := field.NewPath("spec", "networkSpec", "vpc", "secondaryCidrBlocks")
+
+	var cidrs []*net.IPNet
+	if _, vpcCIDR, err := net.ParseCIDR(vpc.CidrBlock); err == nil {
+		cidrs = append(cidrs, vpcCIDR)
+	}
+
+	for i, cidrBlock := range vpc.SecondaryCidrBlocks {
+		ip, cidr, err := net.ParseCIDR(cidrBlock)
+		if err != nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), cidrBlock, "must be a valid IPv4 CIDR block"))
+			continue
+		}
+		if ones, _ := cidr.Mask.Size(); ones < 16 || ones > 28 {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), cidrBlock, "must have a prefix length between /16 and /28"))
+			continue
+		}
+		for _, other := range cidrs {
+			if cidrsOverlap(cidr, other) {
+				allErrs = append(allErrs, field.Invalid(path.Index(i), cidrBlock, fmt.Sprintf("overlaps with the VPC CIDR block %q", other)))
+				break
+			}
+		}
+		cidrs = append(cidrs, cidr)
+	}
+
+	return allErrs
+}
+
+// validateSecurityGroupOverrides checks that the security groups are provided
+// for known roles, in an existing VPC.
+func (r *AWSCluster) validateSecurityGroupOverrides() field.ErrorList {
+	var allErrs field.ErrorList
+	overrides := r.Spec.NetworkSpec.SecurityGroupOverrides
+	if len(overrides) == 0 {
+		return allErrs
+	}
+	path := field.NewPath("spec", "networkSpec", "securityGroupOverrides")
+
+	if r.Spec.NetworkSpec.VPC.ID == "" {
+		allErrs = append(allErrs, field.Forbidden(path, "requires an existing VPC"))
+	}
+
+	for role, id := range overrides {
+		switch role {
+		case SecurityGroupBastion, SecurityGroupControlPlane, SecurityGroupNode, SecurityGroupLB:
+		default:
+			allErrs = append(allErrs, field.NotSupported(path, string(role), []string{
+				string(SecurityGroupBastion), string(SecurityGroupControlPlane), string(SecurityGroupNode), string(SecurityGroupLB),
+			}))
+		}
+		if id == "" {
+			allErrs = append(allErrs, field.Required(path.Key(string(role)), "must be a security group ID"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateIsolated checks that an isolated network has no public subnets, no
+// NAT gateways, no bastion host and no internet-facing API server load balancer.
+func (r *AWSCluster) validateIsolated() field.ErrorList {
+	var allErrs field.ErrorList
+	if !r.Spec.NetworkSpec.Isolated {
+		return allErrs
+	}
+
+	for i, sn := range r.Spec.NetworkSpec.Subnets {
+		if sn.IsPublic {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "networkSpec", "subnets").Index(i).Child("isPublic"), "public subnets are not allowed in an isolated network"))
+		}
+	}
+
+	if strategy := r.Spec.NetworkSpec.NatGatewayStrategy; strategy != "" && strategy != NatGatewayStrategyNone {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "networkSpec", "natGatewayStrategy"), "NAT gateways are not allowed in an isolated network"))
+	}
+
+	if r.Spec.Bastion.Enabled != nil && *r.Spec.Bastion.Enabled {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "bastion", "enabled"), "a bastion host is not allowed in an isolated network"))
+	}
+
+	if lb := r.Spec.ControlPlaneLoadBalancer; lb != nil && lb.Scheme != nil && *lb.Scheme == ClassicELBSchemeInternetFacing {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "controlPlaneLoadBalancer", "scheme"), "the API server load balancer must be internal in an isolated network"))
+	}
+
+	return allErrs
+}
+
+// controlPlaneLoadBalancerScheme returns the scheme of the API server load
+// balancer, internal in an isolated network and Internet-facing by default.
+func controlPlaneLoadBalancerScheme(spec AWSClusterSpec) ClassicELBScheme {
+	if spec.NetworkSpec.Isolated {
+		return ClassicELBSchemeInternal
+	}
+	if spec.ControlPlaneLoadBalancer != nil && spec.ControlPlaneLoadBalancer.Scheme != nil {
+		return *spec.ControlPlaneLoadBalancer.Scheme
+	}
+	return ClassicELBSchemeInternetFacing
+}
+
+// controlPlaneLoadBalancerType returns the type of the control plane load balancer, defaulting to classic.
+func controlPlaneLoadBalancerType(spec AWSClusterSpec) LoadBalancerType {
+	if spec.ControlPlaneLoadBalancer != nil && spec.ControlPlaneLoadBalancer.LoadBalancerType != "" {
+		return spec.ControlPlaneLoadBalancer.LoadBalancerType
+	}
+	return LoadBalancerTypeClassic
+}
+
+// natGatewayStrategy returns the NAT gateway strategy of the network, defaulting to per-az.
+func natGatewayStrategy(network NetworkSpec) NatGatewayStrategy {
+	if network.NatGatewayStrategy == "" {
+		return NatGatewayStrategyPerAZ
+	}
+	return network.NatGatewayStrategy
+}
+
+// validateVPCEndpoints checks that the VPC endpoints have a service name and
+// that each service is only listed once.
+func (r *AWSCluster) validateVPCEndpoints() field.ErrorList {
+	var allErrs field.ErrorList
+	path := field.NewPath("spec", "networkSpec", "vpc", "endpoints")
+
+	names := make(map[string]struct{}, len(r.Spec.NetworkSpec.VPC.Endpoints))
+	for i, ep := range r.Spec.NetworkSpec.VPC.Endpoints {
+		if ep.ServiceName == "" {
+			allErrs = append(allErrs, field.Required(path.Index(i).Child("serviceName"), "must be set"))
+			continue
+		}
+		if _, ok := names[ep.ServiceName]; ok {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i).Child("serviceName"), ep.ServiceName))
+		}
+		names[ep.ServiceName] = struct{}{}
+	}
+
+	return allErrs
+}
+
+// validateCNI checks that the port ranges of the CNI ingress rules are valid
+// for the protocols using ports.
+func (r *AWSCluster) validateCNI() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.NetworkSpec.CNI == nil {
+		return allErrs
+	}
+	path := field.NewPath("spec", "networkSpec", "cni", "cniIngressRules")
+
+	for i, rule := range r.Spec.NetworkSpec.CNI.CNIIngressRules {
+		if rule.Protocol != SecurityGroupProtocolTCP && rule.Protocol != SecurityGroupProtocolUDP {
+			continue
+		}
+		if rule.FromPort < 0 || rule.FromPort > 65535 {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("fromPort"), rule.FromPort, "must be a valid port"))
+		}
+		if rule.ToPort < rule.FromPort || rule.ToPort > 65535 {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("toPort"), rule.ToPort, "must be a valid port not lower than fromPort"))
+		}
+	}
+
+	return allErrs
+}
+
+// isManagedVPC returns true if the VPC is still to be created by the provider
+// or was created by it, in which case it is tagged as owned by the cluster.
+func isManagedVPC(vpc VPCSpec) bool {
+	if vpc.ID == "" {
+		return true
+	}
+	for key, value := range vpc.Tags {
+		if strings.HasPrefix(key, NameAWSProviderOwned) && ResourceLifecycle(value) == ResourceLifecycleOwned {
+			return true
+		}
+	}
+	return false
+}
+
+// isManagedSubnet returns true if the subnet was created by the provider in the
+// VPC it manages, that is if both are tagged as owned by the same cluster.
+func isManagedSubnet(vpc VPCSpec, sn *SubnetSpec) bool {
+	for key, value := range vpc.Tags {
+		if strings.HasPrefix(key, NameAWSProviderOwned) && ResourceLifecycle(value) == ResourceLifecycleOwned && sn.Tags[key] == value {
+			return true
+		}
+	}
+	return false
+}
+
+// cidrContains returns true if inner is a subset of outer.
+func cidrContains(outer, inner *net.IPNet) bool {
+	outerOnes, _ := outer.Mask.Size()
+	innerOnes, _ := inner.Mask.Size()
+	return outer.Contains(inner.IP) && innerOnes >= outerOnes
+}
+
+// cidrsOverlap returns true if the two CIDR blocks share any address.
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
@@ -0,0 +1,750 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+func TestAWSCluster_ValidateCreate(t *testing.T) {
+	subnetsWithRoutes := func(routes ...RouteSpec) Subnets {
+		return Subnets{
+			{CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a", IsPublic: true},
+			{CidrBlock: "10.0.1.0/24", AvailabilityZone: "us-east-1a", Routes: routes},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		network NetworkSpec
+		bastion Bastion
+		spec    AWSClusterSpec
+		wantErr bool
+	}{
+		{
+			name: "default network",
+		},
+		{
+			name: "managed vpc",
+			network: NetworkSpec{
+				VPC: VPCSpec{CidrBlock: "10.0.0.0/16"},
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24", AvailabilityZone: "us-east-1a"},
+				},
+			},
+		},
+		{
+			name: "unmanaged vpc",
+			network: NetworkSpec{
+				VPC:     VPCSpec{ID: "vpc-1"},
+				Subnets: Subnets{{ID: "subnet-1"}},
+			},
+		},
+		{
+			name:    "invalid vpc cidr",
+			network: NetworkSpec{VPC: VPCSpec{CidrBlock: "10.0.0.0/33"}},
+			wantErr: true,
+		},
+		{
+			name: "invalid subnet cidr",
+			network: NetworkSpec{
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0/24", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subnet outside of the vpc",
+			network: NetworkSpec{
+				VPC: VPCSpec{CidrBlock: "10.0.0.0/16"},
+				Subnets: Subnets{
+					{CidrBlock: "10.1.0.0/24", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subnet larger than the vpc",
+			network: NetworkSpec{
+				VPC: VPCSpec{CidrBlock: "10.0.0.0/16"},
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/8", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "overlapping subnets",
+			network: NetworkSpec{
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/20", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no private subnet",
+			network: NetworkSpec{
+				Subnets: Subnets{{CidrBlock: "10.0.0.0/24", IsPublic: true}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no public subnet",
+			network: NetworkSpec{
+				Subnets: Subnets{{CidrBlock: "10.0.0.0/24"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "dual-stack managed vpc",
+			network: NetworkSpec{
+				VPC: VPCSpec{CidrBlock: "10.0.0.0/16", IPv6: &IPv6{}},
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/24", IPv6CidrBlock: "2001:db8:0:1::/64", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24"},
+				},
+			},
+		},
+		{
+			name: "subnet ipv6 block without ipv6 vpc",
+			network: NetworkSpec{
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/24", IPv6CidrBlock: "2001:db8:0:1::/64", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subnet ipv6 block is not a /64",
+			network: NetworkSpec{
+				VPC: VPCSpec{IPv6: &IPv6{}},
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/24", IPv6CidrBlock: "2001:db8::/56", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subnet ipv6 block outside of the vpc",
+			network: NetworkSpec{
+				VPC: VPCSpec{ID: "vpc-1", IPv6: &IPv6{CidrBlock: "2001:db8::/56"}},
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/24", IPv6CidrBlock: "2001:db9::/64"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "availability zone in another region",
+			network: NetworkSpec{
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-west-2a", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24", AvailabilityZone: "us-east-1a"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cni ingress rules",
+			network: NetworkSpec{
+				CNI: &CNISpec{
+					CNIIngressRules: CNIIngressRules{
+						{Description: "vxlan (cilium)", Protocol: SecurityGroupProtocolUDP, FromPort: 8472, ToPort: 8472},
+						{Description: "health (cilium)", Protocol: SecurityGroupProtocolTCP, FromPort: 4240, ToPort: 4240},
+						{Description: "IP-in-IP", Protocol: SecurityGroupProtocolIPinIP, FromPort: -1, ToPort: 65535},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid cni ingress rule port range",
+			network: NetworkSpec{
+				CNI: &CNISpec{
+					CNIIngressRules: CNIIngressRules{
+						{Description: "vxlan", Protocol: SecurityGroupProtocolUDP, FromPort: 8472, ToPort: 8000},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security group overrides",
+			network: NetworkSpec{
+				VPC: VPCSpec{ID: "vpc-1"},
+				SecurityGroupOverrides: map[SecurityGroupRole]string{
+					SecurityGroupControlPlane: "sg-cp",
+					SecurityGroupNode:         "sg-node",
+				},
+			},
+		},
+		{
+			name: "security group overrides without vpc",
+			network: NetworkSpec{
+				SecurityGroupOverrides: map[SecurityGroupRole]string{SecurityGroupNode: "sg-node"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security group override for unknown role",
+			network: NetworkSpec{
+				VPC:                    VPCSpec{ID: "vpc-1"},
+				SecurityGroupOverrides: map[SecurityGroupRole]string{"worker": "sg-node"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "vpc endpoints",
+			network: NetworkSpec{
+				VPC: VPCSpec{
+					Endpoints: []VPCEndpointSpec{
+						{ServiceName: "s3"},
+						{ServiceName: "com.amazonaws.us-east-1.ecr.api", Type: VPCEndpointTypeInterface},
+					},
+				},
+			},
+		},
+		{
+			name: "vpc endpoints in an existing vpc",
+			network: NetworkSpec{
+				VPC: VPCSpec{ID: "vpc-1", Endpoints: []VPCEndpointSpec{{ServiceName: "s3"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "vpc endpoint without service name",
+			network: NetworkSpec{
+				VPC: VPCSpec{Endpoints: []VPCEndpointSpec{{Type: VPCEndpointTypeGateway}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate vpc endpoints",
+			network: NetworkSpec{
+				VPC: VPCSpec{Endpoints: []VPCEndpointSpec{{ServiceName: "ec2"}, {ServiceName: "ec2"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "isolated network",
+			network: NetworkSpec{
+				Isolated: true,
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a"},
+					{CidrBlock: "10.0.1.0/24", AvailabilityZone: "us-east-1b"},
+				},
+			},
+			bastion: Bastion{Enabled: aws.Bool(false)},
+		},
+		{
+			name: "public subnet in an isolated network",
+			network: NetworkSpec{
+				Isolated: true,
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/24", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "nat gateways in an isolated network",
+			network: NetworkSpec{Isolated: true, NatGatewayStrategy: NatGatewayStrategySingle},
+			wantErr: true,
+		},
+		{
+			name:    "no nat gateways in an isolated network",
+			network: NetworkSpec{Isolated: true, NatGatewayStrategy: NatGatewayStrategyNone},
+		},
+		{
+			name:    "bastion in an isolated network",
+			network: NetworkSpec{Isolated: true},
+			bastion: Bastion{Enabled: aws.Bool(true)},
+			wantErr: true,
+		},
+		{
+			name:    "internet-facing load balancer in an isolated network",
+			network: NetworkSpec{Isolated: true},
+			spec: AWSClusterSpec{
+				ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{Scheme: &ClassicELBSchemeInternetFacing},
+			},
+			wantErr: true,
+		},
+		{
+			name: "transit gateway",
+			network: NetworkSpec{
+				TransitGateway: &TransitGatewaySpec{ID: "tgw-1", Routes: []string{"10.100.0.0/16", "2001:db8::/32"}},
+			},
+		},
+		{
+			name: "transit gateway in an existing vpc",
+			network: NetworkSpec{
+				VPC:            VPCSpec{ID: "vpc-1"},
+				TransitGateway: &TransitGatewaySpec{ID: "tgw-1"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "transit gateway without id",
+			network: NetworkSpec{TransitGateway: &TransitGatewaySpec{Routes: []string{"10.100.0.0/16"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid transit gateway route",
+			network: NetworkSpec{TransitGateway: &TransitGatewaySpec{ID: "tgw-1", Routes: []string{"10.100.0.0"}}},
+			wantErr: true,
+		},
+		{
+			name: "pod subnets in secondary cidr blocks",
+			network: NetworkSpec{
+				VPC: VPCSpec{CidrBlock: "10.0.0.0/16", SecondaryCidrBlocks: []string{"100.64.0.0/16"}},
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24", AvailabilityZone: "us-east-1a"},
+					{CidrBlock: "100.64.0.0/18", AvailabilityZone: "us-east-1a", IsPod: true},
+				},
+			},
+		},
+		{
+			name: "subnet outside the vpc cidr blocks",
+			network: NetworkSpec{
+				VPC: VPCSpec{CidrBlock: "10.0.0.0/16", SecondaryCidrBlocks: []string{"100.64.0.0/16"}},
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a", IsPublic: true},
+					{CidrBlock: "100.65.0.0/18", AvailabilityZone: "us-east-1a"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "only pod private subnets",
+			network: NetworkSpec{
+				VPC: VPCSpec{CidrBlock: "10.0.0.0/16"},
+				Subnets: Subnets{
+					{CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a", IsPublic: true},
+					{CidrBlock: "10.0.1.0/24", AvailabilityZone: "us-east-1a", IsPod: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "public pod subnet",
+			network: NetworkSpec{
+				Subnets: Subnets{{CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a", IsPublic: true, IsPod: true}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "secondary cidr block in an existing vpc",
+			network: NetworkSpec{VPC: VPCSpec{ID: "vpc-1", SecondaryCidrBlocks: []string{"100.64.0.0/16"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid secondary cidr block",
+			network: NetworkSpec{VPC: VPCSpec{SecondaryCidrBlocks: []string{"2001:db8::/56"}}},
+			wantErr: true,
+		},
+		{
+			name:    "secondary cidr block too large",
+			network: NetworkSpec{VPC: VPCSpec{SecondaryCidrBlocks: []string{"100.64.0.0/10"}}},
+			wantErr: true,
+		},
+		{
+			name:    "overlapping secondary cidr blocks",
+			network: NetworkSpec{VPC: VPCSpec{CidrBlock: "10.0.0.0/16", SecondaryCidrBlocks: []string{"100.64.0.0/16", "10.0.128.0/17"}}},
+			wantErr: true,
+		},
+		{
+			name: "subnet routes",
+			network: NetworkSpec{Subnets: subnetsWithRoutes(
+				RouteSpec{DestinationCidrBlock: "10.100.0.0/16", TransitGatewayID: "tgw-2"},
+				RouteSpec{DestinationPrefixListID: "pl-1", VPCPeeringConnectionID: "pcx-1"},
+				RouteSpec{DestinationCidrBlock: "192.168.0.0/16", VPCEndpointID: "vpce-1"},
+			)},
+		},
+		{
+			name: "subnet routes in an existing vpc",
+			network: NetworkSpec{
+				VPC:     VPCSpec{ID: "vpc-1"},
+				Subnets: Subnets{{ID: "subnet-1", Routes: []RouteSpec{{DestinationCidrBlock: "10.100.0.0/16", TransitGatewayID: "tgw-2"}}}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "subnet route without destination",
+			network: NetworkSpec{Subnets: subnetsWithRoutes(RouteSpec{NatGatewayID: "nat-1"})},
+			wantErr: true,
+		},
+		{
+			name: "subnet route with two targets",
+			network: NetworkSpec{Subnets: subnetsWithRoutes(
+				RouteSpec{DestinationCidrBlock: "10.100.0.0/16", TransitGatewayID: "tgw-2", NatGatewayID: "nat-1"},
+			)},
+			wantErr: true,
+		},
+		{
+			name: "duplicate subnet route destinations",
+			network: NetworkSpec{Subnets: subnetsWithRoutes(
+				RouteSpec{DestinationCidrBlock: "10.100.0.0/16", TransitGatewayID: "tgw-2"},
+				RouteSpec{DestinationCidrBlock: "10.100.0.0/16", VPCPeeringConnectionID: "pcx-1"},
+			)},
+			wantErr: true,
+		},
+		{
+			name: "subnet route to the network transit gateway",
+			network: NetworkSpec{
+				TransitGateway: &TransitGatewaySpec{ID: "tgw-1"},
+				Subnets:        subnetsWithRoutes(RouteSpec{DestinationCidrBlock: "10.100.0.0/16", TransitGatewayID: "tgw-1"}),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "subnet route replacing the default route",
+			network: NetworkSpec{Subnets: subnetsWithRoutes(RouteSpec{DestinationCidrBlock: "0.0.0.0/0", TransitGatewayID: "tgw-2"})},
+			wantErr: true,
+		},
+		{
+			name: "default subnet route without nat gateways",
+			network: NetworkSpec{
+				NatGatewayStrategy: NatGatewayStrategyNone,
+				Subnets:            subnetsWithRoutes(RouteSpec{DestinationCidrBlock: "0.0.0.0/0", TransitGatewayID: "tgw-2"}),
+			},
+		},
+		{
+			name: "subnet route to a vpc endpoint with a prefix list",
+			network: NetworkSpec{Subnets: subnetsWithRoutes(
+				RouteSpec{DestinationPrefixListID: "pl-1", VPCEndpointID: "vpce-1"},
+			)},
+			wantErr: true,
+		},
+		{
+			name: "classic load balancer attributes",
+			spec: AWSClusterSpec{
+				ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+					CrossZoneLoadBalancing:           true,
+					ConnectionDrainingTimeoutSeconds: aws.Int64(300),
+					AccessLog:                        &ClassicELBAccessLog{S3BucketName: "logs", S3BucketPrefix: "apiserver", EmitInterval: 5},
+				},
+			},
+		},
+		{
+			name: "classic load balancer attributes on a network load balancer",
+			spec: AWSClusterSpec{
+				ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{LoadBalancerType: LoadBalancerTypeNetwork, CrossZoneLoadBalancing: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "connection draining timeout too long",
+			spec: AWSClusterSpec{
+				ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{ConnectionDrainingTimeoutSeconds: aws.Int64(3601)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "access log without bucket",
+			spec: AWSClusterSpec{
+				ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{AccessLog: &ClassicELBAccessLog{}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid access log emit interval",
+			spec: AWSClusterSpec{
+				ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{AccessLog: &ClassicELBAccessLog{S3BucketName: "logs", EmitInterval: 10}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "bastion allowed cidr blocks",
+			bastion: Bastion{AllowedCIDRBlocks: []string{"192.168.0.0/16", "2001:db8::/32"}},
+		},
+		{
+			name:    "invalid bastion allowed cidr block",
+			bastion: Bastion{AllowedCIDRBlocks: []string{"192.168.0.0"}},
+			wantErr: true,
+		},
+		{
+			name: "api server and node port allowed cidr blocks",
+			spec: AWSClusterSpec{
+				APIServerAllowedCIDRBlocks: []string{"192.168.0.0/16"},
+				NodePortAllowedCIDRBlocks:  []string{"2001:db8::/32"},
+			},
+		},
+		{
+			name:    "invalid api server allowed cidr block",
+			spec:    AWSClusterSpec{APIServerAllowedCIDRBlocks: []string{"any"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid node port allowed cidr block",
+			spec:    AWSClusterSpec{NodePortAllowedCIDRBlocks: []string{"10.0.0.0/40"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := tc.spec
+			spec.Region = "us-east-1"
+			spec.NetworkSpec = tc.network
+			spec.Bastion = tc.bastion
+			cluster := &AWSCluster{Spec: spec}
+			if err := cluster.ValidateCreate(); (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAWSCluster_ValidateUpdate(t *testing.T) {
+	old := &AWSCluster{
+		Spec: AWSClusterSpec{
+			Region: "us-east-1",
+			NetworkSpec: NetworkSpec{
+				VPC: VPCSpec{ID: "vpc-1", CidrBlock: "10.0.0.0/16"},
+				Subnets: Subnets{
+					{ID: "subnet-1", CidrBlock: "10.0.0.0/24", AvailabilityZone: "us-east-1a", IsPublic: true},
+					{ID: "subnet-2", CidrBlock: "10.0.1.0/24", AvailabilityZone: "us-east-1a"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*AWSCluster)
+		wantErr bool
+	}{
+		{
+			name: "add subnet",
+			mutate: func(c *AWSCluster) {
+				c.Spec.NetworkSpec.Subnets = append(c.Spec.NetworkSpec.Subnets, &SubnetSpec{CidrBlock: "10.0.2.0/24", AvailabilityZone: "us-east-1b"})
+			},
+		},
+		{
+			name: "add overlapping subnet",
+			mutate: func(c *AWSCluster) {
+				c.Spec.NetworkSpec.Subnets = append(Subnets{{CidrBlock: "10.0.1.128/25"}}, c.Spec.NetworkSpec.Subnets...)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "region",
+			mutate:  func(c *AWSCluster) { c.Spec.Region = "us-west-2" },
+			wantErr: true,
+		},
+		{
+			name:    "vpc id",
+			mutate:  func(c *AWSCluster) { c.Spec.NetworkSpec.VPC.ID = "vpc-2" },
+			wantErr: true,
+		},
+		{
+			name:    "enable ipv6",
+			mutate:  func(c *AWSCluster) { c.Spec.NetworkSpec.VPC.IPv6 = &IPv6{} },
+			wantErr: true,
+		},
+		{
+			name:    "isolated",
+			mutate:  func(c *AWSCluster) { c.Spec.NetworkSpec.Isolated = true },
+			wantErr: true,
+		},
+		{
+			name:   "default nat gateway strategy",
+			mutate: func(c *AWSCluster) { c.Spec.NetworkSpec.NatGatewayStrategy = NatGatewayStrategyPerAZ },
+		},
+		{
+			name:    "nat gateway strategy",
+			mutate:  func(c *AWSCluster) { c.Spec.NetworkSpec.NatGatewayStrategy = NatGatewayStrategySingle },
+			wantErr: true,
+		},
+		{
+			name: "security group overrides",
+			mutate: func(c *AWSCluster) {
+				c.Spec.NetworkSpec.SecurityGroupOverrides = map[SecurityGroupRole]string{SecurityGroupNode: "sg-node"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "add vpc endpoint",
+			mutate: func(c *AWSCluster) {
+				c.Spec.NetworkSpec.VPC.Endpoints = []VPCEndpointSpec{{ServiceName: "s3"}}
+			},
+		},
+		{
+			name: "add transit gateway",
+			mutate: func(c *AWSCluster) {
+				c.Spec.NetworkSpec.TransitGateway = &TransitGatewaySpec{ID: "tgw-1"}
+			},
+		},
+		{
+			name: "add secondary cidr block with a pod subnet",
+			mutate: func(c *AWSCluster) {
+				c.Spec.NetworkSpec.VPC.SecondaryCidrBlocks = []string{"100.64.0.0/16"}
+				c.Spec.NetworkSpec.Subnets = append(c.Spec.NetworkSpec.Subnets, &SubnetSpec{CidrBlock: "100.64.0.0/18", AvailabilityZone: "us-east-1a", IsPod: true})
+			},
+		},
+		{
+			name: "default load balancer scheme",
+			mutate: func(c *AWSCluster) {
+				c.Spec.ControlPlaneLoadBalancer = &AWSLoadBalancerSpec{Scheme: &ClassicELBSchemeInternetFacing}
+			},
+		},
+		{
+			name: "load balancer scheme",
+			mutate: func(c *AWSCluster) {
+				c.Spec.ControlPlaneLoadBalancer = &AWSLoadBalancerSpec{Scheme: &ClassicELBSchemeInternal}
+			},
+			wantErr: true,
+		},
+		{
+			name: "default load balancer type",
+			mutate: func(c *AWSCluster) {
+				c.Spec.ControlPlaneLoadBalancer = &AWSLoadBalancerSpec{LoadBalancerType: LoadBalancerTypeClassic}
+			},
+		},
+		{
+			name: "load balancer type",
+			mutate: func(c *AWSCluster) {
+				c.Spec.ControlPlaneLoadBalancer = &AWSLoadBalancerSpec{LoadBalancerType: LoadBalancerTypeNetwork}
+			},
+			wantErr: true,
+		},
+		{
+			name: "load balancer attributes",
+			mutate: func(c *AWSCluster) {
+				c.Spec.ControlPlaneLoadBalancer = &AWSLoadBalancerSpec{CrossZoneLoadBalancing: true, ConnectionDrainingTimeoutSeconds: aws.Int64(60)}
+			},
+		},
+		{
+			name:    "subnet id",
+			mutate:  func(c *AWSCluster) { c.Spec.NetworkSpec.Subnets[0].ID = "subnet-3" },
+			wantErr: true,
+		},
+		{
+			name:    "remove subnet",
+			mutate:  func(c *AWSCluster) { c.Spec.NetworkSpec.Subnets = c.Spec.NetworkSpec.Subnets[:1] },
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := old.DeepCopy()
+			tc.mutate(cluster)
+			if err := cluster.ValidateUpdate(old); (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("transit gateway", func(t *testing.T) {
+		attached := old.DeepCopy()
+		attached.Spec.NetworkSpec.TransitGateway = &TransitGatewaySpec{ID: "tgw-1", SubnetIDs: []string{"subnet-2"}}
+
+		routes := attached.DeepCopy()
+		routes.Spec.NetworkSpec.TransitGateway.Routes = []string{"10.100.0.0/16"}
+		if err := routes.ValidateUpdate(attached); err != nil {
+			t.Fatalf("did not expect error: %v", err)
+		}
+
+		for _, mutate := range []func(*AWSCluster){
+			func(c *AWSCluster) { c.Spec.NetworkSpec.TransitGateway = nil },
+			func(c *AWSCluster) { c.Spec.NetworkSpec.TransitGateway.ID = "tgw-2" },
+			func(c *AWSCluster) { c.Spec.NetworkSpec.TransitGateway.SubnetIDs = []string{"subnet-3"} },
+		} {
+			cluster := attached.DeepCopy()
+			mutate(cluster)
+			if err := cluster.ValidateUpdate(attached); err == nil {
+				t.Fatalf("expected error for %+v", cluster.Spec.NetworkSpec.TransitGateway)
+			}
+		}
+	})
+
+	t.Run("secondary cidr blocks", func(t *testing.T) {
+		secondary := old.DeepCopy()
+		secondary.Spec.NetworkSpec.VPC.SecondaryCidrBlocks = []string{"100.64.0.0/16", "100.65.0.0/16"}
+		secondary.Spec.NetworkSpec.Subnets = append(secondary.Spec.NetworkSpec.Subnets, &SubnetSpec{ID: "subnet-3", CidrBlock: "100.64.0.0/18", AvailabilityZone: "us-east-1a", IsPod: true})
+
+		unused := secondary.DeepCopy()
+		unused.Spec.NetworkSpec.VPC.SecondaryCidrBlocks = []string{"100.64.0.0/16"}
+		if err := unused.ValidateUpdate(secondary); err != nil {
+			t.Fatalf("did not expect error: %v", err)
+		}
+
+		used := secondary.DeepCopy()
+		used.Spec.NetworkSpec.VPC.SecondaryCidrBlocks = []string{"100.65.0.0/16"}
+		if err := used.ValidateUpdate(secondary); err == nil {
+			t.Fatal("expected error when removing a secondary cidr block used by a subnet")
+		}
+	})
+
+	t.Run("managed subnets", func(t *testing.T) {
+		owned := Tags{ClusterTagKey("test"): string(ResourceLifecycleOwned)}
+		managed := old.DeepCopy()
+		managed.Spec.NetworkSpec.VPC.Tags = owned
+		managed.Spec.NetworkSpec.Subnets[1].Tags = owned
+		managed.Spec.NetworkSpec.Subnets = append(managed.Spec.NetworkSpec.Subnets,
+			&SubnetSpec{ID: "subnet-3", CidrBlock: "10.0.2.0/24", AvailabilityZone: "us-east-1b", Tags: owned},
+			&SubnetSpec{ID: "subnet-4", CidrBlock: "10.0.3.0/24", AvailabilityZone: "us-east-1b", IsPublic: true, Tags: owned},
+		)
+
+		removed := managed.DeepCopy()
+		removed.Spec.NetworkSpec.Subnets = Subnets{managed.Spec.NetworkSpec.Subnets[0], managed.Spec.NetworkSpec.Subnets[2]}
+		if err := removed.ValidateUpdate(managed); err != nil {
+			t.Fatalf("did not expect error: %v", err)
+		}
+
+		// The first subnet is not tagged as owned, it was not created by the provider.
+		removed.Spec.NetworkSpec.Subnets = managed.Spec.NetworkSpec.Subnets[1:]
+		if err := removed.ValidateUpdate(managed); err == nil {
+			t.Fatal("expected error when removing a subnet not created by the provider")
+		}
+
+		// The remaining subnets must still include a public one.
+		publicOwned := managed.DeepCopy()
+		publicOwned.Spec.NetworkSpec.Subnets[0].Tags = owned
+		removed = publicOwned.DeepCopy()
+		removed.Spec.NetworkSpec.Subnets = Subnets{publicOwned.Spec.NetworkSpec.Subnets[1], publicOwned.Spec.NetworkSpec.Subnets[2]}
+		if err := removed.ValidateUpdate(publicOwned); err == nil {
+			t.Fatal("expected error when removing every public subnet of a managed VPC")
+		}
+
+		// And a private one.
+		removed.Spec.NetworkSpec.Subnets = Subnets{publicOwned.Spec.NetworkSpec.Subnets[0], publicOwned.Spec.NetworkSpec.Subnets[3]}
+		if err := removed.ValidateUpdate(publicOwned); err == nil {
+			t.Fatal("expected error when removing every private subnet of a managed VPC")
+		}
+	})
+
+	t.Run("controller sets ids", func(t *testing.T) {
+		created := &AWSCluster{Spec: AWSClusterSpec{Region: "us-east-1"}}
+		if err := old.ValidateUpdate(created); err != nil {
+			t.Fatalf("did not expect error: %v", err)
+		}
+	})
+}
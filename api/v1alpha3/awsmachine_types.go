@@ -80,14 +80,29 @@ type AWSMachineSpec struct {
 	SSHKeyName string `json:"sshKeyName,omitempty"`
 
 	// RootDeviceSize is the size of the root volume in gigabytes(GB).
+	// Deprecated: use RootVolume.Size instead. Cannot be set together with RootVolume.
 	// +optional
 	RootDeviceSize int64 `json:"rootDeviceSize,omitempty"`
 
+	// RootVolume encapsulates the configuration options for the root volume.
+	// +optional
+	RootVolume *Volume `json:"rootVolume,omitempty"`
+
+	// NonRootVolumes is a list of additional EBS volumes to attach to the instance,
+	// e.g. to store container images or etcd data on a separate device.
+	// +optional
+	NonRootVolumes []Volume `json:"nonRootVolumes,omitempty"`
+
 	// NetworkInterfaces is a list of ENIs to associate with the instance.
 	// A maximum of 2 may be specified.
 	// +optional
 	// +kubebuilder:validation:MaxItems=2
 	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
+
+	// SpotMarketOptions allows the instance to run on spot capacity.
+	// If not specified, an on-demand instance is used.
+	// +optional
+	SpotMarketOptions *SpotMarketOptions `json:"spotMarketOptions,omitempty"`
 }
 
 // AWSMachineStatus defines the observed state of AWSMachine
@@ -103,6 +118,10 @@ type AWSMachineStatus struct {
 	// +optional
 	InstanceState *InstanceState `json:"instanceState,omitempty"`
 
+	// InstanceLifecycle is whether the AWS instance for this machine is an on-demand or a spot instance.
+	// +optional
+	InstanceLifecycle *InstanceLifecycle `json:"instanceLifecycle,omitempty"`
+
 	// ErrorReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -140,6 +159,10 @@ type AWSMachineStatus struct {
 	// controller's output.
 	// +optional
 	ErrorMessage *string `json:"errorMessage,omitempty"`
+
+	// Conditions defines current service state of the AWSMachine.
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -170,6 +193,16 @@ type AWSMachineList struct {
 	Items           []AWSMachine `json:"items"`
 }
 
+// GetConditions returns the observations of the operational state of the AWSMachine resource.
+func (r *AWSMachine) GetConditions() Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the AWSMachine to the predescribed Conditions.
+func (r *AWSMachine) SetConditions(conditions Conditions) {
+	r.Status.Conditions = conditions
+}
+
 func init() {
 	SchemeBuilder.Register(&AWSMachine{}, &AWSMachineList{})
 }
@@ -0,0 +1,293 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// MinimumRootDeviceSize is the smallest root volume, in gigabytes, that can be requested for an instance.
+	MinimumRootDeviceSize = 8
+
+	awsMachineDefaultingPath = "/mutate-infrastructure-cluster-x-k8s-io-v1alpha3-awsmachine"
+)
+
+func (r *AWSMachine) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete(); err != nil {
+		return err
+	}
+
+	mgr.GetWebhookServer().Register(awsMachineDefaultingPath, &webhook.Admission{Handler: &awsMachineDefaulter{}})
+	return nil
+}
+
+func (r *AWSMachineList) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1alpha3-awsmachine,mutating=false,failurePolicy=fail,groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,versions=v1alpha3,name=validation.awsmachine.infrastructure.x-k8s.io
+
+var _ webhook.Validator = &AWSMachine{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *AWSMachine) ValidateCreate() error {
+	var allErrs field.ErrorList
+
+	if r.Spec.AvailabilityZone != nil && r.Spec.Subnet != nil && r.Spec.Subnet.ID != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "availabilityZone"), "cannot be set together with spec.subnet.id"))
+	}
+
+	if r.Spec.RootDeviceSize != 0 && r.Spec.RootDeviceSize < MinimumRootDeviceSize {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "rootDeviceSize"), r.Spec.RootDeviceSize, fmt.Sprintf("must be at least %d gigabytes", MinimumRootDeviceSize)))
+	}
+
+	if r.Spec.RootVolume != nil {
+		if r.Spec.RootDeviceSize != 0 {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "rootDeviceSize"), "cannot be set together with spec.rootVolume"))
+		}
+		allErrs = append(allErrs, validateVolume(field.NewPath("spec", "rootVolume"), r.Spec.RootVolume, true)...)
+	}
+
+	deviceNames := make(map[string]struct{}, len(r.Spec.NonRootVolumes))
+	for i := range r.Spec.NonRootVolumes {
+		volume := &r.Spec.NonRootVolumes[i]
+		path := field.NewPath("spec", "nonRootVolumes").Index(i)
+		allErrs = append(allErrs, validateVolume(path, volume, false)...)
+
+		if _, ok := deviceNames[volume.DeviceName]; ok && volume.DeviceName != "" {
+			allErrs = append(allErrs, field.Duplicate(path.Child("deviceName"), volume.DeviceName))
+		}
+		deviceNames[volume.DeviceName] = struct{}{}
+	}
+
+	// Hibernated instances save their memory to the root volume, which must be encrypted.
+	if r.Spec.SpotMarketOptions != nil && r.Spec.SpotMarketOptions.InterruptionBehavior == SpotInterruptionBehaviorHibernate {
+		if r.Spec.RootVolume == nil || r.Spec.RootVolume.Encrypted == nil || !*r.Spec.RootVolume.Encrypted {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "rootVolume", "encrypted"), "must be true when spot instances are hibernated"))
+		}
+	}
+
+	if r.Spec.SpotMarketOptions != nil && r.Spec.SpotMarketOptions.MaxPrice != nil {
+		maxPrice := *r.Spec.SpotMarketOptions.MaxPrice
+		if price, err := strconv.ParseFloat(maxPrice, 64); err != nil || price <= 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "spotMarketOptions", "maxPrice"), maxPrice, "must be a positive decimal number"))
+		}
+	}
+
+	return aggregateObjErrors(GroupVersion.WithKind("AWSMachine").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *AWSMachine) ValidateUpdate(old runtime.Object) error {
+	oldAWSMachine := old.(*AWSMachine)
+	spec := field.NewPath("spec")
+
+	var allErrs field.ErrorList
+
+	if r.Spec.InstanceType != oldAWSMachine.Spec.InstanceType {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("instanceType"), "field is immutable"))
+	}
+
+	if r.Spec.IAMInstanceProfile != oldAWSMachine.Spec.IAMInstanceProfile {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("iamInstanceProfile"), "field is immutable"))
+	}
+
+	if r.Spec.SSHKeyName != oldAWSMachine.Spec.SSHKeyName {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("sshKeyName"), "field is immutable"))
+	}
+
+	if r.Spec.RootDeviceSize != oldAWSMachine.Spec.RootDeviceSize {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("rootDeviceSize"), "field is immutable"))
+	}
+
+	if !reflect.DeepEqual(r.Spec.RootVolume, oldAWSMachine.Spec.RootVolume) {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("rootVolume"), "field is immutable"))
+	}
+
+	if !reflect.DeepEqual(r.Spec.NonRootVolumes, oldAWSMachine.Spec.NonRootVolumes) {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("nonRootVolumes"), "field is immutable"))
+	}
+
+	if !reflect.DeepEqual(r.Spec.Subnet, oldAWSMachine.Spec.Subnet) {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("subnet"), "field is immutable"))
+	}
+
+	if !reflect.DeepEqual(r.Spec.PublicIP, oldAWSMachine.Spec.PublicIP) {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("publicIP"), "field is immutable"))
+	}
+
+	if !reflect.DeepEqual(r.Spec.AMI, oldAWSMachine.Spec.AMI) {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("ami"), "field is immutable"))
+	}
+
+	if !reflect.DeepEqual(r.Spec.SpotMarketOptions, oldAWSMachine.Spec.SpotMarketOptions) {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("spotMarketOptions"), "field is immutable"))
+	}
+
+	return aggregateObjErrors(GroupVersion.WithKind("AWSMachine").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *AWSMachine) ValidateDelete() error {
+	return nil
+}
+
+// validateVolume checks that the options of an EBS volume are supported by its type.
+func validateVolume(path *field.Path, v *Volume, root bool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if root && v.Size < MinimumRootDeviceSize {
+		allErrs = append(allErrs, field.Invalid(path.Child("size"), v.Size, fmt.Sprintf("must be at least %d gigabytes", MinimumRootDeviceSize)))
+	}
+
+	if !root && v.DeviceName == "" {
+		allErrs = append(allErrs, field.Required(path.Child("deviceName"), "is required for non-root volumes"))
+	}
+
+	switch v.Type {
+	case VolumeTypeIO1, VolumeTypeIO2:
+		if v.IOPS == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("iops"), fmt.Sprintf("is required for %s volumes", v.Type)))
+		}
+	case VolumeTypeGP3:
+	default:
+		if v.IOPS != 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("iops"), "is only supported by io1, io2 and gp3 volumes"))
+		}
+	}
+
+	if v.Throughput != nil && v.Type != VolumeTypeGP3 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("throughput"), "is only supported by gp3 volumes"))
+	}
+
+	if v.KMSKeyID != "" && (v.Encrypted == nil || !*v.Encrypted) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("kmsKeyID"), "requires encrypted to be true"))
+	}
+
+	return allErrs
+}
+
+// DefaultFromCluster fills in the fields of the AWSMachine that would otherwise
+// be inherited from the given AWSCluster when the instance is created.
+func (r *AWSMachine) DefaultFromCluster(awsCluster *AWSCluster) {
+	if r.Spec.SSHKeyName == "" {
+		r.Spec.SSHKeyName = awsCluster.Spec.SSHKeyName
+	}
+
+	if r.Spec.AMI.ID == nil && r.Spec.ImageLookupOrg == "" {
+		r.Spec.ImageLookupOrg = awsCluster.Spec.ImageLookupOrg
+	}
+}
+
+// +kubebuilder:webhook:verbs=create,path=/mutate-infrastructure-cluster-x-k8s-io-v1alpha3-awsmachine,mutating=true,failurePolicy=fail,groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,versions=v1alpha3,name=default.awsmachine.infrastructure.x-k8s.io
+
+// awsMachineDefaulter defaults new AWSMachines from the AWSCluster of the
+// cluster they belong to. Defaulting needs to read other objects, so unlike the
+// validation above it can't be implemented with webhook.Defaulter.
+type awsMachineDefaulter struct {
+	client  client.Client
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &awsMachineDefaulter{}
+
+// Handle implements admission.Handler.
+func (h *awsMachineDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	awsMachine := &AWSMachine{}
+	if err := h.decoder.Decode(req, awsMachine); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	awsCluster, err := h.getAWSCluster(ctx, awsMachine)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if awsCluster == nil {
+		return admission.Allowed("")
+	}
+
+	awsMachine.DefaultFromCluster(awsCluster)
+
+	marshalled, err := json.Marshal(awsMachine)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshalled)
+}
+
+// getAWSCluster returns the AWSCluster of the cluster the AWSMachine belongs to,
+// or nil if it doesn't exist yet.
+func (h *awsMachineDefaulter) getAWSCluster(ctx context.Context, awsMachine *AWSMachine) (*AWSCluster, error) {
+	clusterName, ok := awsMachine.Labels[clusterv1.ClusterLabelName]
+	if !ok {
+		return nil, nil
+	}
+
+	cluster := &clusterv1.Cluster{}
+	if err := h.client.Get(ctx, types.NamespacedName{Namespace: awsMachine.Namespace, Name: clusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ref := cluster.Spec.InfrastructureRef
+	if ref == nil || ref.Kind != "AWSCluster" {
+		return nil, nil
+	}
+
+	awsCluster := &AWSCluster{}
+	if err := h.client.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: ref.Name}, awsCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return awsCluster, nil
+}
+
+// InjectClient injects the client into the awsMachineDefaulter.
+func (h *awsMachineDefaulter) InjectClient(c client.Client) error {
+	h.client = c
+	return nil
+}
+
+// InjectDecoder injects the decoder into the awsMachineDefaulter.
+func (h *awsMachineDefaulter) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
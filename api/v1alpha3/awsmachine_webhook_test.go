@@ -0,0 +1,332 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestAWSMachine_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    AWSMachineSpec
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			spec: AWSMachineSpec{
+				InstanceType:     "m5.large",
+				AvailabilityZone: aws.String("us-east-1a"),
+				RootDeviceSize:   20,
+			},
+		},
+		{
+			name: "subnet ID and availability zone",
+			spec: AWSMachineSpec{
+				AvailabilityZone: aws.String("us-east-1a"),
+				Subnet:           &AWSResourceReference{ID: aws.String("subnet-1")},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid spot max price",
+			spec:    AWSMachineSpec{SpotMarketOptions: &SpotMarketOptions{MaxPrice: aws.String("$0.1")}},
+			wantErr: true,
+		},
+		{
+			name: "spot",
+			spec: AWSMachineSpec{SpotMarketOptions: &SpotMarketOptions{MaxPrice: aws.String("0.1")}},
+		},
+		{
+			name: "hibernated spot without encrypted root volume",
+			spec: AWSMachineSpec{
+				RootVolume:        &Volume{Size: 20},
+				SpotMarketOptions: &SpotMarketOptions{InterruptionBehavior: SpotInterruptionBehaviorHibernate},
+			},
+			wantErr: true,
+		},
+		{
+			name: "hibernated spot",
+			spec: AWSMachineSpec{
+				RootVolume:        &Volume{Size: 20, Encrypted: aws.Bool(true)},
+				SpotMarketOptions: &SpotMarketOptions{InterruptionBehavior: SpotInterruptionBehaviorHibernate},
+			},
+		},
+		{
+			name:    "root device too small",
+			spec:    AWSMachineSpec{RootDeviceSize: MinimumRootDeviceSize - 1},
+			wantErr: true,
+		},
+		{
+			name: "volumes",
+			spec: AWSMachineSpec{
+				RootVolume: &Volume{Size: 20, Type: VolumeTypeGP3, IOPS: 4000, Throughput: aws.Int64(250), Encrypted: aws.Bool(true), KMSKeyID: "alias/ebs"},
+				NonRootVolumes: []Volume{
+					{DeviceName: "/dev/sdb", Size: 100, Type: VolumeTypeIO1, IOPS: 1000},
+					{DeviceName: "/dev/sdc", Size: 50},
+				},
+			},
+		},
+		{
+			name: "root volume and root device size",
+			spec: AWSMachineSpec{
+				RootDeviceSize: 20,
+				RootVolume:     &Volume{Size: 20},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "root volume too small",
+			spec:    AWSMachineSpec{RootVolume: &Volume{Size: MinimumRootDeviceSize - 1}},
+			wantErr: true,
+		},
+		{
+			name:    "provisioned iops volume without iops",
+			spec:    AWSMachineSpec{RootVolume: &Volume{Size: 20, Type: VolumeTypeIO2}},
+			wantErr: true,
+		},
+		{
+			name:    "iops on a gp2 volume",
+			spec:    AWSMachineSpec{RootVolume: &Volume{Size: 20, Type: VolumeTypeGP2, IOPS: 1000}},
+			wantErr: true,
+		},
+		{
+			name:    "throughput on a gp2 volume",
+			spec:    AWSMachineSpec{RootVolume: &Volume{Size: 20, Type: VolumeTypeGP2, Throughput: aws.Int64(250)}},
+			wantErr: true,
+		},
+		{
+			name:    "kms key on an unencrypted volume",
+			spec:    AWSMachineSpec{RootVolume: &Volume{Size: 20, KMSKeyID: "alias/ebs"}},
+			wantErr: true,
+		},
+		{
+			name:    "non-root volume without device name",
+			spec:    AWSMachineSpec{NonRootVolumes: []Volume{{Size: 20}}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate non-root device names",
+			spec: AWSMachineSpec{
+				NonRootVolumes: []Volume{
+					{DeviceName: "/dev/sdb", Size: 20},
+					{DeviceName: "/dev/sdb", Size: 40},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			machine := &AWSMachine{Spec: tc.spec}
+			if err := machine.ValidateCreate(); (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAWSMachine_ValidateUpdate(t *testing.T) {
+	base := AWSMachineSpec{
+		ProviderID:         aws.String("aws:////i-1"),
+		InstanceType:       "m5.large",
+		IAMInstanceProfile: "nodes",
+		SSHKeyName:         "default",
+		RootDeviceSize:     20,
+		Subnet:             &AWSResourceReference{ID: aws.String("subnet-1")},
+		PublicIP:           aws.Bool(false),
+		AMI:                AWSResourceReference{ID: aws.String("ami-1")},
+		NonRootVolumes:     []Volume{{DeviceName: "/dev/sdb", Size: 20}},
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*AWSMachineSpec)
+		wantErr bool
+	}{
+		{
+			name:   "mutable fields",
+			mutate: func(s *AWSMachineSpec) { s.ProviderID = aws.String("aws:////i-2"); s.AdditionalTags = Tags{"a": "b"} },
+		},
+		{
+			name:    "instance type",
+			mutate:  func(s *AWSMachineSpec) { s.InstanceType = "m5.xlarge" },
+			wantErr: true,
+		},
+		{
+			name:    "iam instance profile",
+			mutate:  func(s *AWSMachineSpec) { s.IAMInstanceProfile = "control-plane" },
+			wantErr: true,
+		},
+		{
+			name:    "ssh key",
+			mutate:  func(s *AWSMachineSpec) { s.SSHKeyName = "" },
+			wantErr: true,
+		},
+		{
+			name:    "root device size",
+			mutate:  func(s *AWSMachineSpec) { s.RootDeviceSize = 40 },
+			wantErr: true,
+		},
+		{
+			name:    "root volume",
+			mutate:  func(s *AWSMachineSpec) { s.RootVolume = &Volume{Size: 20, Type: VolumeTypeGP3} },
+			wantErr: true,
+		},
+		{
+			name: "non-root volumes",
+			mutate: func(s *AWSMachineSpec) {
+				s.NonRootVolumes = append(s.NonRootVolumes, Volume{DeviceName: "/dev/sdc", Size: 20})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "subnet",
+			mutate:  func(s *AWSMachineSpec) { s.Subnet = &AWSResourceReference{ID: aws.String("subnet-2")} },
+			wantErr: true,
+		},
+		{
+			name:    "public ip",
+			mutate:  func(s *AWSMachineSpec) { s.PublicIP = nil },
+			wantErr: true,
+		},
+		{
+			name:    "spot market options",
+			mutate:  func(s *AWSMachineSpec) { s.SpotMarketOptions = &SpotMarketOptions{} },
+			wantErr: true,
+		},
+		{
+			name:    "ami",
+			mutate:  func(s *AWSMachineSpec) { s.AMI.ID = aws.String("ami-2") },
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			old := &AWSMachine{Spec: *base.DeepCopy()}
+			machine := old.DeepCopy()
+			tc.mutate(&machine.Spec)
+			if err := machine.ValidateUpdate(old); (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAWSMachineDefaulter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{Kind: "AWSCluster", Name: "test"},
+		},
+	}
+	awsCluster := &AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: AWSClusterSpec{
+			SSHKeyName:     "cluster-key",
+			ImageLookupOrg: "123456789012",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		labels        map[string]string
+		spec          AWSMachineSpec
+		patchedKey    string
+		patchedImgOrg string
+	}{
+		{
+			name:          "defaults from the cluster",
+			labels:        map[string]string{clusterv1.ClusterLabelName: "test"},
+			patchedKey:    "cluster-key",
+			patchedImgOrg: "123456789012",
+		},
+		{
+			name:   "keeps machine values",
+			labels: map[string]string{clusterv1.ClusterLabelName: "test"},
+			spec:   AWSMachineSpec{SSHKeyName: "machine-key", AMI: AWSResourceReference{ID: aws.String("ami-1")}},
+		},
+		{
+			name: "no cluster label",
+		},
+		{
+			name:   "unknown cluster",
+			labels: map[string]string{clusterv1.ClusterLabelName: "other"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			decoder, err := admission.NewDecoder(scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := &awsMachineDefaulter{}
+			_ = h.InjectClient(fake.NewFakeClientWithScheme(scheme, cluster.DeepCopy(), awsCluster.DeepCopy()))
+			_ = h.InjectDecoder(decoder)
+
+			machine := &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: "default", Labels: tc.labels},
+				Spec:       tc.spec,
+			}
+			raw, err := json.Marshal(machine)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp := h.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					Operation: admissionv1beta1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+			if !resp.Allowed {
+				t.Fatalf("expected the request to be allowed: %+v", resp.Result)
+			}
+
+			patched := map[string]string{}
+			for _, p := range resp.Patches {
+				if s, ok := p.Value.(string); ok {
+					patched[p.Path] = s
+				}
+			}
+			if patched["/spec/sshKeyName"] != tc.patchedKey {
+				t.Fatalf("expected sshKeyName to be patched to %q, got patches %+v", tc.patchedKey, resp.Patches)
+			}
+			if patched["/spec/imageLookupOrg"] != tc.patchedImgOrg {
+				t.Fatalf("expected imageLookupOrg to be patched to %q, got patches %+v", tc.patchedImgOrg, resp.Patches)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/errors"
+)
+
+const (
+	// MachinePoolFinalizer allows ReconcileAWSMachinePool to clean up AWS resources associated with AWSMachinePool before
+	// removing it from the apiserver.
+	MachinePoolFinalizer = "awsmachinepool.infrastructure.cluster.x-k8s.io"
+)
+
+// AWSMachinePoolSpec defines the desired state of AWSMachinePool
+type AWSMachinePoolSpec struct {
+	// Replicas is the desired number of instances in the pool.
+	// Defaults to MinSize when not set.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// MinSize defines the minimum size of the Auto Scaling Group.
+	// +kubebuilder:validation:Minimum=0
+	MinSize int32 `json:"minSize"`
+
+	// MaxSize defines the maximum size of the Auto Scaling Group.
+	// +kubebuilder:validation:Minimum=1
+	MaxSize int32 `json:"maxSize"`
+
+	// AvailabilityZones is an array of availability zones instances can run in.
+	// Used to select the cluster private subnets when Subnets is not set.
+	// +optional
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
+	// Subnets is an array of references to subnets the Auto Scaling Group should use.
+	// If not specified, the cluster private subnets will be used.
+	// +optional
+	Subnets []AWSResourceReference `json:"subnets,omitempty"`
+
+	// AdditionalTags is an optional set of tags to add to the Auto Scaling Group, the launch template and the
+	// instances, in addition to the ones added by default by the AWS provider. If both the AWSCluster and the
+	// AWSMachinePool specify the same tag name with different values, the AWSMachinePool's value takes precedence.
+	// +optional
+	AdditionalTags Tags `json:"additionalTags,omitempty"`
+
+	// AWSLaunchTemplate specifies the launch template and version to use when an instance is launched.
+	AWSLaunchTemplate AWSLaunchTemplate `json:"awsLaunchTemplate"`
+
+	// Version defines the Kubernetes version used to look up a default AMI
+	// when AWSLaunchTemplate.AMI is not set.
+	// +optional
+	Version *string `json:"version,omitempty"`
+
+	// Bootstrap is a reference to a local struct which encapsulates
+	// fields to configure the instances' bootstrapping mechanism.
+	Bootstrap clusterv1.Bootstrap `json:"bootstrap"`
+}
+
+// AWSLaunchTemplate defines the desired state of the EC2 launch template used by an AWSMachinePool.
+type AWSLaunchTemplate struct {
+	// Name is the name of the launch template. Defaults to the AWSMachinePool name.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// IamInstanceProfile is a name of an IAM instance profile to assign to the instances
+	// +optional
+	IamInstanceProfile string `json:"iamInstanceProfile,omitempty"`
+
+	// AMI is the reference to the AMI from which to create the instances.
+	// +optional
+	AMI AWSResourceReference `json:"ami,omitempty"`
+
+	// ImageLookupOrg is the AWS Organization ID to use for image lookup if AMI is not set.
+	// +optional
+	ImageLookupOrg string `json:"imageLookupOrg,omitempty"`
+
+	// InstanceType is the type of instance to create. Example: m4.xlarge
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// RootDeviceSize is the size of the root volume in gigabytes(GB).
+	// +optional
+	RootDeviceSize int64 `json:"rootDeviceSize,omitempty"`
+
+	// SSHKeyName is the name of the ssh key to attach to the instances.
+	// +optional
+	SSHKeyName string `json:"sshKeyName,omitempty"`
+
+	// AdditionalSecurityGroups is an array of references to security groups that should be applied to the
+	// instances. These security groups would be set in addition to any security groups defined
+	// at the cluster level or in the actuator.
+	// +optional
+	AdditionalSecurityGroups []AWSResourceReference `json:"additionalSecurityGroups,omitempty"`
+}
+
+// AWSMachinePoolStatus defines the observed state of AWSMachinePool
+type AWSMachinePoolStatus struct {
+	// Ready is true when the provider resource is ready.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// Replicas is the most recently observed number of instances in the Auto Scaling Group.
+	// +optional
+	Replicas int32 `json:"replicas"`
+
+	// ReadyReplicas is the number of instances in the Auto Scaling Group that are in service and healthy.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// ProviderIDList is the list of provider IDs of the instances in the Auto Scaling Group.
+	// +optional
+	ProviderIDList []string `json:"providerIDList,omitempty"`
+
+	// Instances contains the status of each instance in the Auto Scaling Group.
+	// +optional
+	Instances []AWSMachinePoolInstanceStatus `json:"instances,omitempty"`
+
+	// LaunchTemplateID is the ID of the launch template managed for this pool.
+	// +optional
+	LaunchTemplateID string `json:"launchTemplateID,omitempty"`
+
+	// LaunchTemplateVersion is the latest version of the launch template used by the Auto Scaling Group.
+	// +optional
+	LaunchTemplateVersion *int64 `json:"launchTemplateVersion,omitempty"`
+
+	// ErrorReason will be set in the event that there is a terminal problem
+	// reconciling the AWSMachinePool and will contain a succinct value suitable
+	// for machine interpretation.
+	// +optional
+	ErrorReason *errors.MachineStatusError `json:"errorReason,omitempty"`
+
+	// ErrorMessage will be set in the event that there is a terminal problem
+	// reconciling the AWSMachinePool and will contain a more verbose string suitable
+	// for logging and human consumption.
+	// +optional
+	ErrorMessage *string `json:"errorMessage,omitempty"`
+}
+
+// AWSMachinePoolInstanceStatus defines the observed status of an instance in an AWSMachinePool.
+type AWSMachinePoolInstanceStatus struct {
+	// InstanceID is the identification of the Machine Instance within the ASG
+	InstanceID string `json:"instanceID"`
+
+	// InstanceState is the lifecycle state of the instance within the Auto Scaling Group.
+	// +optional
+	InstanceState string `json:"instanceState,omitempty"`
+
+	// HealthStatus is the health status reported by the Auto Scaling Group for the instance.
+	// +optional
+	HealthStatus string `json:"healthStatus,omitempty"`
+
+	// Version defines the launch template version used by the instance.
+	// +optional
+	Version *string `json:"version,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=awsmachinepools,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this AWSMachinePool belongs"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Machine pool ready status"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="Number of instances in the Auto Scaling Group"
+// +kubebuilder:printcolumn:name="ReadyReplicas",type="integer",JSONPath=".status.readyReplicas",description="Number of healthy instances in the Auto Scaling Group"
+// +kubebuilder:printcolumn:name="MinSize",type="integer",JSONPath=".spec.minSize",description="Minimum size of the Auto Scaling Group"
+// +kubebuilder:printcolumn:name="MaxSize",type="integer",JSONPath=".spec.maxSize",description="Maximum size of the Auto Scaling Group"
+
+// AWSMachinePool is the Schema for the awsmachinepools API
+type AWSMachinePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AWSMachinePoolSpec   `json:"spec,omitempty"`
+	Status AWSMachinePoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AWSMachinePoolList contains a list of AWSMachinePool
+type AWSMachinePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AWSMachinePool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AWSMachinePool{}, &AWSMachinePoolList{})
+}
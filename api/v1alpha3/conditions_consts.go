@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+// Conditions and condition Reasons for the AWSCluster object.
+
+const (
+	// VPCReadyCondition reports on the successful reconciliation of the VPC.
+	VPCReadyCondition ConditionType = "VPCReady"
+	// VPCReconciliationFailedReason used when errors occur during VPC reconciliation.
+	VPCReconciliationFailedReason = "VPCReconciliationFailed"
+)
+
+const (
+	// SubnetsReadyCondition reports on the successful reconciliation of subnets.
+	SubnetsReadyCondition ConditionType = "SubnetsReady"
+	// SubnetsReconciliationFailedReason used to report failures while reconciling subnets.
+	SubnetsReconciliationFailedReason = "SubnetsReconciliationFailed"
+)
+
+const (
+	// InternetGatewayReadyCondition reports on the successful reconciliation of internet gateways.
+	// Only applicable to managed clusters.
+	InternetGatewayReadyCondition ConditionType = "InternetGatewayReady"
+	// InternetGatewayFailedReason used when errors occur during internet gateway reconciliation.
+	InternetGatewayFailedReason = "InternetGatewayFailed"
+)
+
+const (
+	// EgressOnlyInternetGatewayReadyCondition reports on the successful reconciliation of the egress-only internet gateway.
+	// Only applicable to managed clusters with IPv6 enabled.
+	EgressOnlyInternetGatewayReadyCondition ConditionType = "EgressOnlyInternetGatewayReady"
+	// EgressOnlyInternetGatewayFailedReason used when errors occur during egress-only internet gateway reconciliation.
+	EgressOnlyInternetGatewayFailedReason = "EgressOnlyInternetGatewayFailed"
+)
+
+const (
+	// NatGatewaysReadyCondition reports on the successful reconciliation of NAT gateways.
+	// Only applicable to managed clusters.
+	NatGatewaysReadyCondition ConditionType = "NatGatewaysReady"
+	// NatGatewaysReconciliationFailedReason used when any errors occur during reconciliation of NAT gateways.
+	NatGatewaysReconciliationFailedReason = "NatGatewaysReconciliationFailed"
+)
+
+const (
+	// RouteTablesReadyCondition reports on the successful reconciliation of route tables.
+	// Only applicable to managed clusters.
+	RouteTablesReadyCondition ConditionType = "RouteTablesReady"
+	// RouteTableReconciliationFailedReason used when any errors occur during reconciliation of route tables.
+	RouteTableReconciliationFailedReason = "RouteTableReconciliationFailed"
+)
+
+const (
+	// TransitGatewayAttachmentReadyCondition reports on the successful reconciliation of the transit gateway attachment.
+	// Only applicable to managed clusters attached to a transit gateway.
+	TransitGatewayAttachmentReadyCondition ConditionType = "TransitGatewayAttachmentReady"
+	// TransitGatewayAttachmentFailedReason used when any errors occur during reconciliation of the transit gateway attachment.
+	TransitGatewayAttachmentFailedReason = "TransitGatewayAttachmentFailed"
+)
+
+const (
+	// VPCEndpointsReadyCondition reports on the successful reconciliation of VPC endpoints.
+	// Only applicable to managed clusters with VPC endpoints.
+	VPCEndpointsReadyCondition ConditionType = "VPCEndpointsReady"
+	// VPCEndpointsReconciliationFailedReason used when any errors occur during reconciliation of VPC endpoints.
+	VPCEndpointsReconciliationFailedReason = "VPCEndpointsReconciliationFailed"
+)
+
+const (
+	// SecurityGroupsReadyCondition reports on the successful reconciliation of security groups.
+	SecurityGroupsReadyCondition ConditionType = "SecurityGroupsReady"
+	// SecurityGroupsFailedReason used when any errors occur during reconciliation of security groups.
+	SecurityGroupsFailedReason = "SecurityGroupsReconciliationFailed"
+)
+
+const (
+	// BastionHostReadyCondition reports whether a bastion host is ready. Depending on the configuration, a cluster
+	// may not require a bastion host and this condition will be skipped.
+	BastionHostReadyCondition ConditionType = "BastionHostReady"
+	// BastionCreationStartedReason used when creating a new bastion host.
+	BastionCreationStartedReason = "BastionCreationStarted"
+	// BastionHostFailedReason used when an error occurs during the creation of a bastion host.
+	BastionHostFailedReason = "BastionHostFailed"
+)
+
+const (
+	// LoadBalancerReadyCondition reports on whether a control plane load balancer was successfully reconciled.
+	LoadBalancerReadyCondition ConditionType = "LoadBalancerReady"
+	// WaitForDNSNameReason used while waiting for a DNS name for the API server to be populated.
+	WaitForDNSNameReason = "WaitForDNSName"
+	// LoadBalancerFailedReason used when an error occurs during load balancer reconciliation.
+	LoadBalancerFailedReason = "LoadBalancerFailed"
+)
+
+// Conditions and condition Reasons for the AWSMachine object.
+
+const (
+	// InstanceReadyCondition reports on current status of the EC2 instance. Ready indicates the instance is in a Running state.
+	InstanceReadyCondition ConditionType = "InstanceReady"
+
+	// WaitingForClusterInfrastructureReason used when machine is waiting for cluster infrastructure to be ready before proceeding.
+	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
+	// WaitingForBootstrapDataReason used when machine is waiting for bootstrap data to be ready before proceeding.
+	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+	// InstanceProvisionFailedReason used for failures during instance provisioning.
+	InstanceProvisionFailedReason = "InstanceProvisionFailed"
+	// InstanceNotFoundReason used when the instance couldn't be retrieved.
+	InstanceNotFoundReason = "InstanceNotFound"
+	// InstanceNotReadyReason used when the instance is in a pending state.
+	InstanceNotReadyReason = "InstanceNotReady"
+	// InstanceStoppedReason instance is in a stopped state.
+	InstanceStoppedReason = "InstanceStopped"
+	// InstanceTerminatedReason instance is in a terminated state.
+	InstanceTerminatedReason = "InstanceTerminated"
+	// InstanceSpotInterruptedReason used when a spot instance was stopped or terminated by AWS to reclaim capacity.
+	InstanceSpotInterruptedReason = "InstanceSpotInterrupted"
+)
+
+const (
+	// ELBAttachedCondition will report true when a control plane is successfully registered with an ELB.
+	// When set to false, severity can be an Error if the subnet is not found or unavailable in the instance's AZ.
+	// Note this is only applicable to control plane machines.
+	ELBAttachedCondition ConditionType = "ELBAttached"
+	// ELBAttachFailedReason used when a control plane node fails to attach to the ELB.
+	ELBAttachFailedReason = "ELBAttachFailed"
+)
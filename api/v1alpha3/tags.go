@@ -118,6 +118,9 @@ const (
 
 	// PrivateRoleTagValue describes the value for the private role
 	PrivateRoleTagValue = "private"
+
+	// PodRoleTagValue describes the value for the pod role
+	PodRoleTagValue = "pod"
 )
 
 // ClusterTagKey generates the key for resources associated with a cluster.
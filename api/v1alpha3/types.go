@@ -71,10 +71,21 @@ type Network struct {
 	// SecurityGroups is a map from the role/kind of the security group to its unique name, if any.
 	SecurityGroups map[SecurityGroupRole]SecurityGroup `json:"securityGroups,omitempty"`
 
-	// APIServerELB is the Kubernetes api server classic load balancer.
+	// APIServerELB is the Kubernetes api server load balancer.
 	APIServerELB ClassicELB `json:"apiServerElb,omitempty"`
 }
 
+// LoadBalancerType defines the type of load balancer used for the API server.
+type LoadBalancerType string
+
+var (
+	// LoadBalancerTypeClassic defines an AWS Classic ELB.
+	LoadBalancerTypeClassic = LoadBalancerType("classic")
+
+	// LoadBalancerTypeNetwork defines an AWS Network Load Balancer.
+	LoadBalancerTypeNetwork = LoadBalancerType("network")
+)
+
 // ClassicELBScheme defines the scheme of a classic load balancer.
 type ClassicELBScheme string
 
@@ -114,9 +125,19 @@ type ClassicELB struct {
 	// DNSName is the dns name of the load balancer.
 	DNSName string `json:"dnsName,omitempty"`
 
+	// ARN is the Amazon Resource Name of the load balancer.
+	// Only set for network load balancers.
+	ARN string `json:"arn,omitempty"`
+
+	// LoadBalancerType is the type of the load balancer, classic or network.
+	LoadBalancerType LoadBalancerType `json:"loadBalancerType,omitempty"`
+
 	// Scheme is the load balancer scheme, either internet-facing or private.
 	Scheme ClassicELBScheme `json:"scheme,omitempty"`
 
+	// AvailabilityZones is an array of availability zones in the VPC attached to the load balancer.
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
 	// SubnetIDs is an array of subnets in the VPC attached to the load balancer.
 	SubnetIDs []string `json:"subnetIds,omitempty"`
 
@@ -141,6 +162,36 @@ type ClassicELBAttributes struct {
 	// IdleTimeout is time that the connection is allowed to be idle (no data
 	// has been sent over the connection) before it is closed by the load balancer.
 	IdleTimeout time.Duration `json:"idleTimeout,omitempty"`
+
+	// CrossZoneLoadBalancing distributes the traffic evenly across the instances
+	// of all the availability zones the load balancer is attached to.
+	CrossZoneLoadBalancing bool `json:"crossZoneLoadBalancing,omitempty"`
+
+	// ConnectionDrainingTimeout is the time the load balancer keeps the existing
+	// connections open to deregistered or unhealthy instances. Connection
+	// draining is disabled when unset.
+	ConnectionDrainingTimeout time.Duration `json:"connectionDrainingTimeout,omitempty"`
+
+	// AccessLog configures the delivery of the access logs to S3, they are
+	// disabled when unset.
+	AccessLog *ClassicELBAccessLog `json:"accessLog,omitempty"`
+}
+
+// ClassicELBAccessLog defines the delivery of the access logs of a classic load balancer to S3.
+type ClassicELBAccessLog struct {
+	// S3BucketName is the name of the S3 bucket the access logs are stored in.
+	// The bucket policy must allow the load balancer to write to it.
+	S3BucketName string `json:"s3BucketName"`
+
+	// S3BucketPrefix is the path within the bucket the access logs are stored under.
+	// +optional
+	S3BucketPrefix string `json:"s3BucketPrefix,omitempty"`
+
+	// EmitInterval is the interval in minutes at which the access logs are
+	// published, either 5 or 60 (defaults to 60).
+	// +kubebuilder:validation:Enum=5;60
+	// +optional
+	EmitInterval int64 `json:"emitInterval,omitempty"`
 }
 
 // ClassicELBListener defines an AWS classic load balancer listener.
@@ -169,6 +220,90 @@ type NetworkSpec struct {
 	// Subnets configuration.
 	// +optional
 	Subnets Subnets `json:"subnets,omitempty"`
+
+	// CNI configuration.
+	// +optional
+	CNI *CNISpec `json:"cni,omitempty"`
+
+	// SecurityGroupOverrides is an optional set of existing security group IDs,
+	// by role, used instead of the security groups created by the controller.
+	// They require an existing VPC and are neither modified nor deleted.
+	// +optional
+	SecurityGroupOverrides map[SecurityGroupRole]string `json:"securityGroupOverrides,omitempty"`
+
+	// Isolated creates a network without access to the internet: no internet
+	// gateways, NAT gateways, elastic IPs, public subnets or bastion host, and
+	// an internal API server load balancer. The cluster reaches the AWS services
+	// through the VPC endpoints.
+	// +optional
+	Isolated bool `json:"isolated,omitempty"`
+
+	// NatGatewayStrategy sets how many NAT gateways are created for the private
+	// subnets: one in each public subnet (per-az, the default), a single one
+	// shared by all the private subnets (single), or none at all (none).
+	// +kubebuilder:validation:Enum=per-az;single;none
+	// +optional
+	NatGatewayStrategy NatGatewayStrategy `json:"natGatewayStrategy,omitempty"`
+
+	// TransitGateway attaches the managed VPC to an existing transit gateway.
+	// +optional
+	TransitGateway *TransitGatewaySpec `json:"transitGateway,omitempty"`
+}
+
+// TransitGatewaySpec defines the attachment of the VPC to a transit gateway.
+type TransitGatewaySpec struct {
+	// ID is the ID of the transit gateway.
+	ID string `json:"id"`
+
+	// SubnetIDs are the subnets the VPC is attached through, at most one per
+	// availability zone. Defaults to a private subnet in each availability zone.
+	// +optional
+	SubnetIDs []string `json:"subnetIds,omitempty"`
+
+	// Routes are the CIDR blocks routed to the transit gateway from the route
+	// tables of the cluster subnets.
+	// +optional
+	Routes []string `json:"routes,omitempty"`
+}
+
+// NatGatewayStrategy defines how NAT gateways are created for the private subnets.
+type NatGatewayStrategy string
+
+var (
+	// NatGatewayStrategyPerAZ creates a NAT gateway in each public subnet, used
+	// by the private subnets of the same availability zone.
+	NatGatewayStrategyPerAZ = NatGatewayStrategy("per-az")
+
+	// NatGatewayStrategySingle creates a single NAT gateway shared by all the
+	// private subnets.
+	NatGatewayStrategySingle = NatGatewayStrategy("single")
+
+	// NatGatewayStrategyNone creates no NAT gateways, the private subnets have
+	// no IPv4 route to the internet.
+	NatGatewayStrategyNone = NatGatewayStrategy("none")
+)
+
+// CNISpec defines configuration for the CNI plugin of the cluster.
+type CNISpec struct {
+	// CNIIngressRules are the rules allowing the CNI plugin traffic between the
+	// control plane and node machines. They replace the default rules for Calico
+	// (BGP and IP-in-IP), an empty list adds no rules for the CNI plugin.
+	// +optional
+	CNIIngressRules CNIIngressRules `json:"cniIngressRules,omitempty"`
+}
+
+// CNIIngressRules is a slice of CNIIngressRule.
+type CNIIngressRules []CNIIngressRule
+
+// CNIIngressRule defines an ingress rule between the cluster machines for the CNI plugin.
+type CNIIngressRule struct {
+	Description string `json:"description"`
+
+	// +kubebuilder:validation:Enum="-1";"4";tcp;udp;icmp;"58"
+	Protocol SecurityGroupProtocol `json:"protocol"`
+
+	FromPort int64 `json:"fromPort"`
+	ToPort   int64 `json:"toPort"`
 }
 
 // APIEndpoint represents a reachable Kubernetes API endpoint.
@@ -189,12 +324,82 @@ type VPCSpec struct {
 	// Defaults to 10.0.0.0/16.
 	CidrBlock string `json:"cidrBlock,omitempty"`
 
+	// SecondaryCidrBlocks are additional IPv4 CIDR blocks associated with a managed VPC,
+	// typically used to carve out subnets for pod networking. Blocks removed from the list
+	// are disassociated from the VPC.
+	// +optional
+	SecondaryCidrBlocks []string `json:"secondaryCidrBlocks,omitempty"`
+
 	// InternetGatewayID is the id of the internet gateway associated with the VPC.
 	// +optional
 	InternetGatewayID *string `json:"internetGatewayId,omitempty"`
 
 	// Tags is a collection of tags describing the resource.
 	Tags Tags `json:"tags,omitempty"`
+
+	// AvailabilityZoneUsageLimit is the maximum number of availability zones the
+	// default subnets of a managed VPC are spread across. A public and a private
+	// subnet are created in each zone, carved out of the VPC CIDR block.
+	// Only used when no subnets are specified. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	AvailabilityZoneUsageLimit *int `json:"availabilityZoneUsageLimit,omitempty"`
+
+	// IPv6 makes the network dual-stack. For a managed VPC, an Amazon-provided IPv6 CIDR
+	// block is requested and each subnet is assigned a /64 block out of it. For an unmanaged
+	// VPC, the IPv6 CIDR block already associated with the VPC is used.
+	// Cannot be added or removed once the VPC exists.
+	// +optional
+	IPv6 *IPv6 `json:"ipv6,omitempty"`
+
+	// Endpoints is the list of VPC endpoints created in a managed VPC, so that
+	// the cluster machines can reach AWS services without internet access.
+	// +optional
+	Endpoints []VPCEndpointSpec `json:"endpoints,omitempty"`
+}
+
+// VPCEndpointType defines the type of a VPC endpoint.
+type VPCEndpointType string
+
+var (
+	// VPCEndpointTypeGateway is an endpoint routing the traffic to the service
+	// from the route tables of the subnets.
+	VPCEndpointTypeGateway = VPCEndpointType("Gateway")
+
+	// VPCEndpointTypeInterface is an endpoint exposing the service through
+	// network interfaces in the private subnets.
+	VPCEndpointTypeInterface = VPCEndpointType("Interface")
+)
+
+// VPCEndpointSpec defines a VPC endpoint.
+type VPCEndpointSpec struct {
+	// ServiceName is the name of the AWS service, either its short name, for
+	// example ec2 or s3, or its full name, for example com.amazonaws.us-east-1.ec2.
+	ServiceName string `json:"serviceName"`
+
+	// Type is the type of the endpoint (defaults to Gateway for s3 and dynamodb,
+	// Interface otherwise). Gateway endpoints are attached to the route tables of
+	// the subnets, interface endpoints are created in the private subnets.
+	// +kubebuilder:validation:Enum=Gateway;Interface
+	// +optional
+	Type VPCEndpointType `json:"type,omitempty"`
+}
+
+// IPv6 describes the IPv6 configuration of a dual-stack VPC.
+type IPv6 struct {
+	// CidrBlock is the IPv6 CIDR block of the VPC. It is set by the controller.
+	// +optional
+	CidrBlock string `json:"cidrBlock,omitempty"`
+
+	// EgressOnlyInternetGatewayID is the id of the egress-only internet gateway that
+	// private subnets route their outbound IPv6 traffic through. It is set by the controller.
+	// +optional
+	EgressOnlyInternetGatewayID *string `json:"egressOnlyInternetGatewayId,omitempty"`
+}
+
+// IsIPv6Enabled returns true if the VPC is dual-stack.
+func (v *VPCSpec) IsIPv6Enabled() bool {
+	return v.IPv6 != nil
 }
 
 // String returns a string representation of the VPC.
@@ -215,6 +420,11 @@ type SubnetSpec struct {
 	// CidrBlock is the CIDR block to be used when the provider creates a managed VPC.
 	CidrBlock string `json:"cidrBlock,omitempty"`
 
+	// IPv6CidrBlock is the /64 IPv6 CIDR block of the subnet. Only used when IPv6 is
+	// enabled on the VPC, in which case it is carved out of the VPC IPv6 CIDR block if omitted.
+	// +optional
+	IPv6CidrBlock string `json:"ipv6CidrBlock,omitempty"`
+
 	// AvailabilityZone defines the availability zone to use for this subnet in the cluster's region.
 	AvailabilityZone string `json:"availabilityZone,omitempty"`
 
@@ -222,6 +432,12 @@ type SubnetSpec struct {
 	// +optional
 	IsPublic bool `json:"isPublic"`
 
+	// IsPod defines the subnet as a private subnet dedicated to pod networking, such as the
+	// ENIs of the AWS VPC CNI custom networking. Pod subnets are routed like private subnets
+	// but are never used for machines, load balancers or VPC endpoints.
+	// +optional
+	IsPod bool `json:"isPod,omitempty"`
+
 	// RouteTableID is the routing table id associated with the subnet.
 	// +optional
 	RouteTableID *string `json:"routeTableId,omitempty"`
@@ -231,6 +447,12 @@ type SubnetSpec struct {
 	// +optional
 	NatGatewayID *string `json:"natGatewayId,omitempty"`
 
+	// Routes are additional routes added to the route table of a managed subnet,
+	// on top of the default routes created by the provider. Routes removed from
+	// the list are deleted from the route table.
+	// +optional
+	Routes []RouteSpec `json:"routes,omitempty"`
+
 	// Tags is a collection of tags describing the resource.
 	Tags Tags `json:"tags,omitempty"`
 }
@@ -263,10 +485,11 @@ func (s Subnets) FindByID(id string) *SubnetSpec {
 	return nil
 }
 
-// FilterPrivate returns a slice containing all subnets marked as private.
+// FilterPrivate returns a slice containing all subnets marked as private,
+// excluding the subnets dedicated to pods.
 func (s Subnets) FilterPrivate() (res Subnets) {
 	for _, x := range s {
-		if !x.IsPublic {
+		if !x.IsPublic && !x.IsPod {
 			res = append(res, x)
 		}
 	}
@@ -283,6 +506,16 @@ func (s Subnets) FilterPublic() (res Subnets) {
 	return
 }
 
+// FilterPods returns a slice containing all subnets dedicated to pods.
+func (s Subnets) FilterPods() (res Subnets) {
+	for _, x := range s {
+		if x.IsPod {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
 // FilterByZone returns a slice containing all subnets that live in the availability zone specified.
 func (s Subnets) FilterByZone(zone string) (res Subnets) {
 	for _, x := range s {
@@ -293,6 +526,42 @@ func (s Subnets) FilterByZone(zone string) (res Subnets) {
 	return
 }
 
+// RouteSpec defines a route to a single destination through a single target.
+type RouteSpec struct {
+	// DestinationCidrBlock is the IPv4 or IPv6 CIDR block matched by the route.
+	// +optional
+	DestinationCidrBlock string `json:"destinationCidrBlock,omitempty"`
+
+	// DestinationPrefixListID is the ID of the managed prefix list matched by the route.
+	// +optional
+	DestinationPrefixListID string `json:"destinationPrefixListId,omitempty"`
+
+	// TransitGatewayID routes the traffic to a transit gateway. Routes to the
+	// transit gateway of the network are set with its routes instead.
+	// +optional
+	TransitGatewayID string `json:"transitGatewayId,omitempty"`
+
+	// VPCPeeringConnectionID routes the traffic to a VPC peering connection.
+	// +optional
+	VPCPeeringConnectionID string `json:"vpcPeeringConnectionId,omitempty"`
+
+	// VPCEndpointID routes the traffic to a Gateway Load Balancer VPC endpoint.
+	// +optional
+	VPCEndpointID string `json:"vpcEndpointId,omitempty"`
+
+	// NatGatewayID routes the traffic to a NAT gateway.
+	// +optional
+	NatGatewayID string `json:"natGatewayId,omitempty"`
+}
+
+// Destination returns the CIDR block or the prefix list matched by the route.
+func (r *RouteSpec) Destination() string {
+	if r.DestinationPrefixListID != "" {
+		return r.DestinationPrefixListID
+	}
+	return r.DestinationCidrBlock
+}
+
 // RouteTable defines an AWS routing table.
 type RouteTable struct {
 	ID string `json:"id"`
@@ -313,6 +582,9 @@ var (
 
 	// SecurityGroupLB defines a container for the cloud provider to inject its load balancer ingress rules
 	SecurityGroupLB = SecurityGroupRole("lb")
+
+	// SecurityGroupVPCEndpoint defines the role of the interface VPC endpoints
+	SecurityGroupVPCEndpoint = SecurityGroupRole("vpc-endpoint")
 )
 
 // SecurityGroup defines an AWS security group.
@@ -370,6 +642,10 @@ type IngressRule struct {
 	// +optional
 	CidrBlocks []string `json:"cidrBlocks"`
 
+	// List of IPv6 CIDR blocks to allow access from. Cannot be specified with SourceSecurityGroupID.
+	// +optional
+	IPv6CidrBlocks []string `json:"ipv6CidrBlocks,omitempty"`
+
 	// The security group id to allow access from. Cannot be specified with CidrBlocks.
 	// +optional
 	SourceSecurityGroupIDs []string `json:"sourceSecurityGroupIds"`
@@ -417,6 +693,19 @@ func (i *IngressRule) Equals(o *IngressRule) bool {
 		}
 	}
 
+	if len(i.IPv6CidrBlocks) != len(o.IPv6CidrBlocks) {
+		return false
+	}
+
+	sort.Strings(i.IPv6CidrBlocks)
+	sort.Strings(o.IPv6CidrBlocks)
+
+	for i, v := range i.IPv6CidrBlocks {
+		if v != o.IPv6CidrBlocks[i] {
+			return false
+		}
+	}
+
 	if len(i.SourceSecurityGroupIDs) != len(o.SourceSecurityGroupIDs) {
 		return false
 	}
@@ -473,6 +762,117 @@ var (
 	InstanceStateStopped = InstanceState("stopped")
 )
 
+// InstanceLifecycle describes the purchasing option an AWS instance runs with.
+type InstanceLifecycle string
+
+var (
+	// InstanceLifecycleOnDemand is the string representing an on-demand instance
+	InstanceLifecycleOnDemand = InstanceLifecycle("on-demand")
+
+	// InstanceLifecycleSpot is the string representing a spot instance
+	InstanceLifecycleSpot = InstanceLifecycle("spot")
+)
+
+// SpotInterruptionBehavior describes what happens to a spot instance when it is interrupted.
+type SpotInterruptionBehavior string
+
+var (
+	// SpotInterruptionBehaviorTerminate terminates interrupted spot instances
+	SpotInterruptionBehaviorTerminate = SpotInterruptionBehavior("terminate")
+
+	// SpotInterruptionBehaviorStop stops interrupted spot instances, they are
+	// started again once capacity is available
+	SpotInterruptionBehaviorStop = SpotInterruptionBehavior("stop")
+
+	// SpotInterruptionBehaviorHibernate hibernates interrupted spot instances,
+	// they are resumed once capacity is available
+	SpotInterruptionBehaviorHibernate = SpotInterruptionBehavior("hibernate")
+)
+
+// SpotMarketOptions defines the options available when running an instance on spot capacity.
+type SpotMarketOptions struct {
+	// MaxPrice defines the maximum hourly price to pay for the spot instance.
+	// Defaults to the on-demand price.
+	// +optional
+	MaxPrice *string `json:"maxPrice,omitempty"`
+
+	// InterruptionBehavior defines what happens to the instance when it is interrupted.
+	// Instances that are stopped or hibernated are backed by a persistent spot request.
+	// Hibernation requires an encrypted root volume. Defaults to terminate.
+	// +kubebuilder:validation:Enum=terminate;stop;hibernate
+	// +optional
+	InterruptionBehavior SpotInterruptionBehavior `json:"interruptionBehavior,omitempty"`
+}
+
+// VolumeType describes the EBS volume type.
+// See https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ebs-volume-types.html
+type VolumeType string
+
+var (
+	// VolumeTypeStandard is the string representing a previous generation magnetic volume
+	VolumeTypeStandard = VolumeType("standard")
+
+	// VolumeTypeGP2 is the string representing a general purpose SSD volume
+	VolumeTypeGP2 = VolumeType("gp2")
+
+	// VolumeTypeGP3 is the string representing a general purpose SSD volume
+	// with provisioned IOPS and throughput
+	VolumeTypeGP3 = VolumeType("gp3")
+
+	// VolumeTypeIO1 is the string representing a provisioned IOPS SSD volume
+	VolumeTypeIO1 = VolumeType("io1")
+
+	// VolumeTypeIO2 is the string representing a provisioned IOPS SSD volume
+	// with higher durability
+	VolumeTypeIO2 = VolumeType("io2")
+
+	// VolumeTypeST1 is the string representing a throughput optimized HDD volume
+	VolumeTypeST1 = VolumeType("st1")
+
+	// VolumeTypeSC1 is the string representing a cold HDD volume
+	VolumeTypeSC1 = VolumeType("sc1")
+)
+
+// Volume encapsulates the configuration options for an EBS volume.
+type Volume struct {
+	// DeviceName is the device name the volume is exposed as to the instance, e.g. /dev/sdb.
+	// It is required for non-root volumes and ignored for the root volume, which
+	// uses the root device name of the AMI.
+	// +optional
+	DeviceName string `json:"deviceName,omitempty"`
+
+	// Size specifies the size of the volume in gigabytes(GB).
+	// The root volume must be at least as large as the AMI snapshot and 8GB.
+	// +kubebuilder:validation:Minimum=1
+	Size int64 `json:"size"`
+
+	// Type is the type of the volume. Defaults to the AWS default for the region, usually gp2.
+	// +kubebuilder:validation:Enum=standard;gp2;gp3;io1;io2;st1;sc1
+	// +optional
+	Type VolumeType `json:"type,omitempty"`
+
+	// IOPS is the number of I/O operations per second to provision for the volume.
+	// Required for io1 and io2 volumes, optional for gp3 volumes and not supported
+	// by the other volume types.
+	// +optional
+	IOPS int64 `json:"iops,omitempty"`
+
+	// Throughput is the throughput to provision for the volume in MiB/s.
+	// Only supported by gp3 volumes.
+	// +optional
+	Throughput *int64 `json:"throughput,omitempty"`
+
+	// Encrypted is whether the volume should be encrypted.
+	// +optional
+	Encrypted *bool `json:"encrypted,omitempty"`
+
+	// KMSKeyID is the ID or ARN of the KMS key used to encrypt the volume.
+	// If the volume is encrypted and this is omitted, the AWS managed key for EBS is used.
+	// The key must already exist and be usable by the controller.
+	// +optional
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+}
+
 // Instance describes an AWS instance.
 type Instance struct {
 	ID string `json:"id"`
@@ -480,6 +880,18 @@ type Instance struct {
 	// The current state of the instance.
 	State InstanceState `json:"instanceState,omitempty"`
 
+	// The reason code for the most recent state transition, if any.
+	StateReason *string `json:"stateReason,omitempty"`
+
+	// Whether the instance is an on-demand or a spot instance.
+	Lifecycle InstanceLifecycle `json:"lifecycle,omitempty"`
+
+	// The spot market options the instance is launched with, if any.
+	SpotMarketOptions *SpotMarketOptions `json:"spotMarketOptions,omitempty"`
+
+	// The ID of the spot instance request, if the instance is a spot instance.
+	SpotInstanceRequestID *string `json:"spotInstanceRequestId,omitempty"`
+
 	// The instance type.
 	Type string `json:"type,omitempty"`
 
@@ -517,9 +929,78 @@ type Instance struct {
 	// Specifies size (in Gi) of the root storage device
 	RootDeviceSize int64 `json:"rootDeviceSize,omitempty"`
 
+	// The root volume of the instance.
+	RootVolume *Volume `json:"rootVolume,omitempty"`
+
+	// The EBS volumes attached to the instance in addition to the root volume.
+	NonRootVolumes []Volume `json:"nonRootVolumes,omitempty"`
+
 	// Specifies ENIs attached to instance
 	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
 
 	// The tags associated with the instance.
 	Tags map[string]string `json:"tags,omitempty"`
 }
+
+// AutoScalingGroup describes an AWS Auto Scaling Group.
+type AutoScalingGroup struct {
+	// The name of the Auto Scaling Group.
+	Name string `json:"name"`
+
+	// The tags associated with the Auto Scaling Group.
+	Tags Tags `json:"tags,omitempty"`
+
+	// The desired size of the group.
+	DesiredCapacity *int32 `json:"desiredCapacity,omitempty"`
+
+	// The minimum size of the group.
+	MinSize int32 `json:"minSize,omitempty"`
+
+	// The maximum size of the group.
+	MaxSize int32 `json:"maxSize,omitempty"`
+
+	// The IDs of the subnets the group launches instances in.
+	Subnets []string `json:"subnets,omitempty"`
+
+	// The ID of the launch template used by the group.
+	LaunchTemplateID string `json:"launchTemplateId,omitempty"`
+
+	// The launch template version used by the group.
+	LaunchTemplateVersion string `json:"launchTemplateVersion,omitempty"`
+
+	// The instances that are part of the group.
+	Instances []AutoScalingGroupInstance `json:"instances,omitempty"`
+}
+
+// AutoScalingGroupInstance describes an instance that is part of an AWS Auto Scaling Group.
+type AutoScalingGroupInstance struct {
+	// The ID of the instance.
+	ID string `json:"id"`
+
+	// The lifecycle state of the instance within the group, e.g. InService.
+	LifecycleState string `json:"lifecycleState,omitempty"`
+
+	// The health status reported by the group for the instance.
+	HealthStatus string `json:"healthStatus,omitempty"`
+
+	// The launch template version the instance was launched from.
+	LaunchTemplateVersion string `json:"launchTemplateVersion,omitempty"`
+}
+
+// LaunchTemplate describes an EC2 launch template and the data of its latest version.
+type LaunchTemplate struct {
+	// The ID of the launch template.
+	ID string `json:"id,omitempty"`
+
+	// The name of the launch template.
+	Name string `json:"name,omitempty"`
+
+	// The latest version number of the launch template.
+	LatestVersion *int64 `json:"latestVersion,omitempty"`
+
+	// The tags of the launch template.
+	Tags Tags `json:"tags,omitempty"`
+
+	// Instance holds the instance parameters stored in the launch template version.
+	Instance Instance `json:"instance,omitempty"`
+}
@@ -22,17 +22,21 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/record"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/conditions"
 	"sigs.k8s.io/cluster-api/util"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // AWSClusterReconciler reconciles a AwsCluster object
@@ -45,6 +49,7 @@ type AWSClusterReconciler struct {
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 func (r *AWSClusterReconciler) Reconcile(req ctrl.Request) (_ ctrl.Result, reterr error) {
 	ctx := context.TODO()
@@ -152,9 +157,11 @@ func reconcileNormal(clusterScope *scope.ClusterScope) (reconcile.Result, error)
 	}
 
 	if awsCluster.Status.Network.APIServerELB.DNSName == "" {
+		conditions.MarkFalse(awsCluster, infrav1.LoadBalancerReadyCondition, infrav1.WaitForDNSNameReason, infrav1.ConditionSeverityInfo, "")
 		clusterScope.Info("Waiting on API server ELB DNS name")
 		return reconcile.Result{RequeueAfter: 15 * time.Second}, nil
 	}
+	conditions.MarkTrue(awsCluster, infrav1.LoadBalancerReadyCondition)
 
 	// Set APIEndpoints so the Cluster API Cluster Controller can pull them
 	// TODO: should we get the Port from the first listener on the ELB?
@@ -175,5 +182,36 @@ func (r *AWSClusterReconciler) SetupWithManager(mgr ctrl.Manager, options contro
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		For(&infrav1.AWSCluster{}).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.IdentitySecretToAWSClusters)},
+		).
 		Complete(r)
 }
+
+// IdentitySecretToAWSClusters is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// of AWSClusters using the Secret as their identity.
+func (r *AWSClusterReconciler) IdentitySecretToAWSClusters(o handler.MapObject) []ctrl.Request {
+	result := []ctrl.Request{}
+
+	s, ok := o.Object.(*corev1.Secret)
+	if !ok {
+		r.Log.Error(errors.Errorf("expected a Secret but got a %T", o.Object), "failed to get AWSClusters for Secret")
+		return nil
+	}
+
+	clusterList := &infrav1.AWSClusterList{}
+	if err := r.List(context.TODO(), clusterList, client.InNamespace(s.Namespace)); err != nil {
+		r.Log.Error(err, "failed to list AWSClusters", "Namespace", s.Namespace)
+		return nil
+	}
+	for _, c := range clusterList.Items {
+		if c.Spec.IdentityRef == nil || c.Spec.IdentityRef.Name != s.Name {
+			continue
+		}
+		name := client.ObjectKey{Namespace: c.Namespace, Name: c.Name}
+		result = append(result, ctrl.Request{NamespacedName: name})
+	}
+
+	return result
+}
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/conditions"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
 	capierrors "sigs.k8s.io/cluster-api/errors"
@@ -197,6 +198,15 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 
 	machineScope.V(3).Info("Instance found matching deleted AWSMachine", "instanceID", instance.ID)
 
+	// Cancel the spot request before terminating the instance, otherwise a
+	// persistent request launches a replacement instance.
+	if instance.SpotInstanceRequestID != nil {
+		if err := ec2Service.CancelSpotInstanceRequest(*instance.SpotInstanceRequestID); err != nil {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedCancelSpotRequest", "Failed to cancel spot instance request %q: %v", *instance.SpotInstanceRequestID, err)
+			return reconcile.Result{}, errors.Wrap(err, "failed to cancel spot instance request")
+		}
+	}
+
 	// Check the instance state. If it's already shutting down or terminated,
 	// do nothing. Otherwise attempt to delete it.
 	// This decision is based on the ec2-instance-lifecycle graph at
@@ -281,12 +291,14 @@ func (r *AWSMachineReconciler) reconcileNormal(ctx context.Context, machineScope
 
 	if !machineScope.Cluster.Status.InfrastructureReady {
 		machineScope.Info("Cluster infrastructure is not ready yet")
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.WaitingForClusterInfrastructureReason, infrav1.ConditionSeverityInfo, "")
 		return reconcile.Result{}, nil
 	}
 
 	// Make sure bootstrap data is available and populated.
 	if machineScope.Machine.Spec.Bootstrap.Data == nil {
 		machineScope.Info("Bootstrap data is not yet available")
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.WaitingForBootstrapDataReason, infrav1.ConditionSeverityInfo, "")
 		return reconcile.Result{}, nil
 	}
 
@@ -295,6 +307,7 @@ func (r *AWSMachineReconciler) reconcileNormal(ctx context.Context, machineScope
 	// Get or create the instance.
 	instance, err := r.getOrCreate(machineScope, ec2svc)
 	if err != nil {
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
 		return reconcile.Result{}, err
 	}
 
@@ -302,10 +315,12 @@ func (r *AWSMachineReconciler) reconcileNormal(ctx context.Context, machineScope
 	if instance == nil {
 		machineScope.SetErrorReason(capierrors.UpdateMachineError)
 		machineScope.SetErrorMessage(errors.New("EC2 instance cannot be found"))
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotFoundReason, infrav1.ConditionSeverityError, "EC2 instance cannot be found")
 		return reconcile.Result{}, nil
 	}
 
-	// TODO(ncdc): move this validation logic into a validating webhook
+	// Immutable fields are enforced by the AWSMachine validating webhook, this only
+	// catches changes made while webhooks are disabled.
 	if errs := r.validateUpdate(&machineScope.AWSMachine.Spec, instance); len(errs) > 0 {
 		agg := kerrors.NewAggregate(errs)
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "InvalidUpdate", "Invalid update: %s", agg.Error())
@@ -317,6 +332,7 @@ func (r *AWSMachineReconciler) reconcileNormal(ctx context.Context, machineScope
 
 	// Proceed to reconcile the AWSMachine state.
 	machineScope.SetInstanceState(instance.State)
+	machineScope.SetInstanceLifecycle(instance.Lifecycle)
 
 	// TODO(vincepri): Remove this annotation when clusterctl is no longer relevant.
 	machineScope.SetAnnotation("cluster-api-provider-aws", "true")
@@ -325,11 +341,34 @@ func (r *AWSMachineReconciler) reconcileNormal(ctx context.Context, machineScope
 	case infrav1.InstanceStateRunning:
 		machineScope.Info("Machine instance is running", "instance-id", *machineScope.GetInstanceID())
 		machineScope.SetReady()
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.InstanceReadyCondition)
 	case infrav1.InstanceStatePending:
 		machineScope.Info("Machine instance is pending", "instance-id", *machineScope.GetInstanceID())
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotReadyReason, infrav1.ConditionSeverityInfo, "")
 	default:
+		if isSpotStopped(instance) {
+			// The persistent spot request starts the instance again once capacity is available.
+			machineScope.Info("Spot instance was stopped by AWS, waiting for capacity", "instance-id", *machineScope.GetInstanceID())
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceSpotInterruptedReason, infrav1.ConditionSeverityWarning, "EC2 spot instance was stopped: %s", aws.StringValue(instance.StateReason))
+			break
+		}
+
+		if isSpotInterrupted(instance) {
+			// AWS reclaimed the capacity, the machine has to be replaced.
+			machineScope.SetErrorReason(capierrors.InsufficientResourcesMachineError)
+			machineScope.SetErrorMessage(errors.Errorf("EC2 spot instance was interrupted: %s", aws.StringValue(instance.StateReason)))
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceSpotInterruptedReason, infrav1.ConditionSeverityError, "EC2 spot instance was interrupted: %s", aws.StringValue(instance.StateReason))
+			break
+		}
+
 		machineScope.SetErrorReason(capierrors.UpdateMachineError)
 		machineScope.SetErrorMessage(errors.Errorf("EC2 instance state %q is unexpected", instance.State))
+
+		reason := infrav1.InstanceTerminatedReason
+		if instance.State == infrav1.InstanceStateStopping || instance.State == infrav1.InstanceStateStopped {
+			reason = infrav1.InstanceStoppedReason
+		}
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, reason, infrav1.ConditionSeverityError, "EC2 instance state %q is unexpected", instance.State)
 	}
 
 	if err := r.reconcileLBAttachment(machineScope, clusterScope, instance); err != nil {
@@ -344,8 +383,10 @@ func (r *AWSMachineReconciler) reconcileNormal(ctx context.Context, machineScope
 	// Ensure that the security groups are correct.
 	_, err = r.ensureSecurityGroups(ec2svc, machineScope, machineScope.AWSMachine.Spec.AdditionalSecurityGroups, existingSecurityGroups)
 	if err != nil {
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition, infrav1.SecurityGroupsFailedReason, infrav1.ConditionSeverityError, "%v", err)
 		return reconcile.Result{}, errors.Errorf("failed to apply security groups: %+v", err)
 	}
+	conditions.MarkTrue(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition)
 
 	// Ensure that the tags are correct.
 	_, err = r.ensureTags(ec2svc, machineScope.AWSMachine, machineScope.GetInstanceID(), machineScope.AdditionalTags())
@@ -382,11 +423,37 @@ func (r *AWSMachineReconciler) reconcileLBAttachment(machineScope *scope.Machine
 	if err := elbsvc.RegisterInstanceWithAPIServerELB(i); err != nil {
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedAttachControlPlaneELB",
 			"Failed to register control plane instance %q with load balancer: %v", i.ID, err)
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBAttachFailedReason, infrav1.ConditionSeverityError, "%v", err)
 		return errors.Wrapf(err, "could not register control plane instance %q with load balancer", i.ID)
 	}
+	conditions.MarkTrue(machineScope.AWSMachine, infrav1.ELBAttachedCondition)
 	return nil
 }
 
+const (
+	// spotInstanceTerminationReason is the state reason of spot instances terminated by AWS.
+	spotInstanceTerminationReason = "Server.SpotInstanceTermination"
+
+	// spotInstanceShutdownReason is the state reason of spot instances stopped or hibernated by AWS.
+	spotInstanceShutdownReason = "Server.SpotInstanceShutdown"
+)
+
+// isSpotInterrupted returns true if the instance was terminated by AWS to
+// reclaim spot capacity.
+func isSpotInterrupted(i *infrav1.Instance) bool {
+	return i.Lifecycle == infrav1.InstanceLifecycleSpot && aws.StringValue(i.StateReason) == spotInstanceTerminationReason
+}
+
+// isSpotStopped returns true if the instance was stopped or hibernated by AWS
+// to reclaim spot capacity. Such instances are started again by their
+// persistent spot request.
+func isSpotStopped(i *infrav1.Instance) bool {
+	if i.Lifecycle != infrav1.InstanceLifecycleSpot || aws.StringValue(i.StateReason) != spotInstanceShutdownReason {
+		return false
+	}
+	return i.State == infrav1.InstanceStateStopping || i.State == infrav1.InstanceStateStopped
+}
+
 // validateUpdate checks that no immutable fields have been updated and
 // returns a slice of errors representing attempts to change immutable state.
 func (r *AWSMachineReconciler) validateUpdate(spec *infrav1.AWSMachineSpec, i *infrav1.Instance) (errs []error) {
@@ -409,6 +476,9 @@ func (r *AWSMachineReconciler) validateUpdate(spec *infrav1.AWSMachineSpec, i *i
 	if spec.RootDeviceSize > 0 && spec.RootDeviceSize != i.RootDeviceSize {
 		errs = append(errs, errors.Errorf("Root volume size cannot be mutated from %v to %v", i.RootDeviceSize, spec.RootDeviceSize))
 	}
+	if spec.RootVolume != nil && spec.RootVolume.Size != i.RootDeviceSize {
+		errs = append(errs, errors.Errorf("Root volume size cannot be mutated from %v to %v", i.RootDeviceSize, spec.RootVolume.Size))
+	}
 
 	// Subnet ID
 	// spec.Subnet is a *AWSResourceReference and could technically be
@@ -22,11 +22,13 @@ import (
 	"flag"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog"
@@ -40,6 +42,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope" //nolint
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/mock_services" //nolint
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/conditions"
 )
 
 var _ = Describe("AWSMachineReconciler", func() {
@@ -142,6 +145,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs)
 				Expect(err).To(BeNil())
 				Expect(buf.String()).To(ContainSubstring("Cluster infrastructure is not ready yet"))
+				expectConditions(ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, infrav1.ConditionSeverityInfo, infrav1.WaitingForClusterInfrastructureReason}})
 			})
 
 			It("should exit immediately if bootstrap data isn't available", func() {
@@ -153,6 +157,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs)
 				Expect(err).To(BeNil())
 				Expect(buf.String()).To(ContainSubstring("Bootstrap data is not yet available"))
+				expectConditions(ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, infrav1.ConditionSeverityInfo, infrav1.WaitingForBootstrapDataReason}})
 			})
 
 			It("should return an error when we can't list instances by tags", func() {
@@ -224,6 +229,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 						_, _ = reconciler.reconcileNormal(context.Background(), ms, cs)
 						Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStateRunning)))
 						Expect(buf.String()).To(ContainSubstring(("Machine instance is running")))
+						expectConditions(ms.AWSMachine, []conditionAssertion{{conditionType: infrav1.InstanceReadyCondition, status: corev1.ConditionTrue}})
 					})
 
 					It("should set instance to pending", func() {
@@ -231,6 +237,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 						_, _ = reconciler.reconcileNormal(context.Background(), ms, cs)
 						Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStatePending)))
 						Expect(buf.String()).To(ContainSubstring(("Machine instance is pending")))
+						expectConditions(ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, infrav1.ConditionSeverityInfo, infrav1.InstanceNotReadyReason}})
 					})
 				})
 
@@ -239,6 +246,28 @@ var _ = Describe("AWSMachineReconciler", func() {
 					_, _ = reconciler.reconcileNormal(context.Background(), ms, cs)
 					Expect(ms.AWSMachine.Status.ErrorReason).To(PointTo(Equal(capierrors.UpdateMachineError)))
 					Expect(ms.AWSMachine.Status.ErrorMessage).To(PointTo(Equal("EC2 instance state \"stopping\" is unexpected")))
+					expectConditions(ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, infrav1.ConditionSeverityError, infrav1.InstanceStoppedReason}})
+				})
+
+				It("should set error message when a spot instance is interrupted", func() {
+					instance.State = infrav1.InstanceStateTerminated
+					instance.Lifecycle = infrav1.InstanceLifecycleSpot
+					instance.StateReason = aws.String("Server.SpotInstanceTermination")
+					_, _ = reconciler.reconcileNormal(context.Background(), ms, cs)
+					Expect(ms.AWSMachine.Status.InstanceLifecycle).To(PointTo(Equal(infrav1.InstanceLifecycleSpot)))
+					Expect(ms.AWSMachine.Status.ErrorReason).To(PointTo(Equal(capierrors.InsufficientResourcesMachineError)))
+					Expect(ms.AWSMachine.Status.ErrorMessage).To(PointTo(Equal("EC2 spot instance was interrupted: Server.SpotInstanceTermination")))
+					expectConditions(ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, infrav1.ConditionSeverityError, infrav1.InstanceSpotInterruptedReason}})
+				})
+
+				It("should wait for a stopped spot instance to be started again", func() {
+					instance.State = infrav1.InstanceStateStopped
+					instance.Lifecycle = infrav1.InstanceLifecycleSpot
+					instance.StateReason = aws.String("Server.SpotInstanceShutdown")
+					_, _ = reconciler.reconcileNormal(context.Background(), ms, cs)
+					Expect(ms.AWSMachine.Status.ErrorReason).To(BeNil())
+					Expect(ms.AWSMachine.Status.ErrorMessage).To(BeNil())
+					expectConditions(ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, infrav1.ConditionSeverityWarning, infrav1.InstanceSpotInterruptedReason}})
 				})
 			})
 
@@ -345,6 +374,20 @@ var _ = Describe("AWSMachineReconciler", func() {
 			Expect(ms.AWSMachine.Finalizers).To(ConsistOf(metav1.FinalizerDeleteDependents))
 		})
 
+		It("should cancel the spot instance request before terminating the instance", func() {
+			ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(&infrav1.Instance{
+				State:                 infrav1.InstanceStateStopped,
+				SpotInstanceRequestID: aws.String("sir-1"),
+			}, nil)
+			gomock.InOrder(
+				ec2Svc.EXPECT().CancelSpotInstanceRequest("sir-1").Return(nil),
+				ec2Svc.EXPECT().TerminateInstanceAndWait(gomock.Any()).Return(nil),
+			)
+
+			_, err := reconciler.reconcileDelete(ms, cs)
+			Expect(err).To(BeNil())
+		})
+
 		Context("Instance not shutting down yet", func() {
 			id := "aws:////myid"
 
@@ -444,3 +487,22 @@ func (p *pointsTo) Matches(x interface{}) bool {
 func (p *pointsTo) String() string {
 	return fmt.Sprintf("Pointer to string %q", p.val)
 }
+
+type conditionAssertion struct {
+	conditionType infrav1.ConditionType
+	status        corev1.ConditionStatus
+	severity      infrav1.ConditionSeverity
+	reason        string
+}
+
+func expectConditions(m *infrav1.AWSMachine, expected []conditionAssertion) {
+	Expect(len(m.Status.Conditions)).To(BeNumerically(">=", len(expected)))
+	for _, c := range expected {
+		actual := conditions.Get(m, c.conditionType)
+		Expect(actual).To(Not(BeNil()))
+		Expect(actual.Type).To(Equal(c.conditionType))
+		Expect(actual.Status).To(Equal(c.status))
+		Expect(actual.Severity).To(Equal(c.severity))
+		Expect(actual.Reason).To(Equal(c.reason))
+	}
+}
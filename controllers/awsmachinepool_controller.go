@@ -0,0 +1,332 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/autoscaling"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// machinePoolRequeueAfter is how long to wait before checking again on instances
+	// of an Auto Scaling Group that are not yet in service.
+	machinePoolRequeueAfter = 30 * time.Second
+)
+
+// AWSMachinePoolReconciler reconciles a AWSMachinePool object
+type AWSMachinePoolReconciler struct {
+	client.Client
+	Log               logr.Logger
+	Recorder          record.EventRecorder
+	ec2ServiceFactory func(*scope.ClusterScope) services.EC2MachinePoolInterface
+	asgServiceFactory func(*scope.ClusterScope) services.ASGInterface
+}
+
+func (r *AWSMachinePoolReconciler) getEC2Service(scope *scope.ClusterScope) services.EC2MachinePoolInterface {
+	if r.ec2ServiceFactory != nil {
+		return r.ec2ServiceFactory(scope)
+	}
+
+	return ec2.NewService(scope)
+}
+
+func (r *AWSMachinePoolReconciler) getASGService(scope *scope.ClusterScope) services.ASGInterface {
+	if r.asgServiceFactory != nil {
+		return r.asgServiceFactory(scope)
+	}
+
+	return autoscaling.NewService(scope)
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
+
+func (r *AWSMachinePoolReconciler) Reconcile(req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx := context.TODO()
+	logger := r.Log.WithValues("namespace", req.Namespace, "awsMachinePool", req.Name)
+
+	// Fetch the AWSMachinePool instance.
+	awsMachinePool := &infrav1.AWSMachinePool{}
+	err := r.Get(ctx, req.NamespacedName, awsMachinePool)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Fetch the Cluster.
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, awsMachinePool.ObjectMeta)
+	if err != nil {
+		logger.Info("AWSMachinePool is missing cluster label or cluster does not exist")
+		return reconcile.Result{}, nil
+	}
+
+	logger = logger.WithValues("cluster", cluster.Name)
+
+	awsCluster := &infrav1.AWSCluster{}
+
+	awsClusterName := client.ObjectKey{
+		Namespace: awsMachinePool.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+	if err := r.Client.Get(ctx, awsClusterName, awsCluster); err != nil {
+		logger.Info("AWSCluster is not available yet")
+		return reconcile.Result{}, nil
+	}
+
+	logger = logger.WithValues("awsCluster", awsCluster.Name)
+
+	// Create the cluster scope
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     r.Client,
+		Logger:     logger,
+		Cluster:    cluster,
+		AWSCluster: awsCluster,
+	})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Create the machine pool scope
+	machinePoolScope, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+		Logger:         logger,
+		Client:         r.Client,
+		Cluster:        cluster,
+		AWSCluster:     awsCluster,
+		AWSMachinePool: awsMachinePool,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	// Always close the scope when exiting this function so we can persist any AWSMachinePool changes.
+	defer func() {
+		if err := machinePoolScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	// Handle deleted machine pools
+	if !awsMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(machinePoolScope, clusterScope)
+	}
+
+	// Handle non-deleted machine pools
+	return r.reconcileNormal(machinePoolScope, clusterScope)
+}
+
+func (r *AWSMachinePoolReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.AWSMachinePool{}).
+		Watches(
+			&source.Kind{Type: &infrav1.AWSCluster{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.AWSClusterToAWSMachinePools)},
+		).
+		Complete(r)
+}
+
+func (r *AWSMachinePoolReconciler) reconcileNormal(machinePoolScope *scope.MachinePoolScope, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	machinePoolScope.Info("Reconciling AWSMachinePool")
+
+	// If the AWSMachinePool is in an error state, return early.
+	if machinePoolScope.AWSMachinePool.Status.ErrorReason != nil || machinePoolScope.AWSMachinePool.Status.ErrorMessage != nil {
+		machinePoolScope.Info("Error state detected, skipping reconciliation")
+		return reconcile.Result{}, nil
+	}
+
+	// If the AWSMachinePool doesn't have our finalizer, add it.
+	if !util.Contains(machinePoolScope.AWSMachinePool.Finalizers, infrav1.MachinePoolFinalizer) {
+		machinePoolScope.AWSMachinePool.Finalizers = append(machinePoolScope.AWSMachinePool.Finalizers, infrav1.MachinePoolFinalizer)
+	}
+
+	if !machinePoolScope.Cluster.Status.InfrastructureReady {
+		machinePoolScope.Info("Cluster infrastructure is not ready yet")
+		return reconcile.Result{}, nil
+	}
+
+	// Make sure bootstrap data is available and populated.
+	if machinePoolScope.GetBootstrapData() == nil {
+		machinePoolScope.Info("Bootstrap data is not yet available")
+		return reconcile.Result{}, nil
+	}
+
+	ec2svc := r.getEC2Service(clusterScope)
+	asgsvc := r.getASGService(clusterScope)
+
+	launchTemplate, err := ec2svc.ReconcileLaunchTemplate(machinePoolScope)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile launch template")
+	}
+	machinePoolScope.SetLaunchTemplate(launchTemplate.ID, launchTemplate.LatestVersion)
+
+	asg, err := asgsvc.GetASGByName(machinePoolScope)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to query Auto Scaling Group")
+	}
+
+	// Auto Scaling Group names are unique per account and region, don't take over
+	// one that was created for another cluster or outside of the provider.
+	if asg != nil && !asg.Tags.HasOwned(clusterScope.Name()) {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedCreate", "Auto Scaling Group %q is not owned by cluster %q", asg.Name, clusterScope.Name())
+		return reconcile.Result{}, errors.Errorf("Auto Scaling Group %q exists but is not owned by cluster %q", asg.Name, clusterScope.Name())
+	}
+
+	if asg == nil {
+		// Create a new Auto Scaling Group if we couldn't find one.
+		asg, err = asgsvc.CreateASG(machinePoolScope, launchTemplate.ID)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to create Auto Scaling Group")
+		}
+		if asg == nil {
+			machinePoolScope.Info("Auto Scaling Group is not yet available")
+			return reconcile.Result{RequeueAfter: machinePoolRequeueAfter}, nil
+		}
+	} else {
+		needsUpdate, err := asgsvc.ASGNeedsUpdate(machinePoolScope, asg, launchTemplate.ID)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if needsUpdate {
+			if err := asgsvc.UpdateASG(machinePoolScope, launchTemplate.ID); err != nil {
+				return reconcile.Result{}, errors.Wrap(err, "failed to update Auto Scaling Group")
+			}
+		}
+	}
+
+	machinePoolScope.UpdateInstanceStatuses(asg)
+	machinePoolScope.SetReady()
+
+	// Instances come and go without generating events we watch, keep polling
+	// until the group settles on the desired number of healthy instances.
+	if machinePoolScope.AWSMachinePool.Status.ReadyReplicas != machinePoolScope.DesiredReplicas() {
+		machinePoolScope.Info("Auto Scaling Group instances are not yet in service",
+			"ready", machinePoolScope.AWSMachinePool.Status.ReadyReplicas,
+			"desired", machinePoolScope.DesiredReplicas())
+		return reconcile.Result{RequeueAfter: machinePoolRequeueAfter}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.MachinePoolScope, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	machinePoolScope.Info("Handling deleted AWSMachinePool")
+
+	ec2svc := r.getEC2Service(clusterScope)
+	asgsvc := r.getASGService(clusterScope)
+
+	asg, err := asgsvc.GetASGByName(machinePoolScope)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to query Auto Scaling Group")
+	}
+
+	switch {
+	case asg == nil:
+		machinePoolScope.V(2).Info("Unable to locate Auto Scaling Group")
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "NoASGFound", "Unable to find matching Auto Scaling Group")
+	case !asg.Tags.HasOwned(clusterScope.Name()):
+		machinePoolScope.Info("Skipping deletion of Auto Scaling Group not owned by the cluster", "name", asg.Name)
+	default:
+		machinePoolScope.SetNotReady()
+		machinePoolScope.Info("Deleting Auto Scaling Group", "name", asg.Name)
+		if err := asgsvc.DeleteASGAndWait(asg.Name); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete Auto Scaling Group %q: %v", asg.Name, err)
+			return reconcile.Result{}, errors.Wrap(err, "failed to delete Auto Scaling Group")
+		}
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulDelete", "Deleted Auto Scaling Group %q", asg.Name)
+	}
+
+	launchTemplate, err := ec2svc.GetLaunchTemplate(machinePoolScope.LaunchTemplateName())
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to query launch template")
+	}
+
+	switch {
+	case launchTemplate == nil:
+		machinePoolScope.V(2).Info("Unable to locate launch template")
+	case !launchTemplate.Tags.HasOwned(clusterScope.Name()):
+		machinePoolScope.Info("Skipping deletion of launch template not owned by the cluster", "id", launchTemplate.ID)
+	default:
+		machinePoolScope.Info("Deleting launch template", "id", launchTemplate.ID)
+		if err := ec2svc.DeleteLaunchTemplate(launchTemplate.ID); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete launch template %q: %v", launchTemplate.ID, err)
+			return reconcile.Result{}, errors.Wrap(err, "failed to delete launch template")
+		}
+	}
+
+	// Auto Scaling Group and launch template are deleted so remove the finalizer.
+	machinePoolScope.AWSMachinePool.Finalizers = util.Filter(machinePoolScope.AWSMachinePool.Finalizers, infrav1.MachinePoolFinalizer)
+
+	return reconcile.Result{}, nil
+}
+
+// AWSClusterToAWSMachinePools is a handler.ToRequestsFunc to be used to enqeue requests for reconciliation
+// of AWSMachinePools.
+func (r *AWSMachinePoolReconciler) AWSClusterToAWSMachinePools(o handler.MapObject) []ctrl.Request {
+	result := []ctrl.Request{}
+
+	c, ok := o.Object.(*infrav1.AWSCluster)
+	if !ok {
+		r.Log.Error(errors.Errorf("expected a AWSCluster but got a %T", o.Object), "failed to get AWSMachinePools for AWSCluster")
+		return nil
+	}
+	log := r.Log.WithValues("AWSCluster", c.Name, "Namespace", c.Namespace)
+
+	cluster, err := util.GetOwnerCluster(context.TODO(), r.Client, c.ObjectMeta)
+	switch {
+	case apierrors.IsNotFound(err) || cluster == nil:
+		return result
+	case err != nil:
+		log.Error(err, "failed to get owning cluster")
+		return result
+	}
+
+	labels := map[string]string{clusterv1.ClusterLabelName: cluster.Name}
+	poolList := &infrav1.AWSMachinePoolList{}
+	if err := r.List(context.TODO(), poolList, client.InNamespace(c.Namespace), client.MatchingLabels(labels)); err != nil {
+		log.Error(err, "failed to list AWSMachinePools")
+		return nil
+	}
+	for _, p := range poolList.Items {
+		name := client.ObjectKey{Namespace: p.Namespace, Name: p.Name}
+		result = append(result, ctrl.Request{NamespacedName: name})
+	}
+
+	return result
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"flag"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope" //nolint
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/mock_services" //nolint
+)
+
+var _ = Describe("AWSMachinePoolReconciler", func() {
+	var (
+		reconciler AWSMachinePoolReconciler
+		cs         *scope.ClusterScope
+		ms         *scope.MachinePoolScope
+		mockCtrl   *gomock.Controller
+		ec2Svc     *mock_services.MockEC2MachinePoolInterface
+		asgSvc     *mock_services.MockASGInterface
+		recorder   *record.FakeRecorder
+	)
+
+	ownedTags := infrav1.Tags{infrav1.ClusterTagKey("test"): string(infrav1.ResourceLifecycleOwned)}
+
+	BeforeEach(func() {
+		// https://github.com/kubernetes/klog/issues/87#issuecomment-540153080
+		// TODO: Replace with LogToOutput when https://github.com/kubernetes/klog/pull/99 merges
+		flag.Set("logtostderr", "false")
+		flag.Set("v", "2")
+		klog.SetOutput(GinkgoWriter)
+
+		scheme, err := setupScheme()
+		Expect(err).To(BeNil())
+
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Status: clusterv1.ClusterStatus{
+				InfrastructureReady: true,
+			},
+		}
+		awsCluster := &infrav1.AWSCluster{}
+
+		ms, err = scope.NewMachinePoolScope(
+			scope.MachinePoolScopeParams{
+				Client:     fake.NewFakeClientWithScheme(scheme),
+				Cluster:    cluster,
+				AWSCluster: awsCluster,
+				AWSMachinePool: &infrav1.AWSMachinePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+					Spec: infrav1.AWSMachinePoolSpec{
+						MinSize: 1,
+						MaxSize: 3,
+						Bootstrap: clusterv1.Bootstrap{
+							Data: pointer.StringPtr("best pony: all of them"),
+						},
+					},
+				},
+			},
+		)
+		Expect(err).To(BeNil())
+
+		cs, err = scope.NewClusterScope(
+			scope.ClusterScopeParams{
+				Cluster:    cluster,
+				AWSCluster: awsCluster,
+			},
+		)
+		Expect(err).To(BeNil())
+
+		mockCtrl = gomock.NewController(GinkgoT())
+		ec2Svc = mock_services.NewMockEC2MachinePoolInterface(mockCtrl)
+		asgSvc = mock_services.NewMockASGInterface(mockCtrl)
+
+		recorder = record.NewFakeRecorder(10)
+
+		reconciler = AWSMachinePoolReconciler{
+			ec2ServiceFactory: func(*scope.ClusterScope) services.EC2MachinePoolInterface {
+				return ec2Svc
+			},
+			asgServiceFactory: func(*scope.ClusterScope) services.ASGInterface {
+				return asgSvc
+			},
+			Recorder: recorder,
+		}
+	})
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("Reconciling an AWSMachinePool", func() {
+		It("should name the Auto Scaling Group and launch template after the cluster and pool", func() {
+			Expect(ms.ASGName()).To(Equal("test-pool"))
+			Expect(ms.LaunchTemplateName()).To(Equal("test-pool"))
+		})
+
+		It("should exit immediately on an error state", func() {
+			er := capierrors.CreateMachineError
+			ms.AWSMachinePool.Status.ErrorReason = &er
+			ms.AWSMachinePool.Status.ErrorMessage = pointer.StringPtr("Couldn't create machine pool")
+
+			buf := new(bytes.Buffer)
+			klog.SetOutput(buf)
+
+			_, err := reconciler.reconcileNormal(ms, cs)
+			Expect(err).To(BeNil())
+			Expect(buf).To(ContainSubstring("Error state detected, skipping reconciliation"))
+		})
+
+		It("should add our finalizer to the machine pool", func() {
+			ms.Cluster.Status.InfrastructureReady = false
+
+			_, _ = reconciler.reconcileNormal(ms, cs)
+
+			Expect(ms.AWSMachinePool.Finalizers).To(ContainElement(infrav1.MachinePoolFinalizer))
+		})
+
+		It("should exit immediately if bootstrap data isn't available", func() {
+			ms.AWSMachinePool.Spec.Bootstrap.Data = nil
+
+			buf := new(bytes.Buffer)
+			klog.SetOutput(buf)
+
+			_, err := reconciler.reconcileNormal(ms, cs)
+			Expect(err).To(BeNil())
+			Expect(buf).To(ContainSubstring("Bootstrap data is not yet available"))
+		})
+
+		It("should create the launch template and Auto Scaling Group", func() {
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(ms).Return(&infrav1.LaunchTemplate{ID: "lt-1", LatestVersion: aws.Int64(1)}, nil)
+			asgSvc.EXPECT().GetASGByName(ms).Return(nil, nil)
+			asgSvc.EXPECT().CreateASG(ms, "lt-1").Return(&infrav1.AutoScalingGroup{
+				Name: "test-pool",
+				Tags: ownedTags,
+				Instances: []infrav1.AutoScalingGroupInstance{
+					{ID: "i-1", LifecycleState: "InService", HealthStatus: "Healthy"},
+				},
+			}, nil)
+
+			res, err := reconciler.reconcileNormal(ms, cs)
+			Expect(err).To(BeNil())
+			Expect(res.RequeueAfter).To(Equal(time.Duration(0)))
+			Expect(ms.AWSMachinePool.Status.Ready).To(BeTrue())
+			Expect(ms.AWSMachinePool.Status.LaunchTemplateID).To(Equal("lt-1"))
+			Expect(ms.AWSMachinePool.Status.ProviderIDList).To(ConsistOf("aws:////i-1"))
+		})
+
+		It("should update an Auto Scaling Group that drifted from the spec", func() {
+			asg := &infrav1.AutoScalingGroup{Name: "test-pool", Tags: ownedTags}
+
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(ms).Return(&infrav1.LaunchTemplate{ID: "lt-1"}, nil)
+			asgSvc.EXPECT().GetASGByName(ms).Return(asg, nil)
+			asgSvc.EXPECT().ASGNeedsUpdate(ms, asg, "lt-1").Return(true, nil)
+			asgSvc.EXPECT().UpdateASG(ms, "lt-1").Return(nil)
+
+			res, err := reconciler.reconcileNormal(ms, cs)
+			Expect(err).To(BeNil())
+			Expect(res.RequeueAfter).To(Equal(machinePoolRequeueAfter))
+		})
+
+		It("should refuse to adopt an Auto Scaling Group owned by someone else", func() {
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(ms).Return(&infrav1.LaunchTemplate{ID: "lt-1"}, nil)
+			asgSvc.EXPECT().GetASGByName(ms).Return(&infrav1.AutoScalingGroup{
+				Name: "test-pool",
+				Tags: infrav1.Tags{infrav1.ClusterTagKey("other"): string(infrav1.ResourceLifecycleOwned)},
+			}, nil)
+
+			_, err := reconciler.reconcileNormal(ms, cs)
+			Expect(err).NotTo(BeNil())
+			Expect(ms.AWSMachinePool.Status.Ready).To(BeFalse())
+		})
+
+		It("should surface launch template errors", func() {
+			ec2Svc.EXPECT().ReconcileLaunchTemplate(ms).Return(nil, errors.New("no connection available"))
+
+			_, err := reconciler.reconcileNormal(ms, cs)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Context("Deleting an AWSMachinePool", func() {
+		BeforeEach(func() {
+			ms.AWSMachinePool.Finalizers = []string{infrav1.MachinePoolFinalizer}
+		})
+
+		It("should delete the Auto Scaling Group and launch template", func() {
+			asgSvc.EXPECT().GetASGByName(ms).Return(&infrav1.AutoScalingGroup{Name: "test-pool", Tags: ownedTags}, nil)
+			asgSvc.EXPECT().DeleteASGAndWait("test-pool").Return(nil)
+			ec2Svc.EXPECT().GetLaunchTemplate("test-pool").Return(&infrav1.LaunchTemplate{
+				ID:   "lt-1",
+				Tags: ownedTags,
+			}, nil)
+			ec2Svc.EXPECT().DeleteLaunchTemplate("lt-1").Return(nil)
+
+			_, err := reconciler.reconcileDelete(ms, cs)
+			Expect(err).To(BeNil())
+			Expect(ms.AWSMachinePool.Finalizers).To(BeEmpty())
+		})
+
+		It("should leave resources owned by someone else alone", func() {
+			asgSvc.EXPECT().GetASGByName(ms).Return(&infrav1.AutoScalingGroup{Name: "test-pool"}, nil)
+			ec2Svc.EXPECT().GetLaunchTemplate("test-pool").Return(&infrav1.LaunchTemplate{ID: "lt-1"}, nil)
+
+			_, err := reconciler.reconcileDelete(ms, cs)
+			Expect(err).To(BeNil())
+			Expect(ms.AWSMachinePool.Finalizers).To(BeEmpty())
+		})
+
+		It("should keep the finalizer if the Auto Scaling Group can't be deleted", func() {
+			asgSvc.EXPECT().GetASGByName(ms).Return(&infrav1.AutoScalingGroup{Name: "test-pool", Tags: ownedTags}, nil)
+			asgSvc.EXPECT().DeleteASGAndWait("test-pool").Return(errors.New("no connection available"))
+
+			_, err := reconciler.reconcileDelete(ms, cs)
+			Expect(err).NotTo(BeNil())
+			Expect(ms.AWSMachinePool.Finalizers).To(ContainElement(infrav1.MachinePoolFinalizer))
+		})
+	})
+})
@@ -55,15 +55,16 @@ func main() {
 	klog.InitFlags(nil)
 
 	var (
-		metricsAddr             string
-		enableLeaderElection    bool
-		leaderElectionNamespace string
-		watchNamespace          string
-		profilerAddress         string
-		awsClusterConcurrency   int
-		awsMachineConcurrency   int
-		syncPeriod              time.Duration
-		webhookPort             int
+		metricsAddr               string
+		enableLeaderElection      bool
+		leaderElectionNamespace   string
+		watchNamespace            string
+		profilerAddress           string
+		awsClusterConcurrency     int
+		awsMachineConcurrency     int
+		awsMachinePoolConcurrency int
+		syncPeriod                time.Duration
+		webhookPort               int
 	)
 
 	flag.StringVar(
@@ -113,6 +114,12 @@ func main() {
 		"Number of AWSMachines to process simultaneously",
 	)
 
+	flag.IntVar(&awsMachinePoolConcurrency,
+		"awsmachinepool-concurrency",
+		5,
+		"Number of AWSMachinePools to process simultaneously",
+	)
+
 	flag.DurationVar(&syncPeriod,
 		"sync-period",
 		10*time.Minute,
@@ -180,12 +187,40 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSCluster")
 		os.Exit(1)
 	}
+	if err = (&controllers.AWSMachinePoolReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("AWSMachinePool"),
+		Recorder: mgr.GetEventRecorderFor("awsmachinepool-controller"),
+	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: awsMachinePoolConcurrency}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AWSMachinePool")
+		os.Exit(1)
+	}
 
 	if webhookPort != 0 {
 		if err = (&infrav1alpha3.AWSMachineTemplate{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachineTemplate")
 			os.Exit(1)
 		}
+		if err = (&infrav1alpha3.AWSMachineTemplateList{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachineTemplateList")
+			os.Exit(1)
+		}
+		if err = (&infrav1alpha3.AWSCluster{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AWSCluster")
+			os.Exit(1)
+		}
+		if err = (&infrav1alpha3.AWSClusterList{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AWSClusterList")
+			os.Exit(1)
+		}
+		if err = (&infrav1alpha3.AWSMachine{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachine")
+			os.Exit(1)
+		}
+		if err = (&infrav1alpha3.AWSMachineList{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachineList")
+			os.Exit(1)
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
@@ -24,6 +24,7 @@ import (
 
 const (
 	AuthFailure             = "AuthFailure"
+	DependencyViolation     = "DependencyViolation"
 	InUseIPAddress          = "InvalidIPAddress.InUse"
 	GroupNotFound           = "InvalidGroup.NotFound"
 	PermissionNotFound      = "InvalidPermission.NotFound"
@@ -38,6 +39,8 @@ const (
 	ResourceNotFound        = "InvalidResourceID.NotFound"
 	InvalidSubnet           = "InvalidSubnet"
 	AssociationIDNotFound   = "InvalidAssociationID.NotFound"
+	LaunchTemplateNotFound  = "InvalidLaunchTemplateName.NotFoundException"
+	VPCEndpointNotFound     = "InvalidVpcEndpointId.NotFound"
 )
 
 var _ error = &EC2Error{}
@@ -26,7 +26,7 @@ import (
 
 // SDKToInstance converts an EC2 instance type to the CAPA
 // instance type.
-// Note: This does not return a complete instance, as rootVolumeSize
+// Note: This does not return a complete instance, as the volumes
 // can not be determined via the output of EC2.DescribeInstances.
 func SDKToInstance(v *ec2.Instance) *infrav1.Instance {
 	i := &infrav1.Instance{
@@ -40,6 +40,18 @@ func SDKToInstance(v *ec2.Instance) *infrav1.Instance {
 		PublicIP:     v.PublicIpAddress,
 		ENASupport:   v.EnaSupport,
 		EBSOptimized: v.EbsOptimized,
+
+		SpotInstanceRequestID: v.SpotInstanceRequestId,
+	}
+
+	// EC2 only reports a lifecycle for spot and scheduled instances.
+	i.Lifecycle = infrav1.InstanceLifecycleOnDemand
+	if v.InstanceLifecycle != nil {
+		i.Lifecycle = infrav1.InstanceLifecycle(*v.InstanceLifecycle)
+	}
+
+	if v.StateReason != nil {
+		i.StateReason = v.StateReason.Code
 	}
 
 	// Extract IAM Instance Profile name from ARN
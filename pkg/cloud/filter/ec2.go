@@ -120,6 +120,22 @@ func (ec2Filters) NATGatewayStates(states ...string) *ec2.Filter {
 	}
 }
 
+// TransitGateway returns a filter based on the id of the transit gateway.
+func (ec2Filters) TransitGateway(transitGatewayID string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("transit-gateway-id"),
+		Values: aws.StringSlice([]string{transitGatewayID}),
+	}
+}
+
+// TransitGatewayAttachmentStates returns a filter based on the list of states passed in.
+func (ec2Filters) TransitGatewayAttachmentStates(states ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String(filterNameState),
+		Values: aws.StringSlice(states),
+	}
+}
+
 // InstanceStates returns a filter based on the list of states passed in.
 func (ec2Filters) InstanceStates(states ...string) *ec2.Filter {
 	return &ec2.Filter{
@@ -17,14 +17,83 @@ limitations under the License.
 package scope
 
 import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // AWSClients contains all the aws clients used by the scopes.
 type AWSClients struct {
 	EC2             ec2iface.EC2API
 	ELB             elbiface.ELBAPI
+	ELBV2           elbv2iface.ELBV2API
 	ResourceTagging resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+	ASG             autoscalingiface.AutoScalingAPI
+}
+
+// newAWSClients fills in any client not already set using a session resolved
+// from the AWSCluster's identity and region. Permission issues are recorded
+// as events on target.
+func newAWSClients(c client.Client, awsCluster *infrav1.AWSCluster, clients AWSClients, target runtime.Object) (AWSClients, error) {
+	session, err := sessionForCluster(c, awsCluster)
+	if err != nil {
+		return clients, errors.Errorf("failed to create aws session: %v", err)
+	}
+
+	if clients.EC2 == nil {
+		ec2Client := ec2.New(session)
+		ec2Client.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+		clients.EC2 = ec2Client
+	}
+
+	if clients.ELB == nil {
+		elbClient := elb.New(session)
+		elbClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+		clients.ELB = elbClient
+	}
+
+	if clients.ELBV2 == nil {
+		elbv2Client := elbv2.New(session)
+		elbv2Client.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+		clients.ELBV2 = elbv2Client
+	}
+
+	if clients.ResourceTagging == nil {
+		resourceTagging := resourcegroupstaggingapi.New(session)
+		resourceTagging.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+		clients.ResourceTagging = resourceTagging
+	}
+
+	if clients.ASG == nil {
+		asgClient := autoscaling.New(session)
+		asgClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+		clients.ASG = asgClient
+	}
+
+	return clients, nil
+}
+
+func recordAWSPermissionsIssue(target runtime.Object) func(r *request.Request) {
+	return func(r *request.Request) {
+		if awsErr, ok := r.Error.(awserr.Error); ok {
+			switch awsErr.Code() {
+			case "AuthFailure", "UnauthorizedOperation":
+				record.Warnf(target, awsErr.Code(), "Operation %s failed with a credentials or permission issue", r.Operation.Name)
+			}
+		}
+	}
 }
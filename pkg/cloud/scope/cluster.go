@@ -20,17 +20,10 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/request"
-	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/aws/aws-sdk-go/service/elb"
-	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/klogr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
-	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -59,27 +52,9 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 		params.Logger = klogr.New()
 	}
 
-	session, err := sessionForRegion(params.AWSCluster.Spec.Region)
+	awsClients, err := newAWSClients(params.Client, params.AWSCluster, params.AWSClients, params.AWSCluster)
 	if err != nil {
-		return nil, errors.Errorf("failed to create aws session: %v", err)
-	}
-
-	if params.AWSClients.EC2 == nil {
-		ec2Client := ec2.New(session)
-		ec2Client.Handlers.Complete.PushBack(recordAWSPermissionsIssue(params.AWSCluster))
-		params.AWSClients.EC2 = ec2Client
-	}
-
-	if params.AWSClients.ELB == nil {
-		elbClient := elb.New(session)
-		elbClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(params.AWSCluster))
-		params.AWSClients.ELB = elbClient
-	}
-
-	if params.AWSClients.ResourceTagging == nil {
-		resourceTagging := resourcegroupstaggingapi.New(session)
-		resourceTagging.Handlers.Complete.PushBack(recordAWSPermissionsIssue(params.AWSCluster))
-		params.AWSClients.ResourceTagging = resourceTagging
+		return nil, err
 	}
 
 	helper, err := patch.NewHelper(params.AWSCluster, params.Client)
@@ -89,24 +64,13 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 	return &ClusterScope{
 		Logger:      params.Logger,
 		client:      params.Client,
-		AWSClients:  params.AWSClients,
+		AWSClients:  awsClients,
 		Cluster:     params.Cluster,
 		AWSCluster:  params.AWSCluster,
 		patchHelper: helper,
 	}, nil
 }
 
-func recordAWSPermissionsIssue(target runtime.Object) func(r *request.Request) {
-	return func(r *request.Request) {
-		if awsErr, ok := r.Error.(awserr.Error); ok {
-			switch awsErr.Code() {
-			case "AuthFailure", "UnauthorizedOperation":
-				record.Warnf(target, awsErr.Code(), "Operation %s failed with a credentials or permission issue", r.Operation.Name)
-			}
-		}
-	}
-}
-
 // ClusterScope defines the basic context for an actuator to operate upon.
 type ClusterScope struct {
 	logr.Logger
@@ -133,6 +97,35 @@ func (s *ClusterScope) Subnets() infrav1.Subnets {
 	return s.AWSCluster.Spec.NetworkSpec.Subnets
 }
 
+// SecurityGroupOverrides returns the existing security groups to use instead
+// of creating them, by role.
+func (s *ClusterScope) SecurityGroupOverrides() map[infrav1.SecurityGroupRole]string {
+	return s.AWSCluster.Spec.NetworkSpec.SecurityGroupOverrides
+}
+
+// CNI returns the CNI plugin configuration of the cluster, if any.
+func (s *ClusterScope) CNI() *infrav1.CNISpec {
+	return s.AWSCluster.Spec.NetworkSpec.CNI
+}
+
+// IsIsolated returns true if the cluster network has no access to the internet.
+func (s *ClusterScope) IsIsolated() bool {
+	return s.AWSCluster.Spec.NetworkSpec.Isolated
+}
+
+// NatGatewayStrategy returns how NAT gateways are created for the private subnets (defaults to per-az).
+func (s *ClusterScope) NatGatewayStrategy() infrav1.NatGatewayStrategy {
+	if s.AWSCluster.Spec.NetworkSpec.NatGatewayStrategy != "" {
+		return s.AWSCluster.Spec.NetworkSpec.NatGatewayStrategy
+	}
+	return infrav1.NatGatewayStrategyPerAZ
+}
+
+// TransitGateway returns the transit gateway the VPC is attached to, if any.
+func (s *ClusterScope) TransitGateway() *infrav1.TransitGatewaySpec {
+	return s.AWSCluster.Spec.NetworkSpec.TransitGateway
+}
+
 // SecurityGroups returns the cluster security groups as a map, it creates the map if empty.
 func (s *ClusterScope) SecurityGroups() map[infrav1.SecurityGroupRole]infrav1.SecurityGroup {
 	return s.AWSCluster.Status.Network.SecurityGroups
@@ -153,6 +146,11 @@ func (s *ClusterScope) Region() string {
 	return s.AWSCluster.Spec.Region
 }
 
+// Bastion returns the bastion host configuration.
+func (s *ClusterScope) Bastion() *infrav1.Bastion {
+	return &s.AWSCluster.Spec.Bastion
+}
+
 // ControlPlaneLoadBalancer returns the AWSLoadBalancerSpec
 func (s *ClusterScope) ControlPlaneLoadBalancer() *infrav1.AWSLoadBalancerSpec {
 	return s.AWSCluster.Spec.ControlPlaneLoadBalancer
@@ -160,12 +158,23 @@ func (s *ClusterScope) ControlPlaneLoadBalancer() *infrav1.AWSLoadBalancerSpec {
 
 // ControlPlaneLoadBalancerScheme returns the Classic ELB scheme (public or internal facing)
 func (s *ClusterScope) ControlPlaneLoadBalancerScheme() infrav1.ClassicELBScheme {
+	if s.IsIsolated() {
+		return infrav1.ClassicELBSchemeInternal
+	}
 	if s.ControlPlaneLoadBalancer() != nil && s.ControlPlaneLoadBalancer().Scheme != nil {
 		return *s.ControlPlaneLoadBalancer().Scheme
 	}
 	return infrav1.ClassicELBSchemeInternetFacing
 }
 
+// ControlPlaneLoadBalancerType returns the type of load balancer fronting the API server (defaults to classic)
+func (s *ClusterScope) ControlPlaneLoadBalancerType() infrav1.LoadBalancerType {
+	if s.ControlPlaneLoadBalancer() != nil && s.ControlPlaneLoadBalancer().LoadBalancerType != "" {
+		return s.ControlPlaneLoadBalancer().LoadBalancerType
+	}
+	return infrav1.LoadBalancerTypeClassic
+}
+
 // ControlPlaneConfigMapName returns the name of the ConfigMap used to
 // coordinate the bootstrapping of control plane nodes.
 func (s *ClusterScope) ControlPlaneConfigMapName() string {
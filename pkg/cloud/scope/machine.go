@@ -149,6 +149,11 @@ func (m *MachineScope) SetInstanceState(v infrav1.InstanceState) {
 	m.AWSMachine.Status.InstanceState = &v
 }
 
+// SetInstanceLifecycle sets the AWSMachine instance lifecycle.
+func (m *MachineScope) SetInstanceLifecycle(v infrav1.InstanceLifecycle) {
+	m.AWSMachine.Status.InstanceLifecycle = &v
+}
+
 // SetReady sets the AWSMachine Ready Status
 func (m *MachineScope) SetReady() {
 	m.AWSMachine.Status.Ready = true
@@ -0,0 +1,204 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/klog/klogr"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MachinePoolScopeParams defines the input parameters used to create a new MachinePoolScope.
+type MachinePoolScopeParams struct {
+	Client         client.Client
+	Logger         logr.Logger
+	Cluster        *clusterv1.Cluster
+	AWSCluster     *infrav1.AWSCluster
+	AWSMachinePool *infrav1.AWSMachinePool
+}
+
+// NewMachinePoolScope creates a new MachinePoolScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewMachinePoolScope(params MachinePoolScopeParams) (*MachinePoolScope, error) {
+	if params.Client == nil {
+		return nil, errors.New("client is required when creating a MachinePoolScope")
+	}
+	if params.Cluster == nil {
+		return nil, errors.New("cluster is required when creating a MachinePoolScope")
+	}
+	if params.AWSMachinePool == nil {
+		return nil, errors.New("aws machine pool is required when creating a MachinePoolScope")
+	}
+	if params.AWSCluster == nil {
+		return nil, errors.New("aws cluster is required when creating a MachinePoolScope")
+	}
+
+	if params.Logger == nil {
+		params.Logger = klogr.New()
+	}
+
+	helper, err := patch.NewHelper(params.AWSMachinePool, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+	return &MachinePoolScope{
+		Logger:      params.Logger,
+		client:      params.Client,
+		patchHelper: helper,
+
+		Cluster:        params.Cluster,
+		AWSCluster:     params.AWSCluster,
+		AWSMachinePool: params.AWSMachinePool,
+	}, nil
+}
+
+// MachinePoolScope defines a scope defined around a machine pool and its cluster.
+type MachinePoolScope struct {
+	logr.Logger
+	client      client.Client
+	patchHelper *patch.Helper
+
+	Cluster        *clusterv1.Cluster
+	AWSCluster     *infrav1.AWSCluster
+	AWSMachinePool *infrav1.AWSMachinePool
+}
+
+// Name returns the AWSMachinePool name.
+func (m *MachinePoolScope) Name() string {
+	return m.AWSMachinePool.Name
+}
+
+// Namespace returns the namespace name.
+func (m *MachinePoolScope) Namespace() string {
+	return m.AWSMachinePool.Namespace
+}
+
+// Role returns the machine pool role. Machine pools only run worker nodes.
+func (m *MachinePoolScope) Role() string {
+	return "node"
+}
+
+// ASGName returns the name of the Auto Scaling Group for the pool. Auto Scaling Group
+// names are unique per account and region, so the cluster name is used as a prefix.
+func (m *MachinePoolScope) ASGName() string {
+	return fmt.Sprintf("%s-%s", m.Cluster.Name, m.Name())
+}
+
+// LaunchTemplateName returns the name of the launch template for the pool,
+// defaulting to the Auto Scaling Group name.
+func (m *MachinePoolScope) LaunchTemplateName() string {
+	if m.AWSMachinePool.Spec.AWSLaunchTemplate.Name != "" {
+		return m.AWSMachinePool.Spec.AWSLaunchTemplate.Name
+	}
+	return m.ASGName()
+}
+
+// DesiredReplicas returns the desired number of instances in the pool,
+// defaulting to the minimum size of the pool.
+func (m *MachinePoolScope) DesiredReplicas() int32 {
+	if m.AWSMachinePool.Spec.Replicas != nil {
+		return *m.AWSMachinePool.Spec.Replicas
+	}
+	return m.AWSMachinePool.Spec.MinSize
+}
+
+// GetBootstrapData returns the bootstrap data for the pool instances, if available.
+func (m *MachinePoolScope) GetBootstrapData() *string {
+	return m.AWSMachinePool.Spec.Bootstrap.Data
+}
+
+// SetReady sets the AWSMachinePool Ready Status.
+func (m *MachinePoolScope) SetReady() {
+	m.AWSMachinePool.Status.Ready = true
+}
+
+// SetNotReady sets the AWSMachinePool Ready Status to false.
+func (m *MachinePoolScope) SetNotReady() {
+	m.AWSMachinePool.Status.Ready = false
+}
+
+// SetErrorMessage sets the AWSMachinePool status error message.
+func (m *MachinePoolScope) SetErrorMessage(v error) {
+	m.AWSMachinePool.Status.ErrorMessage = pointer.StringPtr(v.Error())
+}
+
+// SetErrorReason sets the AWSMachinePool status error reason.
+func (m *MachinePoolScope) SetErrorReason(v capierrors.MachineStatusError) {
+	m.AWSMachinePool.Status.ErrorReason = &v
+}
+
+// SetLaunchTemplate records the launch template ID and version in the AWSMachinePool status.
+func (m *MachinePoolScope) SetLaunchTemplate(id string, version *int64) {
+	m.AWSMachinePool.Status.LaunchTemplateID = id
+	m.AWSMachinePool.Status.LaunchTemplateVersion = version
+}
+
+// UpdateInstanceStatuses updates the AWSMachinePool status from the instances of the Auto Scaling Group.
+func (m *MachinePoolScope) UpdateInstanceStatuses(asg *infrav1.AutoScalingGroup) {
+	statuses := make([]infrav1.AWSMachinePoolInstanceStatus, 0, len(asg.Instances))
+	providerIDs := make([]string, 0, len(asg.Instances))
+	var ready int32
+
+	for _, i := range asg.Instances {
+		status := infrav1.AWSMachinePoolInstanceStatus{
+			InstanceID:    i.ID,
+			InstanceState: i.LifecycleState,
+			HealthStatus:  i.HealthStatus,
+		}
+		if i.LaunchTemplateVersion != "" {
+			status.Version = pointer.StringPtr(i.LaunchTemplateVersion)
+		}
+		statuses = append(statuses, status)
+		providerIDs = append(providerIDs, fmt.Sprintf("aws:////%s", i.ID))
+
+		if i.LifecycleState == "InService" && i.HealthStatus == "Healthy" {
+			ready++
+		}
+	}
+
+	m.AWSMachinePool.Status.Instances = statuses
+	m.AWSMachinePool.Status.ProviderIDList = providerIDs
+	m.AWSMachinePool.Status.Replicas = int32(len(asg.Instances))
+	m.AWSMachinePool.Status.ReadyReplicas = ready
+}
+
+// Close the MachinePoolScope by updating the machine pool spec, machine pool status.
+func (m *MachinePoolScope) Close() error {
+	return m.patchHelper.Patch(context.TODO(), m.AWSMachinePool)
+}
+
+// AdditionalTags merges AdditionalTags from the scope's AWSCluster and AWSMachinePool. If the same key is present in both,
+// the value from AWSMachinePool takes precedence. The returned Tags will never be nil.
+func (m *MachinePoolScope) AdditionalTags() infrav1.Tags {
+	tags := make(infrav1.Tags)
+
+	// Start with the cluster-wide tags...
+	tags.Merge(m.AWSCluster.Spec.AdditionalTags)
+	// ... and merge in the AWSMachinePool's
+	tags.Merge(m.AWSMachinePool.Spec.AdditionalTags)
+
+	return tags
+}
@@ -17,27 +17,132 @@ limitations under the License.
 package scope
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/sts"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
 	sessionCache sync.Map
 )
 
-func sessionForRegion(region string) (*session.Session, error) {
-	s, ok := sessionCache.Load(region)
-	if ok {
-		return s.(*session.Session), nil
+// sessionCacheKey identifies a cached session. An empty identity refers to
+// the controller's own credentials.
+type sessionCacheKey struct {
+	identity string
+	region   string
+}
+
+// sessionCacheEntry is a cached session along with the resource version of
+// the identity Secret it was built from.
+type sessionCacheEntry struct {
+	resourceVersion string
+	session         *session.Session
+}
+
+// sessionForCluster returns a session for the AWSCluster's region using the
+// credentials referenced by its identity, falling back to the controller's
+// own credentials when no identity is set. When a role identity is set, the
+// role is assumed using those credentials. Cached sessions are rebuilt
+// whenever the identity Secret changes.
+func sessionForCluster(c client.Client, awsCluster *infrav1.AWSCluster) (*session.Session, error) {
+	key := sessionCacheKey{region: awsCluster.Spec.Region}
+	config := aws.NewConfig().WithRegion(awsCluster.Spec.Region)
+
+	var secret *corev1.Secret
+	if ref := awsCluster.Spec.IdentityRef; ref != nil {
+		if c == nil {
+			return nil, errors.New("client is required to resolve the AWSCluster identity")
+		}
+
+		secret = &corev1.Secret{}
+		secretName := client.ObjectKey{Namespace: awsCluster.Namespace, Name: ref.Name}
+		if err := c.Get(context.TODO(), secretName, secret); err != nil {
+			return nil, errors.Wrapf(err, "failed to get identity secret %s", secretName)
+		}
+		key.identity = secretName.String()
+	}
+
+	role := awsCluster.Spec.RoleIdentity
+	if role != nil {
+		key.identity = key.identity + "|" + roleIdentityKey(role)
+	}
+
+	if s, ok := sessionCache.Load(key); ok {
+		entry := s.(*sessionCacheEntry)
+		if secret == nil || entry.resourceVersion == secret.ResourceVersion {
+			return entry.session, nil
+		}
+	}
+
+	entry := &sessionCacheEntry{}
+	if secret != nil {
+		creds, err := credentialsFromSecret(secret)
+		if err != nil {
+			return nil, err
+		}
+		config = config.WithCredentials(creds)
+		entry.resourceVersion = secret.ResourceVersion
 	}
 
-	ns, err := session.NewSession(aws.NewConfig().WithRegion(region))
+	ns, err := session.NewSession(config)
 	if err != nil {
 		return nil, err
 	}
 
-	sessionCache.Store(region, ns)
+	if role != nil {
+		ns = ns.Copy(aws.NewConfig().WithCredentials(sts.NewAssumeRoleCredentials(ns, role)))
+	}
+
+	entry.session = ns
+	sessionCache.Store(key, entry)
 	return ns, nil
 }
+
+// roleIdentityKey returns a string uniquely identifying the chain of roles
+// assumed for a role identity.
+func roleIdentityKey(role *infrav1.AWSRoleIdentity) string {
+	roleKey := func(r infrav1.AWSRoleSpec) string {
+		// Session tags are part of the assumed session, so a change to them
+		// must not reuse credentials obtained with the previous tags.
+		tags := make([]string, 0, len(r.SessionTags))
+		for k, v := range r.SessionTags {
+			tags = append(tags, k+"="+v)
+		}
+		sort.Strings(tags)
+		transitive := append([]string{}, r.TransitiveTagKeys...)
+		sort.Strings(transitive)
+
+		return fmt.Sprintf("%s/%s/%s/%d/%s/%s", r.RoleARN, r.ExternalID, r.SessionName, r.DurationSeconds,
+			strings.Join(tags, ";"), strings.Join(transitive, ";"))
+	}
+
+	chain := make([]string, 0, len(role.SourceRoles)+1)
+	for _, r := range role.SourceRoles {
+		chain = append(chain, roleKey(r))
+	}
+	chain = append(chain, roleKey(role.AWSRoleSpec))
+	return strings.Join(chain, ",")
+}
+
+func credentialsFromSecret(secret *corev1.Secret) (*credentials.Credentials, error) {
+	accessKeyID := string(secret.Data[infrav1.AccessKeyIDKey])
+	secretAccessKey := string(secret.Data[infrav1.SecretAccessKeyKey])
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, errors.Errorf("identity secret %s/%s must contain %s and %s",
+			secret.Namespace, secret.Name, infrav1.AccessKeyIDKey, infrav1.SecretAccessKeyKey)
+	}
+
+	return credentials.NewStaticCredentials(accessKeyID, secretAccessKey, string(secret.Data[infrav1.SessionTokenKey])), nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSessionForCluster(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "team-a",
+			Namespace:       "default",
+			ResourceVersion: "1",
+		},
+		Data: map[string][]byte{
+			infrav1.AccessKeyIDKey:     []byte("AKIAOLD"),
+			infrav1.SecretAccessKeyKey: []byte("old"),
+		},
+	}
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add scheme: %v", err)
+	}
+	c := fake.NewFakeClientWithScheme(scheme, secret)
+
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-a", Namespace: "default"},
+		Spec: infrav1.AWSClusterSpec{
+			Region:      "us-east-1",
+			IdentityRef: &corev1.LocalObjectReference{Name: "team-a"},
+		},
+	}
+
+	first, err := sessionForCluster(c, awsCluster)
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	creds, err := first.Config.Credentials.Get()
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if creds.AccessKeyID != "AKIAOLD" {
+		t.Fatalf("expected credentials from the identity secret, got %q", creds.AccessKeyID)
+	}
+
+	cached, err := sessionForCluster(c, awsCluster)
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if cached != first {
+		t.Fatalf("expected the cached session to be reused")
+	}
+
+	secret.ResourceVersion = "2"
+	secret.Data[infrav1.AccessKeyIDKey] = []byte("AKIANEW")
+	if err := c.Update(context.TODO(), secret); err != nil {
+		t.Fatalf("Failed to update secret: %v", err)
+	}
+
+	refreshed, err := sessionForCluster(c, awsCluster)
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	creds, err = refreshed.Config.Credentials.Get()
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if creds.AccessKeyID != "AKIANEW" {
+		t.Fatalf("expected the session to be rebuilt after the secret changed, got %q", creds.AccessKeyID)
+	}
+
+	other := awsCluster.DeepCopy()
+	other.Spec.Region = "eu-west-1"
+	regional, err := sessionForCluster(c, other)
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if regional == refreshed || *regional.Config.Region != "eu-west-1" {
+		t.Fatalf("expected a separate session per region")
+	}
+
+	delete(secret.Data, infrav1.SecretAccessKeyKey)
+	secret.ResourceVersion = "3"
+	if err := c.Update(context.TODO(), secret); err != nil {
+		t.Fatalf("Failed to update secret: %v", err)
+	}
+	if _, err := sessionForCluster(c, awsCluster); err == nil {
+		t.Fatalf("expected an error for incomplete credentials but got none.")
+	}
+}
+
+func TestRoleIdentityKeySessionTags(t *testing.T) {
+	role := func(tags map[string]string, transitive ...string) *infrav1.AWSRoleIdentity {
+		return &infrav1.AWSRoleIdentity{
+			AWSRoleSpec: infrav1.AWSRoleSpec{
+				RoleARN:           "arn:aws:iam::123456789012:role/capa",
+				SessionName:       "capa",
+				SessionTags:       tags,
+				TransitiveTagKeys: transitive,
+			},
+		}
+	}
+
+	base := roleIdentityKey(role(map[string]string{"team": "a", "env": "dev"}, "team", "env"))
+
+	if key := roleIdentityKey(role(map[string]string{"env": "dev", "team": "a"}, "env", "team")); key != base {
+		t.Fatalf("expected the key not to depend on tag order, got %q and %q", base, key)
+	}
+	if key := roleIdentityKey(role(map[string]string{"team": "b", "env": "dev"}, "team", "env")); key == base {
+		t.Fatalf("expected a different key when a session tag changes")
+	}
+	if key := roleIdentityKey(role(map[string]string{"team": "a", "env": "dev"}, "team")); key == base {
+		t.Fatalf("expected a different key when the transitive tag keys change")
+	}
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaling
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+const (
+	// launchTemplateLatestVersion is the alias used to reference the latest version of a launch template.
+	launchTemplateLatestVersion = "$Latest"
+
+	// autoScalingGroupResourceType is the resource type used when tagging Auto Scaling Groups.
+	autoScalingGroupResourceType = "auto-scaling-group"
+)
+
+// GetASGByName returns the Auto Scaling Group for the machine pool, or nothing if it doesn't exist.
+func (s *Service) GetASGByName(scope *scope.MachinePoolScope) (*infrav1.AutoScalingGroup, error) {
+	return s.ASGIfExists(aws.String(scope.ASGName()))
+}
+
+// ASGIfExists returns the existing Auto Scaling Group or nothing if it doesn't exist.
+func (s *Service) ASGIfExists(name *string) (*infrav1.AutoScalingGroup, error) {
+	if name == nil {
+		s.scope.Info("Auto Scaling Group does not have a name")
+		return nil, nil
+	}
+
+	s.scope.V(2).Info("Looking for Auto Scaling Group by name", "name", *name)
+
+	input := &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{name},
+	}
+
+	out, err := s.scope.ASG.DescribeAutoScalingGroups(input)
+	switch {
+	case awserrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to describe Auto Scaling Group %q", *name)
+	}
+
+	for _, group := range out.AutoScalingGroups {
+		// Groups pending deletion are still returned by the API for a while.
+		if group.Status != nil {
+			continue
+		}
+		return sdkToAutoScalingGroup(group), nil
+	}
+
+	return nil, nil
+}
+
+// CreateASG creates the Auto Scaling Group for the machine pool using the given launch template.
+func (s *Service) CreateASG(scope *scope.MachinePoolScope, launchTemplateID string) (*infrav1.AutoScalingGroup, error) {
+	s.scope.V(2).Info("Creating Auto Scaling Group for machine pool", "name", scope.ASGName())
+
+	subnets, err := s.subnetIDs(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(scope.ASGName()),
+		MinSize:              aws.Int64(int64(scope.AWSMachinePool.Spec.MinSize)),
+		MaxSize:              aws.Int64(int64(scope.AWSMachinePool.Spec.MaxSize)),
+		DesiredCapacity:      aws.Int64(int64(scope.DesiredReplicas())),
+		VPCZoneIdentifier:    aws.String(strings.Join(subnets, ",")),
+		LaunchTemplate: &autoscaling.LaunchTemplateSpecification{
+			LaunchTemplateId: aws.String(launchTemplateID),
+			Version:          aws.String(launchTemplateLatestVersion),
+		},
+		Tags: s.buildTags(scope),
+	}
+
+	if _, err := s.scope.ASG.CreateAutoScalingGroup(input); err != nil {
+		record.Warnf(scope.AWSMachinePool, "FailedCreate", "Failed to create Auto Scaling Group: %v", err)
+		return nil, errors.Wrapf(err, "failed to create Auto Scaling Group %q", scope.ASGName())
+	}
+
+	record.Eventf(scope.AWSMachinePool, "SuccessfulCreate", "Created new Auto Scaling Group %q", scope.ASGName())
+
+	return s.GetASGByName(scope)
+}
+
+// UpdateASG updates the size, subnets, launch template and tags of the Auto Scaling Group
+// to match the machine pool spec.
+func (s *Service) UpdateASG(scope *scope.MachinePoolScope, launchTemplateID string) error {
+	s.scope.V(2).Info("Updating Auto Scaling Group for machine pool", "name", scope.ASGName())
+
+	subnets, err := s.subnetIDs(scope)
+	if err != nil {
+		return err
+	}
+
+	input := &autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(scope.ASGName()),
+		MinSize:              aws.Int64(int64(scope.AWSMachinePool.Spec.MinSize)),
+		MaxSize:              aws.Int64(int64(scope.AWSMachinePool.Spec.MaxSize)),
+		DesiredCapacity:      aws.Int64(int64(scope.DesiredReplicas())),
+		VPCZoneIdentifier:    aws.String(strings.Join(subnets, ",")),
+		LaunchTemplate: &autoscaling.LaunchTemplateSpecification{
+			LaunchTemplateId: aws.String(launchTemplateID),
+			Version:          aws.String(launchTemplateLatestVersion),
+		},
+	}
+
+	if _, err := s.scope.ASG.UpdateAutoScalingGroup(input); err != nil {
+		record.Warnf(scope.AWSMachinePool, "FailedUpdate", "Failed to update Auto Scaling Group: %v", err)
+		return errors.Wrapf(err, "failed to update Auto Scaling Group %q", scope.ASGName())
+	}
+
+	if _, err := s.scope.ASG.CreateOrUpdateTags(&autoscaling.CreateOrUpdateTagsInput{Tags: s.buildTags(scope)}); err != nil {
+		return errors.Wrapf(err, "failed to update tags of Auto Scaling Group %q", scope.ASGName())
+	}
+
+	record.Eventf(scope.AWSMachinePool, "SuccessfulUpdate", "Updated Auto Scaling Group %q", scope.ASGName())
+	return nil
+}
+
+// ASGNeedsUpdate returns true if the Auto Scaling Group differs from the machine pool spec.
+func (s *Service) ASGNeedsUpdate(scope *scope.MachinePoolScope, asg *infrav1.AutoScalingGroup, launchTemplateID string) (bool, error) {
+	if asg.MinSize != scope.AWSMachinePool.Spec.MinSize ||
+		asg.MaxSize != scope.AWSMachinePool.Spec.MaxSize ||
+		aws.Int32Value(asg.DesiredCapacity) != scope.DesiredReplicas() ||
+		asg.LaunchTemplateID != launchTemplateID ||
+		asg.LaunchTemplateVersion != launchTemplateLatestVersion {
+		return true, nil
+	}
+
+	subnets, err := s.subnetIDs(scope)
+	if err != nil {
+		return false, err
+	}
+
+	existing := append([]string{}, asg.Subnets...)
+	sort.Strings(subnets)
+	sort.Strings(existing)
+	if strings.Join(subnets, ",") != strings.Join(existing, ",") {
+		return true, nil
+	}
+
+	// Only tags we manage are compared, tags added by other actors are left alone.
+	return len(s.desiredTags(scope).Difference(asg.Tags)) > 0, nil
+}
+
+// DeleteASGAndWait deletes the Auto Scaling Group and its instances, and waits for the deletion to complete.
+func (s *Service) DeleteASGAndWait(name string) error {
+	if err := s.DeleteASG(name); err != nil {
+		return err
+	}
+
+	s.scope.V(2).Info("Waiting for Auto Scaling Group to be deleted", "name", name)
+
+	input := &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: aws.StringSlice([]string{name}),
+	}
+
+	if err := s.scope.ASG.WaitUntilGroupNotExists(input); err != nil {
+		return errors.Wrapf(err, "failed to wait for Auto Scaling Group %q deletion", name)
+	}
+
+	return nil
+}
+
+// DeleteASG deletes the Auto Scaling Group, forcing the termination of its instances.
+func (s *Service) DeleteASG(name string) error {
+	s.scope.V(2).Info("Attempting to delete Auto Scaling Group", "name", name)
+
+	input := &autoscaling.DeleteAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(name),
+		ForceDelete:          aws.Bool(true),
+	}
+
+	if _, err := s.scope.ASG.DeleteAutoScalingGroup(input); err != nil {
+		return errors.Wrapf(err, "failed to delete Auto Scaling Group %q", name)
+	}
+
+	s.scope.V(2).Info("Deleted Auto Scaling Group", "name", name)
+	return nil
+}
+
+// subnetIDs returns the subnets the Auto Scaling Group should launch instances in.
+// Subnets from the machine pool spec take precedence, otherwise the cluster private subnets
+// in the requested availability zones are used.
+func (s *Service) subnetIDs(scope *scope.MachinePoolScope) ([]string, error) {
+	ids := []string{}
+	for _, subnet := range scope.AWSMachinePool.Spec.Subnets {
+		if subnet.ID != nil {
+			ids = append(ids, *subnet.ID)
+		}
+	}
+	if len(ids) > 0 {
+		return ids, nil
+	}
+
+	private := s.scope.Subnets().FilterPrivate()
+	if len(scope.AWSMachinePool.Spec.AvailabilityZones) == 0 {
+		for _, sn := range private {
+			ids = append(ids, sn.ID)
+		}
+	} else {
+		for _, zone := range scope.AWSMachinePool.Spec.AvailabilityZones {
+			for _, sn := range private.FilterByZone(zone) {
+				ids = append(ids, sn.ID)
+			}
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, awserrors.NewFailedDependency(
+			errors.Errorf("failed to create Auto Scaling Group for machine pool %q, no subnets available", scope.Name()),
+		)
+	}
+
+	return ids, nil
+}
+
+func (s *Service) desiredTags(scope *scope.MachinePoolScope) infrav1.Tags {
+	// Make sure to use the MachinePoolScope here to get the merger of AWSCluster and AWSMachinePool tags
+	additionalTags := scope.AdditionalTags()
+	// Set the cloud provider tag
+	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())] = string(infrav1.ResourceLifecycleOwned)
+
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(scope.Name()),
+		Role:        aws.String(scope.Role()),
+		Additional:  additionalTags,
+	})
+}
+
+func (s *Service) buildTags(scope *scope.MachinePoolScope) []*autoscaling.Tag {
+	tags := []*autoscaling.Tag{}
+	for key, value := range s.desiredTags(scope) {
+		tags = append(tags, &autoscaling.Tag{
+			Key:               aws.String(key),
+			Value:             aws.String(value),
+			PropagateAtLaunch: aws.Bool(true),
+			ResourceId:        aws.String(scope.ASGName()),
+			ResourceType:      aws.String(autoScalingGroupResourceType),
+		})
+	}
+	return tags
+}
+
+func sdkToAutoScalingGroup(v *autoscaling.Group) *infrav1.AutoScalingGroup {
+	asg := &infrav1.AutoScalingGroup{
+		Name:    aws.StringValue(v.AutoScalingGroupName),
+		MinSize: int32(aws.Int64Value(v.MinSize)),
+		MaxSize: int32(aws.Int64Value(v.MaxSize)),
+		Tags:    infrav1.Tags{},
+	}
+
+	if v.DesiredCapacity != nil {
+		asg.DesiredCapacity = aws.Int32(int32(*v.DesiredCapacity))
+	}
+
+	if subnets := aws.StringValue(v.VPCZoneIdentifier); subnets != "" {
+		asg.Subnets = strings.Split(subnets, ",")
+	}
+
+	if v.LaunchTemplate != nil {
+		asg.LaunchTemplateID = aws.StringValue(v.LaunchTemplate.LaunchTemplateId)
+		asg.LaunchTemplateVersion = aws.StringValue(v.LaunchTemplate.Version)
+	}
+
+	for _, tag := range v.Tags {
+		asg.Tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	for _, i := range v.Instances {
+		instance := infrav1.AutoScalingGroupInstance{
+			ID:             aws.StringValue(i.InstanceId),
+			LifecycleState: aws.StringValue(i.LifecycleState),
+			HealthStatus:   aws.StringValue(i.HealthStatus),
+		}
+		if i.LaunchTemplate != nil {
+			instance.LaunchTemplateVersion = aws.StringValue(i.LaunchTemplate.Version)
+		}
+		asg.Instances = append(asg.Instances, instance)
+	}
+
+	return asg
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaling
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/autoscaling/mock_autoscalingiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newClusterScope(t *testing.T, asgMock *mock_autoscalingiface.MockAutoScalingAPI) *scope.ClusterScope {
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:  fake.NewFakeClientWithScheme(newScheme(t)),
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+		AWSClients: scope.AWSClients{
+			ASG: asgMock,
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						ID: "vpc-1",
+					},
+					Subnets: infrav1.Subnets{
+						{ID: "subnet-private-a", AvailabilityZone: "us-east-1a"},
+						{ID: "subnet-private-b", AvailabilityZone: "us-east-1b"},
+						{ID: "subnet-public-a", AvailabilityZone: "us-east-1a", IsPublic: true},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	return cs
+}
+
+func newMachinePoolScope(t *testing.T, cs *scope.ClusterScope, spec infrav1.AWSMachinePoolSpec) *scope.MachinePoolScope {
+	mps, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+		Client:     fake.NewFakeClientWithScheme(newScheme(t)),
+		Cluster:    cs.Cluster,
+		AWSCluster: cs.AWSCluster,
+		AWSMachinePool: &infrav1.AWSMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool-1"},
+			Spec:       spec,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create machine pool scope: %v", err)
+	}
+	return mps
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := infrav1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add scheme: %v", err)
+	}
+	return scheme
+}
+
+func TestASGIfExists(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name   string
+		asg    string
+		expect func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		check  func(asg *infrav1.AutoScalingGroup, err error)
+	}{
+		{
+			name: "does not exist",
+			asg:  "asg-missing",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeAutoScalingGroups(gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
+					AutoScalingGroupNames: []*string{aws.String("asg-missing")},
+				})).
+					Return(&autoscaling.DescribeAutoScalingGroupsOutput{}, nil)
+			},
+			check: func(asg *infrav1.AutoScalingGroup, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if asg != nil {
+					t.Fatalf("Did not expect anything but got something: %+v", asg)
+				}
+			},
+		},
+		{
+			name: "being deleted",
+			asg:  "asg-deleting",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeAutoScalingGroups(gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
+					AutoScalingGroupNames: []*string{aws.String("asg-deleting")},
+				})).
+					Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+						AutoScalingGroups: []*autoscaling.Group{
+							{
+								AutoScalingGroupName: aws.String("asg-deleting"),
+								Status:               aws.String("Delete in progress"),
+							},
+						},
+					}, nil)
+			},
+			check: func(asg *infrav1.AutoScalingGroup, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if asg != nil {
+					t.Fatalf("Did not expect anything but got something: %+v", asg)
+				}
+			},
+		},
+		{
+			name: "exists",
+			asg:  "asg-1",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeAutoScalingGroups(gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
+					AutoScalingGroupNames: []*string{aws.String("asg-1")},
+				})).
+					Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+						AutoScalingGroups: []*autoscaling.Group{
+							{
+								AutoScalingGroupName: aws.String("asg-1"),
+								MinSize:              aws.Int64(1),
+								MaxSize:              aws.Int64(3),
+								DesiredCapacity:      aws.Int64(2),
+								VPCZoneIdentifier:    aws.String("subnet-1,subnet-2"),
+								LaunchTemplate: &autoscaling.LaunchTemplateSpecification{
+									LaunchTemplateId: aws.String("lt-1"),
+									Version:          aws.String("$Latest"),
+								},
+								Instances: []*autoscaling.Instance{
+									{
+										InstanceId:     aws.String("i-1"),
+										LifecycleState: aws.String("InService"),
+										HealthStatus:   aws.String("Healthy"),
+									},
+									{
+										InstanceId:     aws.String("i-2"),
+										LifecycleState: aws.String("Pending"),
+										HealthStatus:   aws.String("Healthy"),
+									},
+								},
+							},
+						},
+					}, nil)
+			},
+			check: func(asg *infrav1.AutoScalingGroup, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if asg == nil {
+					t.Fatalf("expected Auto Scaling Group but got nothing")
+				}
+
+				if asg.Name != "asg-1" || asg.LaunchTemplateID != "lt-1" || aws.Int32Value(asg.DesiredCapacity) != 2 {
+					t.Fatalf("unexpected Auto Scaling Group: %+v", asg)
+				}
+
+				if len(asg.Subnets) != 2 || len(asg.Instances) != 2 {
+					t.Fatalf("expected 2 subnets and 2 instances, got: %+v", asg)
+				}
+			},
+		},
+		{
+			name: "error describing groups",
+			asg:  "asg-error",
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.DescribeAutoScalingGroups(gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
+					AutoScalingGroupNames: []*string{aws.String("asg-error")},
+				})).
+					Return(nil, errors.New("some unknown error"))
+			},
+			check: func(asg *infrav1.AutoScalingGroup, err error) {
+				if err == nil {
+					t.Fatalf("expected an error but got none.")
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			cs := newClusterScope(t, asgMock)
+
+			tc.expect(asgMock.EXPECT())
+
+			s := NewService(cs)
+			asg, err := s.ASGIfExists(aws.String(tc.asg))
+			tc.check(asg, err)
+		})
+	}
+}
+
+func TestCreateASG(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name   string
+		spec   infrav1.AWSMachinePoolSpec
+		expect func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		check  func(asg *infrav1.AutoScalingGroup, err error)
+	}{
+		{
+			name: "uses private subnets in the requested zones",
+			spec: infrav1.AWSMachinePoolSpec{
+				MinSize:           1,
+				MaxSize:           5,
+				AvailabilityZones: []string{"us-east-1b"},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CreateAutoScalingGroup(gomock.AssignableToTypeOf(&autoscaling.CreateAutoScalingGroupInput{})).
+					Do(func(input *autoscaling.CreateAutoScalingGroupInput) {
+						if aws.StringValue(input.AutoScalingGroupName) != "test-cluster-pool-1" {
+							t.Fatalf("expected the group to be named after the cluster and pool, got %q", aws.StringValue(input.AutoScalingGroupName))
+						}
+						if aws.StringValue(input.VPCZoneIdentifier) != "subnet-private-b" {
+							t.Fatalf("expected subnet-private-b, got %q", aws.StringValue(input.VPCZoneIdentifier))
+						}
+						if aws.Int64Value(input.DesiredCapacity) != 1 {
+							t.Fatalf("expected desired capacity to default to min size, got %d", aws.Int64Value(input.DesiredCapacity))
+						}
+						if aws.StringValue(input.LaunchTemplate.LaunchTemplateId) != "lt-1" ||
+							aws.StringValue(input.LaunchTemplate.Version) != "$Latest" {
+							t.Fatalf("unexpected launch template: %v", input.LaunchTemplate)
+						}
+					}).
+					Return(&autoscaling.CreateAutoScalingGroupOutput{}, nil)
+				m.DescribeAutoScalingGroups(gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
+					AutoScalingGroupNames: []*string{aws.String("test-cluster-pool-1")},
+				})).
+					Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+						AutoScalingGroups: []*autoscaling.Group{
+							{
+								AutoScalingGroupName: aws.String("test-cluster-pool-1"),
+								MinSize:              aws.Int64(1),
+								MaxSize:              aws.Int64(5),
+								DesiredCapacity:      aws.Int64(1),
+							},
+						},
+					}, nil)
+			},
+			check: func(asg *infrav1.AutoScalingGroup, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if asg == nil || asg.Name != "test-cluster-pool-1" {
+					t.Fatalf("unexpected Auto Scaling Group: %+v", asg)
+				}
+			},
+		},
+		{
+			name: "no subnets available",
+			spec: infrav1.AWSMachinePoolSpec{
+				MinSize:           1,
+				MaxSize:           5,
+				AvailabilityZones: []string{"us-east-1c"},
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {},
+			check: func(asg *infrav1.AutoScalingGroup, err error) {
+				if err == nil {
+					t.Fatalf("expected an error but got none.")
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+			cs := newClusterScope(t, asgMock)
+			mps := newMachinePoolScope(t, cs, tc.spec)
+
+			tc.expect(asgMock.EXPECT())
+
+			s := NewService(cs)
+			asg, err := s.CreateASG(mps, "lt-1")
+			tc.check(asg, err)
+		})
+	}
+}
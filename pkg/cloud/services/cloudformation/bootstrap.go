@@ -149,57 +149,112 @@ func controllersPolicy(accountID, partition string) *iam.PolicyDocument {
 				Action: iam.Actions{
 					"ec2:AllocateAddress",
 					"ec2:AssociateRouteTable",
+					"ec2:AssociateVpcCidrBlock",
 					"ec2:AttachInternetGateway",
 					"ec2:AuthorizeSecurityGroupIngress",
+					"ec2:CancelSpotInstanceRequests",
+					"ec2:CreateEgressOnlyInternetGateway",
 					"ec2:CreateInternetGateway",
+					"ec2:CreateLaunchTemplate",
+					"ec2:CreateLaunchTemplateVersion",
 					"ec2:CreateNatGateway",
 					"ec2:CreateRoute",
 					"ec2:CreateRouteTable",
 					"ec2:CreateSecurityGroup",
 					"ec2:CreateSubnet",
 					"ec2:CreateTags",
+					"ec2:CreateTransitGatewayVpcAttachment",
 					"ec2:CreateVpc",
+					"ec2:CreateVpcEndpoint",
 					"ec2:ModifyVpcAttribute",
+					"ec2:DeleteEgressOnlyInternetGateway",
 					"ec2:DeleteInternetGateway",
+					"ec2:DeleteLaunchTemplate",
 					"ec2:DeleteNatGateway",
+					"ec2:DeleteRoute",
 					"ec2:DeleteRouteTable",
 					"ec2:DeleteSecurityGroup",
 					"ec2:DeleteSubnet",
 					"ec2:DeleteTags",
+					"ec2:DeleteTransitGatewayVpcAttachment",
 					"ec2:DeleteVpc",
+					"ec2:DeleteVpcEndpoints",
 					"ec2:DescribeAccountAttributes",
 					"ec2:DescribeAddresses",
 					"ec2:DescribeAvailabilityZones",
+					"ec2:DescribeEgressOnlyInternetGateways",
 					"ec2:DescribeInstances",
 					"ec2:DescribeInternetGateways",
 					"ec2:DescribeImages",
+					"ec2:DescribeLaunchTemplates",
+					"ec2:DescribeLaunchTemplateVersions",
 					"ec2:DescribeNatGateways",
 					"ec2:DescribeNetworkInterfaces",
 					"ec2:DescribeNetworkInterfaceAttribute",
 					"ec2:DescribeRouteTables",
 					"ec2:DescribeSecurityGroups",
 					"ec2:DescribeSubnets",
+					"ec2:DescribeTransitGatewayVpcAttachments",
 					"ec2:DescribeVpcs",
+					"ec2:DescribeVpcEndpoints",
 					"ec2:DescribeVpcAttribute",
 					"ec2:DescribeVolumes",
 					"ec2:DetachInternetGateway",
 					"ec2:DisassociateRouteTable",
+					"ec2:DisassociateVpcCidrBlock",
 					"ec2:DisassociateAddress",
 					"ec2:ModifyInstanceAttribute",
 					"ec2:ModifyNetworkInterfaceAttribute",
 					"ec2:ModifySubnetAttribute",
+					"ec2:ModifyVpcEndpoint",
 					"ec2:ReleaseAddress",
 					"ec2:RevokeSecurityGroupIngress",
 					"ec2:RunInstances",
 					"ec2:TerminateInstances",
 					"tag:GetResources",
+					"elasticloadbalancing:AddTags",
+					"elasticloadbalancing:ApplySecurityGroupsToLoadBalancer",
+					"elasticloadbalancing:AttachLoadBalancerToSubnets",
+					"elasticloadbalancing:CreateListener",
 					"elasticloadbalancing:CreateLoadBalancer",
+					"elasticloadbalancing:CreateLoadBalancerListeners",
+					"elasticloadbalancing:CreateTargetGroup",
 					"elasticloadbalancing:ConfigureHealthCheck",
 					"elasticloadbalancing:DeleteLoadBalancer",
+					"elasticloadbalancing:DeleteLoadBalancerListeners",
+					"elasticloadbalancing:DeleteTargetGroup",
+					"elasticloadbalancing:DescribeListeners",
 					"elasticloadbalancing:DescribeLoadBalancers",
 					"elasticloadbalancing:DescribeLoadBalancerAttributes",
+					"elasticloadbalancing:DescribeTags",
+					"elasticloadbalancing:DescribeTargetGroups",
+					"elasticloadbalancing:DetachLoadBalancerFromSubnets",
+					"elasticloadbalancing:ModifyListener",
 					"elasticloadbalancing:ModifyLoadBalancerAttributes",
+					"elasticloadbalancing:ModifyTargetGroup",
 					"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
+					"elasticloadbalancing:RegisterTargets",
+					"elasticloadbalancing:RemoveTags",
+					"elasticloadbalancing:SetSubnets",
+					"autoscaling:CreateAutoScalingGroup",
+					"autoscaling:CreateOrUpdateTags",
+					"autoscaling:DeleteAutoScalingGroup",
+					"autoscaling:DescribeAutoScalingGroups",
+					"autoscaling:UpdateAutoScalingGroup",
+				},
+			},
+			{
+				Effect: iam.EffectAllow,
+				Resource: iam.Resources{fmt.Sprintf(
+					"arn:%s:iam::%s:role/aws-service-role/autoscaling.amazonaws.com/AWSServiceRoleForAutoScaling",
+					partition,
+					accountID,
+				)},
+				Action: iam.Actions{
+					"iam:CreateServiceLinkedRole",
+				},
+				Condition: iam.Conditions{
+					"StringLike": map[string]string{"iam:AWSServiceName": "autoscaling.amazonaws.com"},
 				},
 			},
 			{
@@ -216,6 +271,20 @@ func controllersPolicy(accountID, partition string) *iam.PolicyDocument {
 					"StringLike": map[string]string{"iam:AWSServiceName": "elasticloadbalancing.amazonaws.com"},
 				},
 			},
+			{
+				Effect: iam.EffectAllow,
+				Resource: iam.Resources{fmt.Sprintf(
+					"arn:%s:iam::%s:role/aws-service-role/spot.amazonaws.com/AWSServiceRoleForEC2Spot",
+					partition,
+					accountID,
+				)},
+				Action: iam.Actions{
+					"iam:CreateServiceLinkedRole",
+				},
+				Condition: iam.Conditions{
+					"StringLike": map[string]string{"iam:AWSServiceName": "spot.amazonaws.com"},
+				},
+			},
 			{
 				Effect: iam.EffectAllow,
 				Resource: iam.Resources{fmt.Sprintf(
@@ -228,6 +297,16 @@ func controllersPolicy(accountID, partition string) *iam.PolicyDocument {
 					"iam:PassRole",
 				},
 			},
+			{
+				// Role identities can live in any account, the roles the controllers
+				// are allowed to assume are restricted by their own trust policies.
+				Effect:   iam.EffectAllow,
+				Resource: iam.Resources{"*"},
+				Action: iam.Actions{
+					"sts:AssumeRole",
+					"sts:TagSession",
+				},
+			},
 		},
 	}
 }
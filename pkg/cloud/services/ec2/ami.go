@@ -43,6 +43,19 @@ const (
 
 	// Amazon's AMI timestamp format
 	createDateTimestampFormat = "2006-01-02T15:04:05.000Z"
+
+	// defaultBastionAMIOwnerID is the Canonical owned account publishing the
+	// official Ubuntu images. Canonical uses different accounts in the GovCloud
+	// and China partitions.
+	defaultBastionAMIOwnerID         = "099720109477"
+	defaultBastionAMIOwnerIDGovCloud = "513442679011"
+	defaultBastionAMIOwnerIDChina    = "837727238323"
+
+	// defaultBastionAMINamePattern matches the official Ubuntu 18.04 images.
+	defaultBastionAMINamePattern = "ubuntu/images/hvm-ssd/ubuntu-bionic-18.04-amd64-server-*"
+
+	// defaultAMIArchitecture is the architecture of the images looked up by default.
+	defaultAMIArchitecture = "x86_64"
 )
 
 func amiName(baseOS, baseOSVersion, kubernetesVersion string) string {
@@ -54,6 +67,39 @@ func (s *Service) defaultAMILookup(ownerID, baseOS, baseOSVersion, kubernetesVer
 	if ownerID == "" {
 		ownerID = defaultMachineAMIOwnerID
 	}
+	return s.latestAMILookup(ownerID, amiName(baseOS, baseOSVersion, kubernetesVersion), defaultAMIArchitecture)
+}
+
+// defaultBastionAMILookup returns the latest image matching the bastion lookup
+// configuration, or the latest official Ubuntu image of the region partition.
+func (s *Service) defaultBastionAMILookup() (string, error) {
+	ownerID := s.scope.Bastion().ImageLookupOrg
+	if ownerID == "" {
+		ownerID = defaultBastionAMIOwnerIDForRegion(s.scope.Region())
+	}
+
+	name := s.scope.Bastion().ImageLookupName
+	if name == "" {
+		name = defaultBastionAMINamePattern
+	}
+
+	return s.latestAMILookup(ownerID, name, defaultAMIArchitecture)
+}
+
+func defaultBastionAMIOwnerIDForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "us-gov-"):
+		return defaultBastionAMIOwnerIDGovCloud
+	case strings.HasPrefix(region, "cn-"):
+		return defaultBastionAMIOwnerIDChina
+	default:
+		return defaultBastionAMIOwnerID
+	}
+}
+
+// latestAMILookup returns the ID of the most recent available image owned by
+// ownerID whose name matches the given pattern.
+func (s *Service) latestAMILookup(ownerID, name, architecture string) (string, error) {
 	describeImageInput := &ec2.DescribeImagesInput{
 		Filters: []*ec2.Filter{
 			{
@@ -62,11 +108,11 @@ func (s *Service) defaultAMILookup(ownerID, baseOS, baseOSVersion, kubernetesVer
 			},
 			{
 				Name:   aws.String("name"),
-				Values: []*string{aws.String(amiName(baseOS, baseOSVersion, kubernetesVersion))},
+				Values: []*string{aws.String(name)},
 			},
 			{
 				Name:   aws.String("architecture"),
-				Values: []*string{aws.String("x86_64")},
+				Values: []*string{aws.String(architecture)},
 			},
 			{
 				Name:   aws.String("state"),
@@ -81,10 +127,10 @@ func (s *Service) defaultAMILookup(ownerID, baseOS, baseOSVersion, kubernetesVer
 
 	out, err := s.scope.EC2.DescribeImages(describeImageInput)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to find ami: %q", amiName(baseOS, baseOSVersion, kubernetesVersion))
+		return "", errors.Wrapf(err, "failed to find ami: %q", name)
 	}
 	if len(out.Images) == 0 {
-		return "", errors.Errorf("found no AMIs with the name: %q", amiName(baseOS, baseOSVersion, kubernetesVersion))
+		return "", errors.Errorf("found no AMIs with the name: %q", name)
 	}
 	latestImage, err := getLatestImage(out.Images)
 	if err != nil {
@@ -127,42 +173,3 @@ func getLatestImage(imgs []*ec2.Image) (*ec2.Image, error) {
 	sort.Sort(images(imgs))
 	return imgs[len(imgs)-1], nil
 }
-
-func (s *Service) defaultBastionAMILookup(region string) string {
-	switch region {
-	case "ap-northeast-1":
-		return "ami-d39a02b5"
-	case "ap-northeast-2":
-		return "ami-67973709"
-	case "ap-south-1":
-		return "ami-5d055232"
-	case "ap-southeast-1":
-		return "ami-325d2e4e"
-	case "ap-southeast-2":
-		return "ami-37df2255"
-	case "ca-central-1":
-		return "ami-f0870294"
-	case "eu-central-1":
-		return "ami-af79ebc0"
-	case "eu-west-1":
-		return "ami-4d46d534"
-	case "eu-west-2":
-		return "ami-d7aab2b3"
-	case "eu-west-3":
-		return "ami-5e0eb923"
-	case "sa-east-1":
-		return "ami-1157157d"
-	case "us-east-1":
-		return "ami-41e0b93b"
-	case "us-east-2":
-		return "ami-2581aa40"
-	case "us-west-1":
-		return "ami-79aeae19"
-	case "us-west-2":
-		return "ami-1ee65166"
-	case "eu-north-1":
-		return "ami-7dd85203"
-	default:
-		return "unknown region"
-	}
-}
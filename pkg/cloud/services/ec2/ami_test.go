@@ -147,3 +147,91 @@ func TestAMIsWithInvalidCreationDate(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultBastionAMILookup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name      string
+		region    string
+		bastion   infrav1.Bastion
+		wantOwner string
+		wantName  string
+	}{
+		{
+			name:      "canonical image",
+			region:    "us-east-1",
+			wantOwner: "099720109477",
+			wantName:  "ubuntu/images/hvm-ssd/ubuntu-bionic-18.04-amd64-server-*",
+		},
+		{
+			name:      "canonical image in govcloud",
+			region:    "us-gov-west-1",
+			wantOwner: "513442679011",
+			wantName:  "ubuntu/images/hvm-ssd/ubuntu-bionic-18.04-amd64-server-*",
+		},
+		{
+			name:      "canonical image in china",
+			region:    "cn-north-1",
+			wantOwner: "837727238323",
+			wantName:  "ubuntu/images/hvm-ssd/ubuntu-bionic-18.04-amd64-server-*",
+		},
+		{
+			name:      "custom lookup",
+			region:    "us-east-1",
+			bastion:   infrav1.Bastion{ImageLookupOrg: "123456789012", ImageLookupName: "bastion-*"},
+			wantOwner: "123456789012",
+			wantName:  "bastion-*",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{Region: tc.region, Bastion: tc.bastion},
+				},
+				AWSClients: scope.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+
+			ec2Mock.EXPECT().DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{
+				Filters: []*ec2.Filter{
+					{Name: aws.String("owner-id"), Values: []*string{aws.String(tc.wantOwner)}},
+					{Name: aws.String("name"), Values: []*string{aws.String(tc.wantName)}},
+					{Name: aws.String("architecture"), Values: []*string{aws.String("x86_64")}},
+					{Name: aws.String("state"), Values: []*string{aws.String("available")}},
+					{Name: aws.String("virtualization-type"), Values: []*string{aws.String("hvm")}},
+				},
+			})).Return(&ec2.DescribeImagesOutput{
+				Images: []*ec2.Image{
+					{
+						ImageId:      aws.String("ami-old"),
+						CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+					},
+					{
+						ImageId:      aws.String("ami-new"),
+						CreationDate: aws.String("2020-02-08T17:02:31.000Z"),
+					},
+				},
+			}, nil)
+
+			s := NewService(scope)
+			id, err := s.defaultBastionAMILookup()
+			if err != nil {
+				t.Fatalf("did not expect error calling a mock: %v", err)
+			}
+			if id != "ami-new" {
+				t.Fatalf("returned %q expected 'ami-new'", id)
+			}
+		})
+	}
+}
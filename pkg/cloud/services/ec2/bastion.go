@@ -28,11 +28,13 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/userdata"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/conditions"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
 )
 
 const (
-	defaultSSHKeyName = "default"
+	defaultSSHKeyName          = "default"
+	defaultBastionInstanceType = "t2.micro"
 )
 
 // ReconcileBastion ensures a bastion is created for the cluster
@@ -42,6 +44,16 @@ func (s *Service) ReconcileBastion() error {
 		return nil
 	}
 
+	if !s.scope.Bastion().IsEnabled() || s.scope.IsIsolated() {
+		s.scope.V(4).Info("Bastion host is disabled or the network is isolated, making sure it does not exist")
+		if err := s.DeleteBastion(); err != nil {
+			return err
+		}
+		s.scope.AWSCluster.Status.Bastion = infrav1.Instance{}
+		conditions.Delete(s.scope.AWSCluster, infrav1.BastionHostReadyCondition)
+		return nil
+	}
+
 	s.scope.V(2).Info("Reconciling bastion host")
 
 	subnets := s.scope.Subnets()
@@ -49,6 +61,7 @@ func (s *Service) ReconcileBastion() error {
 		s.scope.V(2).Info("No private subnets available, skipping bastion host")
 		return nil
 	} else if len(subnets.FilterPublic()) == 0 {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.BastionHostReadyCondition, infrav1.BastionHostFailedReason, infrav1.ConditionSeverityError, "no public subnets are available")
 		return errors.New("failed to reconcile bastion host, no public subnets are available")
 	}
 
@@ -56,10 +69,40 @@ func (s *Service) ReconcileBastion() error {
 
 	// Describe bastion instance, if any.
 	instance, err := s.describeBastionInstance()
+	if err == nil && s.bastionNeedsReplacement(instance, spec) {
+		s.scope.Info("Replacing bastion host to match its spec", "instance-id", instance.ID)
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.BastionHostReadyCondition, infrav1.BastionCreationStartedReason, infrav1.ConditionSeverityInfo, "replacing bastion host %q", instance.ID)
+
+		if err := s.TerminateInstanceAndWait(instance.ID); err != nil {
+			record.Warnf(s.scope.AWSCluster, "FailedTerminateBastion", "Failed to terminate bastion instance %q: %v", instance.ID, err)
+			conditions.MarkFalse(s.scope.AWSCluster, infrav1.BastionHostReadyCondition, infrav1.BastionHostFailedReason, infrav1.ConditionSeverityError, "%v", err)
+			return errors.Wrap(err, "unable to replace bastion instance")
+		}
+		record.Eventf(s.scope.AWSCluster, "SuccessfulTerminateBastion", "Terminated bastion instance %q to replace it", instance.ID)
+
+		instance, err = nil, awserrors.NewNotFound(errors.New("bastion host replaced"))
+	}
+
 	if awserrors.IsNotFound(err) {
+		if !conditions.Has(s.scope.AWSCluster, infrav1.BastionHostReadyCondition) {
+			conditions.MarkFalse(s.scope.AWSCluster, infrav1.BastionHostReadyCondition, infrav1.BastionCreationStartedReason, infrav1.ConditionSeverityInfo, "")
+		}
+
+		// The image is only looked up when creating the bastion host, the
+		// resolved AMI is then recorded in the bastion status.
+		if spec.ImageID == "" {
+			spec.ImageID, err = s.defaultBastionAMILookup()
+			if err != nil {
+				record.Warnf(s.scope.AWSCluster, "FailedCreateBastion", "Failed to look up bastion image: %v", err)
+				conditions.MarkFalse(s.scope.AWSCluster, infrav1.BastionHostReadyCondition, infrav1.BastionHostFailedReason, infrav1.ConditionSeverityError, "%v", err)
+				return err
+			}
+		}
+
 		instance, err = s.runInstance("bastion", spec)
 		if err != nil {
 			record.Warnf(s.scope.AWSCluster, "FailedCreateBastion", "Failed to create bastion instance: %v", err)
+			conditions.MarkFalse(s.scope.AWSCluster, infrav1.BastionHostReadyCondition, infrav1.BastionHostFailedReason, infrav1.ConditionSeverityError, "%v", err)
 			return err
 		}
 
@@ -67,12 +110,12 @@ func (s *Service) ReconcileBastion() error {
 		s.scope.V(2).Info("Created new bastion host", "instance", instance)
 
 	} else if err != nil {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.BastionHostReadyCondition, infrav1.BastionHostFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
 		return err
 	}
 
-	// TODO(vincepri): check for possible changes between the default spec and the instance.
-
 	instance.DeepCopyInto(&s.scope.AWSCluster.Status.Bastion)
+	conditions.MarkTrue(s.scope.AWSCluster, infrav1.BastionHostReadyCondition)
 	s.scope.V(2).Info("Reconcile bastion completed successfully")
 	return nil
 }
@@ -129,6 +172,21 @@ func (s *Service) describeBastionInstance() (*infrav1.Instance, error) {
 	return nil, awserrors.NewNotFound(errors.New("bastion host not found"))
 }
 
+// bastionNeedsReplacement returns true if the bastion instance diverged from
+// the desired spec. The image is only compared when the AMI is set explicitly,
+// so that the bastion host is not replaced when the default image changes.
+func (s *Service) bastionNeedsReplacement(instance, spec *infrav1.Instance) bool {
+	if instance.Type != spec.Type {
+		return true
+	}
+
+	if aws.StringValue(instance.SSHKeyName) != aws.StringValue(spec.SSHKeyName) {
+		return true
+	}
+
+	return s.scope.Bastion().AMI != "" && instance.ImageID != spec.ImageID
+}
+
 func (s *Service) getDefaultBastion() *infrav1.Instance {
 	name := fmt.Sprintf("%s-bastion", s.scope.Name())
 	userData, _ := userdata.NewBastion(&userdata.BastionInput{})
@@ -138,10 +196,15 @@ func (s *Service) getDefaultBastion() *infrav1.Instance {
 		keyName = s.scope.AWSCluster.Spec.SSHKeyName
 	}
 
+	instanceType := defaultBastionInstanceType
+	if s.scope.Bastion().InstanceType != "" {
+		instanceType = s.scope.Bastion().InstanceType
+	}
+
 	i := &infrav1.Instance{
-		Type:       "t2.micro",
+		Type:       instanceType,
 		SubnetID:   s.scope.Subnets().FilterPublic()[0].ID,
-		ImageID:    s.defaultBastionAMILookup(s.scope.AWSCluster.Spec.Region),
+		ImageID:    s.scope.Bastion().AMI,
 		SSHKeyName: aws.String(keyName),
 		UserData:   aws.String(base64.StdEncoding.EncodeToString([]byte(userData))),
 		SecurityGroupIDs: []string{
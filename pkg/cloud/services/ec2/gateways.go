@@ -37,6 +37,11 @@ func (s *Service) reconcileInternetGateways() error {
 		return nil
 	}
 
+	if s.scope.IsIsolated() {
+		s.scope.V(4).Info("Skipping internet gateways reconcile in isolated mode")
+		return nil
+	}
+
 	s.scope.V(2).Info("Reconciling internet gateways")
 
 	igs, err := s.describeVpcInternetGateways()
@@ -191,3 +196,134 @@ func (s *Service) getGatewayTagParams(id string) infrav1.BuildParams {
 		Additional:  s.scope.AdditionalTags(),
 	}
 }
+
+func (s *Service) reconcileEgressOnlyInternetGateways() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) || !s.scope.VPC().IsIPv6Enabled() {
+		s.scope.V(4).Info("Skipping egress-only internet gateways reconcile in unmanaged mode or without IPv6")
+		return nil
+	}
+
+	if s.scope.IsIsolated() {
+		s.scope.V(4).Info("Skipping egress-only internet gateways reconcile in isolated mode")
+		return nil
+	}
+
+	s.scope.V(2).Info("Reconciling egress-only internet gateways")
+
+	eigws, err := s.describeVpcEgressOnlyInternetGateways()
+	if err != nil {
+		return err
+	}
+
+	if len(eigws) == 0 {
+		eigw, err := s.createEgressOnlyInternetGateway()
+		if err != nil {
+			return err
+		}
+		eigws = []*ec2.EgressOnlyInternetGateway{eigw}
+	}
+
+	gateway := eigws[0]
+	s.scope.VPC().IPv6.EgressOnlyInternetGatewayID = gateway.EgressOnlyInternetGatewayId
+
+	// Make sure tags are up to date.
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if err := tags.Ensure(converters.TagsToMap(gateway.Tags), &tags.ApplyParams{
+			EC2Client:   s.scope.EC2,
+			BuildParams: s.getEgressOnlyGatewayTagParams(*gateway.EgressOnlyInternetGatewayId),
+		}); err != nil {
+			return false, err
+		}
+		return true, nil
+	}, awserrors.GatewayNotFound); err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedTagEgressOnlyInternetGateway", "Failed to tag managed Egress Only Internet Gateway %q: %v", *gateway.EgressOnlyInternetGatewayId, err)
+		return errors.Wrapf(err, "failed to tag egress-only internet gateway %q", *gateway.EgressOnlyInternetGatewayId)
+	}
+
+	return nil
+}
+
+func (s *Service) deleteEgressOnlyInternetGateways() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) || !s.scope.VPC().IsIPv6Enabled() {
+		s.scope.V(4).Info("Skipping egress-only internet gateway deletion in unmanaged mode or without IPv6")
+		return nil
+	}
+
+	eigws, err := s.describeVpcEgressOnlyInternetGateways()
+	if err != nil {
+		return err
+	}
+
+	for _, eigw := range eigws {
+		deleteReq := &ec2.DeleteEgressOnlyInternetGatewayInput{
+			EgressOnlyInternetGatewayId: eigw.EgressOnlyInternetGatewayId,
+		}
+
+		if _, err := s.scope.EC2.DeleteEgressOnlyInternetGateway(deleteReq); err != nil {
+			record.Warnf(s.scope.AWSCluster, "FailedDeleteEgressOnlyInternetGateway", "Failed to delete Egress Only Internet Gateway %q previously attached to VPC %q: %v", *eigw.EgressOnlyInternetGatewayId, s.scope.VPC().ID, err)
+			return errors.Wrapf(err, "failed to delete egress-only internet gateway %q", *eigw.EgressOnlyInternetGatewayId)
+		}
+
+		record.Eventf(s.scope.AWSCluster, "SuccessfulDeleteEgressOnlyInternetGateway", "Deleted Egress Only Internet Gateway %q previously attached to VPC %q", *eigw.EgressOnlyInternetGatewayId, s.scope.VPC().ID)
+		s.scope.Info("Deleted egress-only internet gateway in VPC", "egress-only-internet-gateway-id", *eigw.EgressOnlyInternetGatewayId, "vpc-id", s.scope.VPC().ID)
+	}
+
+	return nil
+}
+
+func (s *Service) createEgressOnlyInternetGateway() (*ec2.EgressOnlyInternetGateway, error) {
+	out, err := s.scope.EC2.CreateEgressOnlyInternetGateway(&ec2.CreateEgressOnlyInternetGatewayInput{
+		VpcId: aws.String(s.scope.VPC().ID),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeEgressOnlyInternetGateway),
+				Tags:         converters.MapToTags(infrav1.Build(s.getEgressOnlyGatewayTagParams(""))),
+			},
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedCreateEgressOnlyInternetGateway", "Failed to create new managed Egress Only Internet Gateway: %v", err)
+		return nil, errors.Wrap(err, "failed to create egress-only internet gateway")
+	}
+	record.Eventf(s.scope.AWSCluster, "SuccessfulCreateEgressOnlyInternetGateway", "Created new managed Egress Only Internet Gateway %q", *out.EgressOnlyInternetGateway.EgressOnlyInternetGatewayId)
+	s.scope.Info("Created egress-only internet gateway for VPC", "vpc-id", s.scope.VPC().ID)
+
+	return out.EgressOnlyInternetGateway, nil
+}
+
+func (s *Service) describeVpcEgressOnlyInternetGateways() ([]*ec2.EgressOnlyInternetGateway, error) {
+	// Egress-only internet gateways can't be filtered by VPC, only by tags.
+	out, err := s.scope.EC2.DescribeEgressOnlyInternetGateways(&ec2.DescribeEgressOnlyInternetGatewaysInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.Cluster(s.scope.Name()),
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe egress-only internet gateways in vpc %q", s.scope.VPC().ID)
+	}
+
+	var eigws []*ec2.EgressOnlyInternetGateway
+	for _, eigw := range out.EgressOnlyInternetGateways {
+		for _, attachment := range eigw.Attachments {
+			if aws.StringValue(attachment.VpcId) == s.scope.VPC().ID {
+				eigws = append(eigws, eigw)
+				break
+			}
+		}
+	}
+
+	return eigws, nil
+}
+
+func (s *Service) getEgressOnlyGatewayTagParams(id string) infrav1.BuildParams {
+	name := fmt.Sprintf("%s-eigw", s.scope.Name())
+
+	return infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		ResourceID:  id,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(infrav1.CommonRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	}
+}
@@ -134,3 +134,134 @@ func TestReconcileInternetGateways(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileEgressOnlyInternetGateways(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name   string
+		input  *infrav1.NetworkSpec
+		expect func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		wantID string
+	}{
+		{
+			name: "ipv6 disabled, does nothing",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: "vpc-gateways",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "has eigw",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:   "vpc-gateways",
+					IPv6: &infrav1.IPv6{CidrBlock: "2001:db8::/56"},
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeEgressOnlyInternetGateways(gomock.AssignableToTypeOf(&ec2.DescribeEgressOnlyInternetGatewaysInput{})).
+					Return(&ec2.DescribeEgressOnlyInternetGatewaysOutput{
+						EgressOnlyInternetGateways: []*ec2.EgressOnlyInternetGateway{
+							{
+								EgressOnlyInternetGatewayId: aws.String("eigw-other"),
+								Attachments: []*ec2.InternetGatewayAttachment{
+									{
+										State: aws.String(ec2.AttachmentStatusAttached),
+										VpcId: aws.String("vpc-other"),
+									},
+								},
+							},
+							{
+								EgressOnlyInternetGatewayId: aws.String("eigw-0"),
+								Attachments: []*ec2.InternetGatewayAttachment{
+									{
+										State: aws.String(ec2.AttachmentStatusAttached),
+										VpcId: aws.String("vpc-gateways"),
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil)
+			},
+			wantID: "eigw-0",
+		},
+		{
+			name: "no eigw attached, creates one",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:   "vpc-gateways",
+					IPv6: &infrav1.IPv6{CidrBlock: "2001:db8::/56"},
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeEgressOnlyInternetGateways(gomock.AssignableToTypeOf(&ec2.DescribeEgressOnlyInternetGatewaysInput{})).
+					Return(&ec2.DescribeEgressOnlyInternetGatewaysOutput{}, nil)
+
+				m.CreateEgressOnlyInternetGateway(gomock.AssignableToTypeOf(&ec2.CreateEgressOnlyInternetGatewayInput{})).
+					Return(&ec2.CreateEgressOnlyInternetGatewayOutput{
+						EgressOnlyInternetGateway: &ec2.EgressOnlyInternetGateway{EgressOnlyInternetGatewayId: aws.String("eigw-1")},
+					}, nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil)
+			},
+			wantID: "eigw-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: scope.AWSClients{
+					EC2: ec2Mock,
+					ELB: elbMock,
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: *tc.input,
+					},
+				},
+			})
+
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			if err := s.reconcileEgressOnlyInternetGateways(); err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+
+			if tc.wantID == "" {
+				return
+			}
+			if id := aws.StringValue(scope.VPC().IPv6.EgressOnlyInternetGatewayID); id != tc.wantID {
+				t.Fatalf("expected egress-only internet gateway %q, got %q", tc.wantID, id)
+			}
+		})
+	}
+}
@@ -106,8 +106,15 @@ func (s *Service) CreateInstance(scope *scope.MachineScope) (*infrav1.Instance,
 	input := &infrav1.Instance{
 		Type:              scope.AWSMachine.Spec.InstanceType,
 		IAMProfile:        scope.AWSMachine.Spec.IAMInstanceProfile,
-		RootDeviceSize:    scope.AWSMachine.Spec.RootDeviceSize,
+		RootVolume:        scope.AWSMachine.Spec.RootVolume,
+		NonRootVolumes:    scope.AWSMachine.Spec.NonRootVolumes,
 		NetworkInterfaces: scope.AWSMachine.Spec.NetworkInterfaces,
+		SpotMarketOptions: scope.AWSMachine.Spec.SpotMarketOptions,
+	}
+
+	// RootDeviceSize is the deprecated way of only setting the size of the root volume.
+	if input.RootVolume == nil && scope.AWSMachine.Spec.RootDeviceSize != 0 {
+		input.RootVolume = &infrav1.Volume{Size: scope.AWSMachine.Spec.RootDeviceSize}
 	}
 
 	// Make sure to use the MachineScope here to get the merger of AWSCluster and AWSMachine tags
@@ -215,21 +222,29 @@ func (s *Service) CreateInstance(scope *scope.MachineScope) (*infrav1.Instance,
 // GetCoreSecurityGroups looks up the security group IDs managed by this actuator
 // They are considered "core" to its proper functioning
 func (s *Service) GetCoreSecurityGroups(scope *scope.MachineScope) ([]string, error) {
+	return s.getCoreSecurityGroupsForRole(scope.Role())
+}
+
+func (s *Service) getCoreSecurityGroupsForRole(role string) ([]string, error) {
 	// These are common across both controlplane and node machines
 	sgRoles := []infrav1.SecurityGroupRole{
 		infrav1.SecurityGroupNode,
 		infrav1.SecurityGroupLB,
 	}
-	switch scope.Role() {
+	switch role {
 	case "node":
 		// Just the common security groups above
 	case "control-plane":
 		sgRoles = append(sgRoles, infrav1.SecurityGroupControlPlane)
 	default:
-		return nil, errors.Errorf("Unknown node role %q", scope.Role())
+		return nil, errors.Errorf("Unknown node role %q", role)
 	}
 	ids := make([]string, 0, len(sgRoles))
 	for _, sg := range sgRoles {
+		if id, ok := s.scope.SecurityGroupOverrides()[sg]; ok {
+			ids = append(ids, id)
+			continue
+		}
 		if _, ok := s.scope.SecurityGroups()[sg]; !ok {
 			return nil, awserrors.NewFailedDependency(
 				errors.Errorf("%s security group not available", sg),
@@ -257,6 +272,23 @@ func (s *Service) TerminateInstance(instanceID string) error {
 	return nil
 }
 
+// CancelSpotInstanceRequest cancels a spot instance request, so that no
+// instance is launched for it anymore.
+func (s *Service) CancelSpotInstanceRequest(requestID string) error {
+	s.scope.V(2).Info("Attempting to cancel spot instance request", "spot-instance-request-id", requestID)
+
+	input := &ec2.CancelSpotInstanceRequestsInput{
+		SpotInstanceRequestIds: aws.StringSlice([]string{requestID}),
+	}
+
+	if _, err := s.scope.EC2.CancelSpotInstanceRequests(input); err != nil {
+		return errors.Wrapf(err, "failed to cancel spot instance request with id %q", requestID)
+	}
+
+	s.scope.V(2).Info("Cancelled spot instance request", "spot-instance-request-id", requestID)
+	return nil
+}
+
 // TerminateInstanceAndWait terminates and waits
 // for an EC2 instance to terminate.
 func (s *Service) TerminateInstanceAndWait(instanceID string) error {
@@ -288,25 +320,11 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 	}
 
 	if i.UserData != nil {
-		var buf bytes.Buffer
-
-		decoded, err := base64.StdEncoding.DecodeString(*i.UserData)
+		userData, err := s.compressUserData(role, *i.UserData)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to decode bootstrapData")
-		}
-
-		gz := gzip.NewWriter(&buf)
-		if _, err := gz.Write(decoded); err != nil {
-			return nil, errors.Wrap(err, "failed to gzip userdata")
-		}
-
-		if err := gz.Close(); err != nil {
-			return nil, errors.Wrap(err, "failed to gzip userdata")
+			return nil, err
 		}
-
-		s.scope.V(2).Info("userData size", "bytes", buf.Len(), "role", role)
-
-		input.UserData = aws.String(base64.StdEncoding.EncodeToString(buf.Bytes()))
+		input.UserData = aws.String(userData)
 	}
 
 	if len(i.NetworkInterfaces) > 0 {
@@ -334,21 +352,23 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 	}
 
-	if i.RootDeviceSize != 0 {
+	if i.RootVolume != nil {
 		rootDeviceName, err := s.getImageRootDevice(i.ImageID)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get root volume from image %q", i.ImageID)
 		}
 
-		input.BlockDeviceMappings = []*ec2.BlockDeviceMapping{
-			{
-				DeviceName: rootDeviceName,
-				Ebs: &ec2.EbsBlockDevice{
-					DeleteOnTermination: aws.Bool(true),
-					VolumeSize:          aws.Int64(i.RootDeviceSize),
-				},
-			},
-		}
+		input.BlockDeviceMappings = append(input.BlockDeviceMappings, getBlockDeviceMapping(aws.StringValue(rootDeviceName), i.RootVolume))
+	}
+
+	for n := range i.NonRootVolumes {
+		volume := &i.NonRootVolumes[n]
+		input.BlockDeviceMappings = append(input.BlockDeviceMappings, getBlockDeviceMapping(volume.DeviceName, volume))
+	}
+
+	input.InstanceMarketOptions = getInstanceMarketOptionsRequest(i.SpotMarketOptions)
+	if i.SpotMarketOptions != nil && i.SpotMarketOptions.InterruptionBehavior == infrav1.SpotInterruptionBehaviorHibernate {
+		input.HibernationOptions = &ec2.HibernationOptionsRequest{Configured: aws.Bool(true)}
 	}
 
 	if len(i.Tags) > 0 {
@@ -388,6 +408,54 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 	return converters.SDKToInstance(out.Instances[0]), nil
 }
 
+// getInstanceMarketOptionsRequest returns the market options to run a spot
+// instance with, or nil for an on-demand instance.
+func getInstanceMarketOptionsRequest(spotMarketOptions *infrav1.SpotMarketOptions) *ec2.InstanceMarketOptionsRequest {
+	if spotMarketOptions == nil {
+		return nil
+	}
+
+	// One-time spot requests only support terminating interrupted instances,
+	// instances that are stopped or hibernated need a persistent request.
+	spotOptions := &ec2.SpotMarketOptions{
+		SpotInstanceType: aws.String(ec2.SpotInstanceTypeOneTime),
+		MaxPrice:         spotMarketOptions.MaxPrice,
+	}
+	if behavior := spotMarketOptions.InterruptionBehavior; behavior != "" && behavior != infrav1.SpotInterruptionBehaviorTerminate {
+		spotOptions.SpotInstanceType = aws.String(ec2.SpotInstanceTypePersistent)
+		spotOptions.InstanceInterruptionBehavior = aws.String(string(behavior))
+	}
+
+	return &ec2.InstanceMarketOptionsRequest{
+		MarketType:  aws.String(ec2.MarketTypeSpot),
+		SpotOptions: spotOptions,
+	}
+}
+
+// compressUserData takes base64 encoded bootstrap data, gzips it and returns
+// the compressed data base64 encoded, as expected by the EC2 APIs.
+func (s *Service) compressUserData(role string, data string) (string, error) {
+	var buf bytes.Buffer
+
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode bootstrapData")
+	}
+
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(decoded); err != nil {
+		return "", errors.Wrap(err, "failed to gzip userdata")
+	}
+
+	if err := gz.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to gzip userdata")
+	}
+
+	s.scope.V(2).Info("userData size", "bytes", buf.Len(), "role", role)
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
 // An internal type to satisfy aws' log interface.
 type awslog struct {
 	logr.Logger
@@ -520,33 +588,87 @@ func (s *Service) getImageRootDevice(imageID string) (*string, error) {
 	return output.Images[0].RootDeviceName, nil
 }
 
-func (s *Service) getInstanceRootDeviceSize(instance *ec2.Instance) (*int64, error) {
+// getBlockDeviceMapping returns the block device mapping that creates the given
+// EBS volume when the instance is launched. The volume is deleted with the instance.
+func getBlockDeviceMapping(deviceName string, v *infrav1.Volume) *ec2.BlockDeviceMapping {
+	ebs := &ec2.EbsBlockDevice{
+		DeleteOnTermination: aws.Bool(true),
+		VolumeSize:          aws.Int64(v.Size),
+		Encrypted:           v.Encrypted,
+		Throughput:          v.Throughput,
+	}
+
+	if v.Type != "" {
+		ebs.VolumeType = aws.String(string(v.Type))
+	}
+
+	if v.IOPS != 0 {
+		ebs.Iops = aws.Int64(v.IOPS)
+	}
+
+	if v.KMSKeyID != "" {
+		ebs.KmsKeyId = aws.String(v.KMSKeyID)
+	}
 
+	return &ec2.BlockDeviceMapping{
+		DeviceName: aws.String(deviceName),
+		Ebs:        ebs,
+	}
+}
+
+// getInstanceVolumes describes the EBS volumes attached to the instance and
+// returns its root volume, if any, and the other volumes.
+func (s *Service) getInstanceVolumes(instance *ec2.Instance) (*infrav1.Volume, []infrav1.Volume, error) {
+	deviceNames := map[string]string{}
+	var volumeIDs []*string
 	for _, bdm := range instance.BlockDeviceMappings {
-		if aws.StringValue(bdm.DeviceName) == aws.StringValue(instance.RootDeviceName) {
-			input := &ec2.DescribeVolumesInput{
-				VolumeIds: []*string{bdm.Ebs.VolumeId},
-			}
+		if bdm.Ebs == nil || bdm.Ebs.VolumeId == nil {
+			continue
+		}
+		deviceNames[*bdm.Ebs.VolumeId] = aws.StringValue(bdm.DeviceName)
+		volumeIDs = append(volumeIDs, bdm.Ebs.VolumeId)
+	}
 
-			out, err := s.scope.EC2.DescribeVolumes(input)
-			if err != nil {
-				return nil, err
-			}
+	if len(volumeIDs) == 0 {
+		return nil, nil, nil
+	}
 
-			if len(out.Volumes) == 0 {
-				return nil, errors.Errorf("no volumes found for id %q", aws.StringValue(bdm.Ebs.VolumeId))
-			}
+	out, err := s.scope.EC2.DescribeVolumes(&ec2.DescribeVolumesInput{VolumeIds: volumeIDs})
+	if err != nil {
+		return nil, nil, err
+	}
 
-			return out.Volumes[0].Size, nil
+	if len(out.Volumes) != len(volumeIDs) {
+		return nil, nil, errors.Errorf("expected %d volumes for instance %q, found %d", len(volumeIDs), aws.StringValue(instance.InstanceId), len(out.Volumes))
+	}
+
+	var root *infrav1.Volume
+	var nonRoot []infrav1.Volume
+	for _, v := range out.Volumes {
+		volume := infrav1.Volume{
+			DeviceName: deviceNames[aws.StringValue(v.VolumeId)],
+			Size:       aws.Int64Value(v.Size),
+			Type:       infrav1.VolumeType(aws.StringValue(v.VolumeType)),
+			IOPS:       aws.Int64Value(v.Iops),
+			Throughput: v.Throughput,
+			Encrypted:  v.Encrypted,
+			KMSKeyID:   aws.StringValue(v.KmsKeyId),
 		}
+
+		if volume.DeviceName == aws.StringValue(instance.RootDeviceName) {
+			root = &volume
+			continue
+		}
+		nonRoot = append(nonRoot, volume)
 	}
-	return nil, nil
+
+	return root, nonRoot, nil
 }
 
 // SDKToInstance converts an AWS EC2 SDK instance to the CAPA instance type.
-// converters.SDKToInstance populates all instance fields except for rootVolumeSize,
-// because EC2.DescribeInstances does not return the size of storage devices. An
-// additional call to EC2 is required to get this value.
+// converters.SDKToInstance populates all instance fields except for the volumes,
+// because EC2.DescribeInstances does not return the details of storage devices. An
+// additional call to EC2 is required to get these values.
 func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 	i := &infrav1.Instance{
 		ID:           aws.StringValue(v.InstanceId),
@@ -559,6 +681,18 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 		PublicIP:     v.PublicIpAddress,
 		ENASupport:   v.EnaSupport,
 		EBSOptimized: v.EbsOptimized,
+
+		SpotInstanceRequestID: v.SpotInstanceRequestId,
+	}
+
+	// EC2 only reports a lifecycle for spot and scheduled instances.
+	i.Lifecycle = infrav1.InstanceLifecycleOnDemand
+	if v.InstanceLifecycle != nil {
+		i.Lifecycle = infrav1.InstanceLifecycle(*v.InstanceLifecycle)
+	}
+
+	if v.StateReason != nil {
+		i.StateReason = v.StateReason.Code
 	}
 
 	// Extract IAM Instance Profile name from ARN
@@ -579,12 +713,16 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 		i.Tags = converters.TagsToMap(v.Tags)
 	}
 
-	rootSize, err := s.getInstanceRootDeviceSize(v)
+	rootVolume, nonRootVolumes, err := s.getInstanceVolumes(v)
 	if err != nil {
-		return nil, errors.Wrapf(err, "unable to get root volume size for instance: %q", aws.StringValue(v.InstanceId))
+		return nil, errors.Wrapf(err, "unable to get volumes for instance: %q", aws.StringValue(v.InstanceId))
 	}
 
-	i.RootDeviceSize = aws.Int64Value(rootSize)
+	if rootVolume != nil {
+		i.RootDeviceSize = rootVolume.Size
+	}
+	i.RootVolume = rootVolume
+	i.NonRootVolumes = nonRootVolumes
 	return i, nil
 }
 
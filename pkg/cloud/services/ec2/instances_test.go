@@ -17,6 +17,7 @@ limitations under the License.
 package ec2
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -326,6 +327,112 @@ func TestCreateInstance(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "hibernated spot instance",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						// echo "user-data" | base64
+						Data: pointer.StringPtr("dXNlci1kYXRhCg=="),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				RootVolume:   &infrav1.Volume{Size: 30, Encrypted: aws.Bool(true)},
+				SpotMarketOptions: &infrav1.SpotMarketOptions{
+					InterruptionBehavior: infrav1.SpotInterruptionBehaviorHibernate,
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							&infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							&infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:           aws.String("ami-1"),
+								RootDeviceName: aws.String("/dev/xvda"),
+							},
+						},
+					}, nil).
+					AnyTimes()
+				m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						if input.HibernationOptions == nil || !aws.BoolValue(input.HibernationOptions.Configured) {
+							t.Fatalf("expected hibernation to be configured, got %v", input.HibernationOptions)
+						}
+						if len(input.BlockDeviceMappings) != 1 || !aws.BoolValue(input.BlockDeviceMappings[0].Ebs.Encrypted) {
+							t.Fatalf("expected an encrypted root volume, got %v", input.BlockDeviceMappings)
+						}
+						if aws.StringValue(input.InstanceMarketOptions.SpotOptions.InstanceInterruptionBehavior) != ec2.InstanceInterruptionBehaviorHibernate {
+							t.Fatalf("unexpected spot options: %v", input.InstanceMarketOptions)
+						}
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
 		{
 			name: "with availability zone",
 			machine: clusterv1.Machine{
@@ -853,3 +960,116 @@ func TestCreateInstance(t *testing.T) {
 		})
 	}
 }
+
+func TestGetInstanceMarketOptionsRequest(t *testing.T) {
+	testCases := []struct {
+		name              string
+		spotMarketOptions *infrav1.SpotMarketOptions
+		expected          *ec2.InstanceMarketOptionsRequest
+	}{
+		{
+			name: "on-demand",
+		},
+		{
+			name:              "spot with defaults",
+			spotMarketOptions: &infrav1.SpotMarketOptions{},
+			expected: &ec2.InstanceMarketOptionsRequest{
+				MarketType: aws.String(ec2.MarketTypeSpot),
+				SpotOptions: &ec2.SpotMarketOptions{
+					SpotInstanceType: aws.String(ec2.SpotInstanceTypeOneTime),
+				},
+			},
+		},
+		{
+			name: "spot with max price",
+			spotMarketOptions: &infrav1.SpotMarketOptions{
+				MaxPrice:             aws.String("0.05"),
+				InterruptionBehavior: infrav1.SpotInterruptionBehaviorTerminate,
+			},
+			expected: &ec2.InstanceMarketOptionsRequest{
+				MarketType: aws.String(ec2.MarketTypeSpot),
+				SpotOptions: &ec2.SpotMarketOptions{
+					SpotInstanceType: aws.String(ec2.SpotInstanceTypeOneTime),
+					MaxPrice:         aws.String("0.05"),
+				},
+			},
+		},
+		{
+			name: "spot stopped on interruption",
+			spotMarketOptions: &infrav1.SpotMarketOptions{
+				InterruptionBehavior: infrav1.SpotInterruptionBehaviorStop,
+			},
+			expected: &ec2.InstanceMarketOptionsRequest{
+				MarketType: aws.String(ec2.MarketTypeSpot),
+				SpotOptions: &ec2.SpotMarketOptions{
+					SpotInstanceType:             aws.String(ec2.SpotInstanceTypePersistent),
+					InstanceInterruptionBehavior: aws.String(ec2.InstanceInterruptionBehaviorStop),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getInstanceMarketOptionsRequest(tc.spotMarketOptions)
+			if !reflect.DeepEqual(request, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, request)
+			}
+		})
+	}
+}
+
+func TestGetBlockDeviceMapping(t *testing.T) {
+	testCases := []struct {
+		name       string
+		deviceName string
+		volume     *infrav1.Volume
+		expected   *ec2.BlockDeviceMapping
+	}{
+		{
+			name:       "size only",
+			deviceName: "/dev/sda1",
+			volume:     &infrav1.Volume{Size: 20},
+			expected: &ec2.BlockDeviceMapping{
+				DeviceName: aws.String("/dev/sda1"),
+				Ebs: &ec2.EbsBlockDevice{
+					DeleteOnTermination: aws.Bool(true),
+					VolumeSize:          aws.Int64(20),
+				},
+			},
+		},
+		{
+			name:       "encrypted gp3 volume",
+			deviceName: "/dev/sdb",
+			volume: &infrav1.Volume{
+				Size:       100,
+				Type:       infrav1.VolumeTypeGP3,
+				IOPS:       4000,
+				Throughput: aws.Int64(250),
+				Encrypted:  aws.Bool(true),
+				KMSKeyID:   "alias/ebs",
+			},
+			expected: &ec2.BlockDeviceMapping{
+				DeviceName: aws.String("/dev/sdb"),
+				Ebs: &ec2.EbsBlockDevice{
+					DeleteOnTermination: aws.Bool(true),
+					VolumeSize:          aws.Int64(100),
+					VolumeType:          aws.String(ec2.VolumeTypeGp3),
+					Iops:                aws.Int64(4000),
+					Throughput:          aws.Int64(250),
+					Encrypted:           aws.Bool(true),
+					KmsKeyId:            aws.String("alias/ebs"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mapping := getBlockDeviceMapping(tc.deviceName, tc.volume)
+			if !reflect.DeepEqual(mapping, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, mapping)
+			}
+		})
+	}
+}
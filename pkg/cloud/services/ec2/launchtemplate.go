@@ -0,0 +1,387 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+const (
+	// launchTemplateLatestVersion is the alias used to reference the latest version of a launch template.
+	launchTemplateLatestVersion = "$Latest"
+)
+
+// ReconcileLaunchTemplate ensures the launch template for the machine pool exists and that its
+// latest version matches the desired spec, creating a new version when it doesn't.
+func (s *Service) ReconcileLaunchTemplate(scope *scope.MachinePoolScope) (*infrav1.LaunchTemplate, error) {
+	s.scope.V(2).Info("Reconciling launch template", "name", scope.LaunchTemplateName())
+
+	desired, err := s.launchTemplateInstance(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.GetLaunchTemplate(scope.LaunchTemplateName())
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		lt, err := s.createLaunchTemplate(scope, desired)
+		if err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedCreateLaunchTemplate", "Failed to create launch template: %v", err)
+			return nil, err
+		}
+		record.Eventf(scope.AWSMachinePool, "SuccessfulCreateLaunchTemplate", "Created launch template %q", lt.ID)
+		return lt, nil
+	}
+
+	// Launch template names are unique per account and region, don't take over one
+	// that was created for another cluster or outside of the provider.
+	if !existing.Tags.HasOwned(s.scope.Name()) {
+		record.Warnf(scope.AWSMachinePool, "FailedUpdateLaunchTemplate", "Launch template %q is not owned by cluster %q", existing.ID, s.scope.Name())
+		return nil, errors.Errorf("launch template %q exists but is not owned by cluster %q", scope.LaunchTemplateName(), s.scope.Name())
+	}
+
+	if !launchTemplateNeedsUpdate(desired, &existing.Instance) {
+		return existing, nil
+	}
+
+	lt, err := s.createLaunchTemplateVersion(existing.ID, desired)
+	if err != nil {
+		record.Warnf(scope.AWSMachinePool, "FailedUpdateLaunchTemplate", "Failed to create launch template version: %v", err)
+		return nil, err
+	}
+
+	record.Eventf(scope.AWSMachinePool, "SuccessfulUpdateLaunchTemplate", "Created version %d of launch template %q", aws.Int64Value(lt.LatestVersion), lt.ID)
+	return lt, nil
+}
+
+// GetLaunchTemplate returns the latest version of the launch template with the given name,
+// or nothing if it doesn't exist.
+func (s *Service) GetLaunchTemplate(name string) (*infrav1.LaunchTemplate, error) {
+	input := &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateName: aws.String(name),
+		Versions:           []*string{aws.String(launchTemplateLatestVersion)},
+	}
+
+	out, err := s.scope.EC2.DescribeLaunchTemplateVersions(input)
+	if code, ok := awserrors.Code(err); ok && code == awserrors.LaunchTemplateNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe launch template %q", name)
+	}
+
+	if len(out.LaunchTemplateVersions) == 0 {
+		return nil, nil
+	}
+
+	lt, err := s.sdkToLaunchTemplate(out.LaunchTemplateVersions[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// The tags of the launch template itself are not part of its versions.
+	templates, err := s.scope.EC2.DescribeLaunchTemplates(&ec2.DescribeLaunchTemplatesInput{
+		LaunchTemplateIds: []*string{aws.String(lt.ID)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe launch template %q", name)
+	}
+	for _, t := range templates.LaunchTemplates {
+		lt.Tags = converters.TagsToMap(t.Tags)
+	}
+
+	return lt, nil
+}
+
+// DeleteLaunchTemplate deletes the launch template with the given ID.
+func (s *Service) DeleteLaunchTemplate(id string) error {
+	s.scope.V(2).Info("Deleting launch template", "id", id)
+
+	input := &ec2.DeleteLaunchTemplateInput{
+		LaunchTemplateId: aws.String(id),
+	}
+
+	if _, err := s.scope.EC2.DeleteLaunchTemplate(input); err != nil {
+		return errors.Wrapf(err, "failed to delete launch template %q", id)
+	}
+
+	s.scope.V(2).Info("Deleted launch template", "id", id)
+	return nil
+}
+
+// launchTemplateInstance builds the instance parameters the launch template of the machine pool should have.
+func (s *Service) launchTemplateInstance(scope *scope.MachinePoolScope) (*infrav1.Instance, error) {
+	spec := scope.AWSMachinePool.Spec.AWSLaunchTemplate
+
+	input := &infrav1.Instance{
+		Type:           spec.InstanceType,
+		IAMProfile:     spec.IamInstanceProfile,
+		RootDeviceSize: spec.RootDeviceSize,
+	}
+
+	// Make sure to use the MachinePoolScope here to get the merger of AWSCluster and AWSMachinePool tags
+	additionalTags := scope.AdditionalTags()
+	// Set the cloud provider tag
+	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())] = string(infrav1.ResourceLifecycleOwned)
+
+	input.Tags = infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(scope.Name()),
+		Role:        aws.String(scope.Role()),
+		Additional:  additionalTags,
+	})
+
+	var err error
+	// Pick image from the launch template configuration, or use a default one.
+	if spec.AMI.ID != nil {
+		input.ImageID = *spec.AMI.ID
+	} else {
+		if scope.AWSMachinePool.Spec.Version == nil {
+			return nil, errors.New("either AWSLaunchTemplate.AMI.ID or Version must be set to pick an image")
+		}
+		imageLookupOrg := spec.ImageLookupOrg
+		if imageLookupOrg == "" {
+			imageLookupOrg = scope.AWSCluster.Spec.ImageLookupOrg
+		}
+		input.ImageID, err = s.defaultAMILookup(imageLookupOrg, "ubuntu", "18.04", *scope.AWSMachinePool.Spec.Version)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Set userdata.
+	if data := scope.GetBootstrapData(); data != nil {
+		userData, err := s.compressUserData(scope.Role(), *data)
+		if err != nil {
+			return nil, err
+		}
+		input.UserData = aws.String(userData)
+	}
+
+	// Set security groups.
+	ids, err := s.getCoreSecurityGroupsForRole(scope.Role())
+	if err != nil {
+		return nil, err
+	}
+	input.SecurityGroupIDs = append(input.SecurityGroupIDs, ids...)
+	for _, sg := range spec.AdditionalSecurityGroups {
+		if sg.ID != nil {
+			input.SecurityGroupIDs = append(input.SecurityGroupIDs, *sg.ID)
+		}
+	}
+
+	// Pick SSH key, if any.
+	input.SSHKeyName = aws.String(defaultSSHKeyName)
+	if spec.SSHKeyName != "" {
+		input.SSHKeyName = aws.String(spec.SSHKeyName)
+	} else if scope.AWSCluster.Spec.SSHKeyName != "" {
+		input.SSHKeyName = aws.String(scope.AWSCluster.Spec.SSHKeyName)
+	}
+
+	return input, nil
+}
+
+func (s *Service) createLaunchTemplate(scope *scope.MachinePoolScope, i *infrav1.Instance) (*infrav1.LaunchTemplate, error) {
+	data, err := s.launchTemplateData(i)
+	if err != nil {
+		return nil, err
+	}
+
+	name := scope.LaunchTemplateName()
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(scope.Role()),
+		Additional:  scope.AdditionalTags(),
+	})
+
+	input := &ec2.CreateLaunchTemplateInput{
+		LaunchTemplateName: aws.String(name),
+		LaunchTemplateData: data,
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeLaunchTemplate),
+				Tags:         converters.MapToTags(tags),
+			},
+		},
+	}
+
+	out, err := s.scope.EC2.CreateLaunchTemplate(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create launch template %q", name)
+	}
+
+	s.scope.V(2).Info("Created launch template", "id", aws.StringValue(out.LaunchTemplate.LaunchTemplateId))
+	return &infrav1.LaunchTemplate{
+		ID:            aws.StringValue(out.LaunchTemplate.LaunchTemplateId),
+		Name:          aws.StringValue(out.LaunchTemplate.LaunchTemplateName),
+		LatestVersion: out.LaunchTemplate.LatestVersionNumber,
+		Tags:          tags,
+		Instance:      *i,
+	}, nil
+}
+
+func (s *Service) createLaunchTemplateVersion(id string, i *infrav1.Instance) (*infrav1.LaunchTemplate, error) {
+	data, err := s.launchTemplateData(i)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &ec2.CreateLaunchTemplateVersionInput{
+		LaunchTemplateId:   aws.String(id),
+		LaunchTemplateData: data,
+	}
+
+	out, err := s.scope.EC2.CreateLaunchTemplateVersion(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create version of launch template %q", id)
+	}
+
+	s.scope.V(2).Info("Created launch template version", "id", id, "version", aws.Int64Value(out.LaunchTemplateVersion.VersionNumber))
+	return &infrav1.LaunchTemplate{
+		ID:            aws.StringValue(out.LaunchTemplateVersion.LaunchTemplateId),
+		Name:          aws.StringValue(out.LaunchTemplateVersion.LaunchTemplateName),
+		LatestVersion: out.LaunchTemplateVersion.VersionNumber,
+		Instance:      *i,
+	}, nil
+}
+
+func (s *Service) launchTemplateData(i *infrav1.Instance) (*ec2.RequestLaunchTemplateData, error) {
+	data := &ec2.RequestLaunchTemplateData{
+		InstanceType: aws.String(i.Type),
+		ImageId:      aws.String(i.ImageID),
+		KeyName:      i.SSHKeyName,
+		UserData:     i.UserData,
+	}
+
+	if len(i.SecurityGroupIDs) > 0 {
+		data.SecurityGroupIds = aws.StringSlice(i.SecurityGroupIDs)
+	}
+
+	if i.IAMProfile != "" {
+		data.IamInstanceProfile = &ec2.LaunchTemplateIamInstanceProfileSpecificationRequest{
+			Name: aws.String(i.IAMProfile),
+		}
+	}
+
+	if i.RootDeviceSize != 0 {
+		rootDeviceName, err := s.getImageRootDevice(i.ImageID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get root volume from image %q", i.ImageID)
+		}
+
+		data.BlockDeviceMappings = []*ec2.LaunchTemplateBlockDeviceMappingRequest{
+			{
+				DeviceName: rootDeviceName,
+				Ebs: &ec2.LaunchTemplateEbsBlockDeviceRequest{
+					DeleteOnTermination: aws.Bool(true),
+					VolumeSize:          aws.Int64(i.RootDeviceSize),
+				},
+			},
+		}
+	}
+
+	if len(i.Tags) > 0 {
+		spec := &ec2.LaunchTemplateTagSpecificationRequest{ResourceType: aws.String(ec2.ResourceTypeInstance)}
+		for key, value := range i.Tags {
+			spec.Tags = append(spec.Tags, &ec2.Tag{
+				Key:   aws.String(key),
+				Value: aws.String(value),
+			})
+		}
+
+		data.TagSpecifications = append(data.TagSpecifications, spec)
+	}
+
+	return data, nil
+}
+
+func (s *Service) sdkToLaunchTemplate(v *ec2.LaunchTemplateVersion) (*infrav1.LaunchTemplate, error) {
+	lt := &infrav1.LaunchTemplate{
+		ID:            aws.StringValue(v.LaunchTemplateId),
+		Name:          aws.StringValue(v.LaunchTemplateName),
+		LatestVersion: v.VersionNumber,
+	}
+
+	data := v.LaunchTemplateData
+	if data == nil {
+		return lt, nil
+	}
+
+	lt.Instance = infrav1.Instance{
+		Type:             aws.StringValue(data.InstanceType),
+		ImageID:          aws.StringValue(data.ImageId),
+		SSHKeyName:       data.KeyName,
+		UserData:         data.UserData,
+		SecurityGroupIDs: aws.StringValueSlice(data.SecurityGroupIds),
+	}
+
+	if data.IamInstanceProfile != nil {
+		lt.Instance.IAMProfile = aws.StringValue(data.IamInstanceProfile.Name)
+	}
+
+	for _, bdm := range data.BlockDeviceMappings {
+		if bdm.Ebs != nil {
+			lt.Instance.RootDeviceSize = aws.Int64Value(bdm.Ebs.VolumeSize)
+			break
+		}
+	}
+
+	return lt, nil
+}
+
+// launchTemplateNeedsUpdate returns true if the desired instance parameters
+// differ from the ones stored in the latest launch template version.
+func launchTemplateNeedsUpdate(desired, existing *infrav1.Instance) bool {
+	if desired.Type != existing.Type ||
+		desired.ImageID != existing.ImageID ||
+		desired.IAMProfile != existing.IAMProfile ||
+		desired.RootDeviceSize != existing.RootDeviceSize ||
+		aws.StringValue(desired.SSHKeyName) != aws.StringValue(existing.SSHKeyName) ||
+		aws.StringValue(desired.UserData) != aws.StringValue(existing.UserData) {
+		return true
+	}
+
+	if len(desired.SecurityGroupIDs) != len(existing.SecurityGroupIDs) {
+		return true
+	}
+
+	d := append([]string{}, desired.SecurityGroupIDs...)
+	e := append([]string{}, existing.SecurityGroupIDs...)
+	sort.Strings(d)
+	sort.Strings(e)
+	for i := range d {
+		if d[i] != e[i] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb/mock_elbiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetLaunchTemplate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name   string
+		expect func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		check  func(lt *infrav1.LaunchTemplate, err error)
+	}{
+		{
+			name: "does not exist",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersions(gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateName: aws.String("pool-1"),
+					Versions:           []*string{aws.String("$Latest")},
+				})).
+					Return(nil, awserr.New(awserrors.LaunchTemplateNotFound, "not found", nil))
+			},
+			check: func(lt *infrav1.LaunchTemplate, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if lt != nil {
+					t.Fatalf("Did not expect anything but got something: %+v", lt)
+				}
+			},
+		},
+		{
+			name: "exists",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersions(gomock.Eq(&ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateName: aws.String("pool-1"),
+					Versions:           []*string{aws.String("$Latest")},
+				})).
+					Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+						LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+							{
+								LaunchTemplateId:   aws.String("lt-1"),
+								LaunchTemplateName: aws.String("pool-1"),
+								VersionNumber:      aws.Int64(3),
+								LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+									ImageId:          aws.String("ami-1"),
+									InstanceType:     aws.String("m5.large"),
+									KeyName:          aws.String("default"),
+									SecurityGroupIds: aws.StringSlice([]string{"sg-1", "sg-2"}),
+									IamInstanceProfile: &ec2.LaunchTemplateIamInstanceProfileSpecification{
+										Name: aws.String("nodes"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.DescribeLaunchTemplates(gomock.Eq(&ec2.DescribeLaunchTemplatesInput{
+					LaunchTemplateIds: []*string{aws.String("lt-1")},
+				})).
+					Return(&ec2.DescribeLaunchTemplatesOutput{
+						LaunchTemplates: []*ec2.LaunchTemplate{
+							{
+								LaunchTemplateId: aws.String("lt-1"),
+								Tags: []*ec2.Tag{
+									{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test"), Value: aws.String("owned")},
+								},
+							},
+						},
+					}, nil)
+			},
+			check: func(lt *infrav1.LaunchTemplate, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if lt == nil {
+					t.Fatalf("expected launch template but got nothing")
+				}
+
+				if lt.ID != "lt-1" || aws.Int64Value(lt.LatestVersion) != 3 {
+					t.Fatalf("unexpected launch template: %+v", lt)
+				}
+
+				if lt.Instance.ImageID != "ami-1" || lt.Instance.IAMProfile != "nodes" || len(lt.Instance.SecurityGroupIDs) != 2 {
+					t.Fatalf("unexpected launch template data: %+v", lt.Instance)
+				}
+
+				if !lt.Tags.HasOwned("test") {
+					t.Fatalf("expected the tags of the launch template, got %+v", lt.Tags)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSClients: scope.AWSClients{
+					EC2: ec2Mock,
+					ELB: elbMock,
+				},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			lt, err := s.GetLaunchTemplate("pool-1")
+			tc.check(lt, err)
+		})
+	}
+}
+
+func TestReconcileLaunchTemplate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name      string
+		expect    func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectErr bool
+	}{
+		{
+			name: "creates a tagged launch template",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersions(gomock.AssignableToTypeOf(&ec2.DescribeLaunchTemplateVersionsInput{})).
+					Return(nil, awserr.New(awserrors.LaunchTemplateNotFound, "not found", nil))
+				m.CreateLaunchTemplate(gomock.AssignableToTypeOf(&ec2.CreateLaunchTemplateInput{})).
+					Do(func(input *ec2.CreateLaunchTemplateInput) {
+						if aws.StringValue(input.LaunchTemplateName) != "test-cluster-pool-1" {
+							t.Fatalf("unexpected launch template name %q", aws.StringValue(input.LaunchTemplateName))
+						}
+						if len(input.TagSpecifications) != 1 || aws.StringValue(input.TagSpecifications[0].ResourceType) != ec2.ResourceTypeLaunchTemplate {
+							t.Fatalf("expected the launch template to be tagged, got %v", input.TagSpecifications)
+						}
+						if tags := converters.TagsToMap(input.TagSpecifications[0].Tags); !tags.HasOwned("test-cluster") {
+							t.Fatalf("expected the launch template to be owned by the cluster, got %v", tags)
+						}
+					}).
+					Return(&ec2.CreateLaunchTemplateOutput{
+						LaunchTemplate: &ec2.LaunchTemplate{
+							LaunchTemplateId:    aws.String("lt-1"),
+							LaunchTemplateName:  aws.String("test-cluster-pool-1"),
+							LatestVersionNumber: aws.Int64(1),
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "refuses a launch template owned by another cluster",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeLaunchTemplateVersions(gomock.AssignableToTypeOf(&ec2.DescribeLaunchTemplateVersionsInput{})).
+					Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+						LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+							{
+								LaunchTemplateId:   aws.String("lt-1"),
+								LaunchTemplateName: aws.String("test-cluster-pool-1"),
+								VersionNumber:      aws.Int64(1),
+							},
+						},
+					}, nil)
+				m.DescribeLaunchTemplates(gomock.AssignableToTypeOf(&ec2.DescribeLaunchTemplatesInput{})).
+					Return(&ec2.DescribeLaunchTemplatesOutput{
+						LaunchTemplates: []*ec2.LaunchTemplate{
+							{
+								LaunchTemplateId: aws.String("lt-1"),
+								Tags: []*ec2.Tag{
+									{Key: aws.String(infrav1.ClusterTagKey("other-cluster")), Value: aws.String("owned")},
+								},
+							},
+						},
+					}, nil)
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}}
+			awsCluster := &infrav1.AWSCluster{
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupNode: {ID: "sg-node"},
+							infrav1.SecurityGroupLB:   {ID: "sg-lb"},
+						},
+					},
+				},
+			}
+
+			cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    cluster,
+				AWSCluster: awsCluster,
+				AWSClients: scope.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			mps, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+				Client:     fake.NewFakeClient(),
+				Cluster:    cluster,
+				AWSCluster: awsCluster,
+				AWSMachinePool: &infrav1.AWSMachinePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "pool-1"},
+					Spec: infrav1.AWSMachinePoolSpec{
+						AWSLaunchTemplate: infrav1.AWSLaunchTemplate{
+							InstanceType: "m5.large",
+							AMI:          infrav1.AWSResourceReference{ID: aws.String("ami-1")},
+						},
+						Bootstrap: clusterv1.Bootstrap{
+							// echo "user-data" | base64
+							Data: aws.String("dXNlci1kYXRhCg=="),
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create machine pool scope: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(cs)
+			_, err = s.ReconcileLaunchTemplate(mps)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestLaunchTemplateNeedsUpdate(t *testing.T) {
+	base := infrav1.Instance{
+		Type:             "m5.large",
+		ImageID:          "ami-1",
+		IAMProfile:       "nodes",
+		SSHKeyName:       aws.String("default"),
+		UserData:         aws.String("data"),
+		SecurityGroupIDs: []string{"sg-1", "sg-2"},
+	}
+
+	testCases := []struct {
+		name     string
+		mutate   func(i *infrav1.Instance)
+		expected bool
+	}{
+		{
+			name:     "identical",
+			mutate:   func(i *infrav1.Instance) {},
+			expected: false,
+		},
+		{
+			name: "security groups in a different order",
+			mutate: func(i *infrav1.Instance) {
+				i.SecurityGroupIDs = []string{"sg-2", "sg-1"}
+			},
+			expected: false,
+		},
+		{
+			name: "new image",
+			mutate: func(i *infrav1.Instance) {
+				i.ImageID = "ami-2"
+			},
+			expected: true,
+		},
+		{
+			name: "new userdata",
+			mutate: func(i *infrav1.Instance) {
+				i.UserData = aws.String("other")
+			},
+			expected: true,
+		},
+		{
+			name: "additional security group",
+			mutate: func(i *infrav1.Instance) {
+				i.SecurityGroupIDs = append(i.SecurityGroupIDs, "sg-3")
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			desired := base.DeepCopy()
+			tc.mutate(desired)
+			if actual := launchTemplateNeedsUpdate(desired, &base); actual != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
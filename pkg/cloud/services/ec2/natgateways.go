@@ -37,6 +37,16 @@ func (s *Service) reconcileNatGateways() error {
 		return nil
 	}
 
+	if s.scope.IsIsolated() {
+		s.scope.V(4).Info("Skipping NAT gateway reconcile in isolated mode")
+		return nil
+	}
+
+	if s.scope.NatGatewayStrategy() == infrav1.NatGatewayStrategyNone {
+		s.scope.V(4).Info("Skipping NAT gateway reconcile, the cluster uses no NAT gateways")
+		return nil
+	}
+
 	s.scope.V(2).Info("Reconciling NAT gateways")
 
 	if len(s.scope.Subnets().FilterPrivate()) == 0 {
@@ -52,6 +62,16 @@ func (s *Service) reconcileNatGateways() error {
 		return err
 	}
 
+	// With the single strategy, a NAT gateway is only created if none of the
+	// public subnets has one yet.
+	single := s.scope.NatGatewayStrategy() == infrav1.NatGatewayStrategySingle
+	hasGateway := false
+	for _, sn := range s.scope.Subnets().FilterPublic() {
+		if _, ok := existing[sn.ID]; ok && sn.ID != "" {
+			hasGateway = true
+		}
+	}
+
 	for _, sn := range s.scope.Subnets().FilterPublic() {
 		if sn.ID == "" {
 			continue
@@ -75,12 +95,17 @@ func (s *Service) reconcileNatGateways() error {
 			continue
 		}
 
+		if single && hasGateway {
+			continue
+		}
+
 		ng, err := s.createNatGateway(sn.ID)
 		if err != nil {
 			return err
 		}
 
 		sn.NatGatewayID = ng.NatGatewayId
+		hasGateway = true
 	}
 
 	return nil
@@ -92,6 +117,11 @@ func (s *Service) deleteNatGateways() error {
 		return nil
 	}
 
+	if s.scope.NatGatewayStrategy() == infrav1.NatGatewayStrategyNone {
+		s.scope.V(4).Info("Skipping NAT gateway deletion, the cluster uses no NAT gateways")
+		return nil
+	}
+
 	if len(s.scope.Subnets().FilterPrivate()) == 0 {
 		s.scope.V(2).Info("No private subnets available, skipping NAT gateways")
 		return nil
@@ -250,11 +280,26 @@ func (s *Service) deleteNatGateway(id string) error {
 	return nil
 }
 
+// getNatGatewayForSubnet returns the NAT gateway used by a private subnet
+// according to the NAT gateway strategy of the cluster, or an empty ID if the
+// cluster uses no NAT gateways.
 func (s *Service) getNatGatewayForSubnet(sn *infrav1.SubnetSpec) (string, error) {
 	if sn.IsPublic {
 		return "", errors.Errorf("cannot get NAT gateway for a public subnet, got id %q", sn.ID)
 	}
 
+	switch s.scope.NatGatewayStrategy() {
+	case infrav1.NatGatewayStrategyNone:
+		return "", nil
+	case infrav1.NatGatewayStrategySingle:
+		for _, psn := range s.scope.Subnets().FilterPublic() {
+			if psn.NatGatewayID != nil {
+				return *psn.NatGatewayID, nil
+			}
+		}
+		return "", errors.Errorf("no nat gateway available for private subnet %q", sn.ID)
+	}
+
 	azGateways := make(map[string][]string)
 	for _, psn := range s.scope.Subnets().FilterPublic() {
 		if psn.NatGatewayID == nil {
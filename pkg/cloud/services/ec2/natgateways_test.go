@@ -39,9 +39,10 @@ func TestReconcileNatGateways(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	testCases := []struct {
-		name   string
-		input  []*infrav1.SubnetSpec
-		expect func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		name     string
+		input    []*infrav1.SubnetSpec
+		strategy infrav1.NatGatewayStrategy
+		expect   func(m *mock_ec2iface.MockEC2APIMockRecorder)
 	}{
 		{
 			name: "single private subnet exists, should create no NAT gateway",
@@ -202,6 +203,119 @@ func TestReconcileNatGateways(t *testing.T) {
 					Return(nil, nil).Times(3)
 			},
 		},
+		{
+			name:     "single strategy, two public & 1 private subnet, and one NAT gateway exists",
+			strategy: infrav1.NatGatewayStrategySingle,
+			input: []*infrav1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+				{
+					ID:               "subnet-3",
+					AvailabilityZone: "us-east-1b",
+					CidrBlock:        "10.0.13.0/24",
+					IsPublic:         true,
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Do(func(_, y interface{}) {
+						funct := y.(func(page *ec2.DescribeNatGatewaysOutput, lastPage bool) bool)
+						funct(&ec2.DescribeNatGatewaysOutput{NatGateways: []*ec2.NatGateway{{
+							NatGatewayId: aws.String("gateway"),
+							SubnetId:     aws.String("subnet-3"),
+						}}}, true)
+					}).Return(nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil)
+
+				m.CreateNatGateway(gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:     "single strategy, two public & 1 private subnet, should create 1 NAT gateway",
+			strategy: infrav1.NatGatewayStrategySingle,
+			input: []*infrav1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+				{
+					ID:               "subnet-3",
+					AvailabilityZone: "us-east-1b",
+					CidrBlock:        "10.0.13.0/24",
+					IsPublic:         true,
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				m.DescribeAddresses(gomock.Any()).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+
+				m.AllocateAddress(&ec2.AllocateAddressInput{Domain: aws.String("vpc")}).
+					Return(&ec2.AllocateAddressOutput{
+						AllocationId: aws.String(ElasticIPAllocationID),
+					}, nil)
+
+				m.CreateNatGateway(&ec2.CreateNatGatewayInput{
+					AllocationId: aws.String(ElasticIPAllocationID),
+					SubnetId:     aws.String("subnet-1"),
+				}).Return(&ec2.CreateNatGatewayOutput{
+					NatGateway: &ec2.NatGateway{
+						NatGatewayId: aws.String("natgateway"),
+					},
+				}, nil)
+
+				m.WaitUntilNatGatewayAvailable(&ec2.DescribeNatGatewaysInput{
+					NatGatewayIds: []*string{aws.String("natgateway")},
+				}).Return(nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil).Times(2)
+			},
+		},
+		{
+			name:     "none strategy, public & private subnet exists, should create no NAT gateway",
+			strategy: infrav1.NatGatewayStrategyNone,
+			input: []*infrav1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPages(gomock.Any(), gomock.Any()).Times(0)
+				m.CreateNatGateway(gomock.Any()).Times(0)
+			},
+		},
 		{
 			name: "public & private subnet, and one NAT gateway exists",
 			input: []*infrav1.SubnetSpec{
@@ -305,7 +419,8 @@ func TestReconcileNatGateways(t *testing.T) {
 									infrav1.ClusterTagKey("test-cluster"): "owned",
 								},
 							},
-							Subnets: tc.input,
+							Subnets:            tc.input,
+							NatGatewayStrategy: tc.strategy,
 						},
 					},
 				},
@@ -323,3 +438,68 @@ func TestReconcileNatGateways(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNatGatewayForSubnet(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{ID: "subnet-public-1a", AvailabilityZone: "us-east-1a", IsPublic: true},
+		{ID: "subnet-public-1b", AvailabilityZone: "us-east-1b", IsPublic: true, NatGatewayID: aws.String("nat-1b")},
+		{ID: "subnet-private-1a", AvailabilityZone: "us-east-1a"},
+	}
+
+	testCases := []struct {
+		name      string
+		strategy  infrav1.NatGatewayStrategy
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:      "per-az strategy, no NAT gateway in the zone",
+			expectErr: true,
+		},
+		{
+			name:     "single strategy, uses the NAT gateway of another zone",
+			strategy: infrav1.NatGatewayStrategySingle,
+			expected: "nat-1b",
+		},
+		{
+			name:     "none strategy, no NAT gateway",
+			strategy: infrav1.NatGatewayStrategyNone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets:            subnets,
+							NatGatewayStrategy: tc.strategy,
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			s := NewService(clusterScope)
+			id, err := s.getNatGatewayForSubnet(subnets.FindByID("subnet-private-1a"))
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error but didn't get one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if id != tc.expected {
+				t.Fatalf("expected NAT gateway %q, got %q", tc.expected, id)
+			}
+		})
+	}
+}
@@ -16,39 +16,87 @@ limitations under the License.
 
 package ec2
 
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/conditions"
+)
+
 // ReconcileNetwork reconciles the network of the given cluster.
 func (s *Service) ReconcileNetwork() (err error) {
 	s.scope.V(2).Info("Reconciling network for cluster", "cluster-name", s.scope.Cluster.Name, "cluster-namespace", s.scope.Cluster.Namespace)
 
 	// VPC.
 	if err := s.reconcileVPC(); err != nil {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.VPCReadyCondition, infrav1.VPCReconciliationFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
 		return err
 	}
+	conditions.MarkTrue(s.scope.AWSCluster, infrav1.VPCReadyCondition)
 
 	// Subnets.
 	if err := s.reconcileSubnets(); err != nil {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.SubnetsReadyCondition, infrav1.SubnetsReconciliationFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
 		return err
 	}
+	conditions.MarkTrue(s.scope.AWSCluster, infrav1.SubnetsReadyCondition)
 
 	// Internet Gateways.
 	if err := s.reconcileInternetGateways(); err != nil {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.InternetGatewayReadyCondition, infrav1.InternetGatewayFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
+		return err
+	}
+	if !s.scope.IsIsolated() {
+		conditions.MarkTrue(s.scope.AWSCluster, infrav1.InternetGatewayReadyCondition)
+	}
+
+	// Egress Only Internet Gateways.
+	if err := s.reconcileEgressOnlyInternetGateways(); err != nil {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.EgressOnlyInternetGatewayReadyCondition, infrav1.EgressOnlyInternetGatewayFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
 		return err
 	}
+	if s.scope.VPC().IsIPv6Enabled() && !s.scope.IsIsolated() {
+		conditions.MarkTrue(s.scope.AWSCluster, infrav1.EgressOnlyInternetGatewayReadyCondition)
+	}
 
 	// NAT Gateways.
 	if err := s.reconcileNatGateways(); err != nil {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.NatGatewaysReadyCondition, infrav1.NatGatewaysReconciliationFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
 		return err
 	}
+	if !s.scope.IsIsolated() && s.scope.NatGatewayStrategy() != infrav1.NatGatewayStrategyNone {
+		conditions.MarkTrue(s.scope.AWSCluster, infrav1.NatGatewaysReadyCondition)
+	}
 
 	// Routing tables.
 	if err := s.reconcileRouteTables(); err != nil {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.RouteTablesReadyCondition, infrav1.RouteTableReconciliationFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
+		return err
+	}
+	conditions.MarkTrue(s.scope.AWSCluster, infrav1.RouteTablesReadyCondition)
+
+	// Transit gateway.
+	if err := s.reconcileTransitGateway(); err != nil {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.TransitGatewayAttachmentReadyCondition, infrav1.TransitGatewayAttachmentFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
 		return err
 	}
+	if s.scope.TransitGateway() != nil {
+		conditions.MarkTrue(s.scope.AWSCluster, infrav1.TransitGatewayAttachmentReadyCondition)
+	}
 
 	// Security groups.
 	if err := s.reconcileSecurityGroups(); err != nil {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.SecurityGroupsReadyCondition, infrav1.SecurityGroupsFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
+		return err
+	}
+	conditions.MarkTrue(s.scope.AWSCluster, infrav1.SecurityGroupsReadyCondition)
+
+	// VPC endpoints.
+	if err := s.reconcileVPCEndpoints(); err != nil {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.VPCEndpointsReadyCondition, infrav1.VPCEndpointsReconciliationFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
 		return err
 	}
+	if len(s.scope.VPC().Endpoints) > 0 {
+		conditions.MarkTrue(s.scope.AWSCluster, infrav1.VPCEndpointsReadyCondition)
+	}
 
 	s.scope.V(2).Info("Reconcile network completed successfully")
 	return nil
@@ -58,11 +106,21 @@ func (s *Service) ReconcileNetwork() (err error) {
 func (s *Service) DeleteNetwork() (err error) {
 	s.scope.V(2).Info("Deleting network")
 
+	// VPC endpoints.
+	if err := s.deleteVPCEndpoints(); err != nil {
+		return err
+	}
+
 	// Security groups.
 	if err := s.deleteSecurityGroups(); err != nil {
 		return err
 	}
 
+	// Transit gateway.
+	if err := s.deleteTransitGateway(); err != nil {
+		return err
+	}
+
 	// Routing tables.
 	if err := s.deleteRouteTables(); err != nil {
 		return err
@@ -78,6 +136,11 @@ func (s *Service) DeleteNetwork() (err error) {
 		return err
 	}
 
+	// Egress Only Internet Gateways.
+	if err := s.deleteEgressOnlyInternetGateways(); err != nil {
+		return err
+	}
+
 	// Internet Gateways.
 	if err := s.deleteInternetGateways(); err != nil {
 		return err
@@ -34,6 +34,7 @@ import (
 
 const (
 	anyIPv4CidrBlock       = "0.0.0.0/0"
+	anyIPv6CidrBlock       = "::/0"
 	mainRouteTableInVPCKey = "main"
 )
 
@@ -72,13 +73,20 @@ func (s *Service) reconcileRouteTables() error {
 
 			// Not recording "SuccessfulTagRouteTable" here as we don't know if this was a no-op or an actual change
 
+			if err := s.reconcileSubnetRoutes(sn, rt); err != nil {
+				return err
+			}
+
 			continue
 		}
 
 		// For each subnet that doesn't have a routing table associated with it,
 		// create a new table with the appropriate default routes and associate it to the subnet.
+		// Isolated networks only have the local route, and the routes added by the VPC endpoints.
 		var routes []*ec2.Route
-		if sn.IsPublic {
+		if s.scope.IsIsolated() {
+			s.scope.V(4).Info("Skipping default routes in isolated mode", "subnet-id", sn.ID)
+		} else if sn.IsPublic {
 			if s.scope.VPC().InternetGatewayID == nil {
 				return errors.Errorf("failed to create routing tables: internet gateway for %q is nil", s.scope.VPC().ID)
 			}
@@ -90,6 +98,10 @@ func (s *Service) reconcileRouteTables() error {
 				return err
 			}
 
+			if s.scope.VPC().IsIPv6Enabled() && s.scope.VPC().IPv6.EgressOnlyInternetGatewayID == nil {
+				return errors.Errorf("failed to create routing tables: egress-only internet gateway for %q is nil", s.scope.VPC().ID)
+			}
+
 			routes = s.getDefaultPrivateRoutes(natGatewayID)
 		}
 
@@ -109,11 +121,182 @@ func (s *Service) reconcileRouteTables() error {
 
 		s.scope.V(2).Info("Subnet has been associated with route table", "subnet-id", sn.ID, "route-table-id", rt.ID)
 		sn.RouteTableID = aws.String(rt.ID)
+
+		if err := s.reconcileSubnetRoutes(sn, &ec2.RouteTable{RouteTableId: aws.String(rt.ID)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileSubnetRoutes creates the routes of the subnet missing from its route
+// table, and deletes the ones that were removed from the subnet or whose target
+// changed. The default routes, the routes to the transit gateway of the network
+// and the routes of the gateway VPC endpoints are left untouched.
+func (s *Service) reconcileSubnetRoutes(sn *infrav1.SubnetSpec, rt *ec2.RouteTable) error {
+	id := aws.StringValue(rt.RouteTableId)
+	defaults := s.getDefaultRouteDestinations(sn)
+
+	wanted := make(map[string]infrav1.RouteSpec, len(sn.Routes))
+	for _, route := range sn.Routes {
+		wanted[route.Destination()] = route
+	}
+
+	current := make(map[string]struct{})
+	for _, route := range rt.Routes {
+		if !s.isSubnetRoute(route) {
+			continue
+		}
+
+		destination := getRouteDestination(route)
+		if _, ok := defaults[destination]; ok {
+			continue
+		}
+		if spec, ok := wanted[destination]; ok && routeTargetMatches(spec, route) {
+			current[destination] = struct{}{}
+			continue
+		}
+
+		if err := s.deleteSubnetRoute(id, destination); err != nil {
+			return err
+		}
+	}
+
+	for _, route := range sn.Routes {
+		if _, ok := current[route.Destination()]; ok {
+			continue
+		}
+		if err := s.createSubnetRoute(id, route); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+func (s *Service) createSubnetRoute(routeTableID string, route infrav1.RouteSpec) error {
+	input := &ec2.CreateRouteInput{
+		RouteTableId: aws.String(routeTableID),
+	}
+	switch {
+	case route.DestinationPrefixListID != "":
+		input.DestinationPrefixListId = aws.String(route.DestinationPrefixListID)
+	case isIPv6CidrBlock(route.DestinationCidrBlock):
+		input.DestinationIpv6CidrBlock = aws.String(route.DestinationCidrBlock)
+	default:
+		input.DestinationCidrBlock = aws.String(route.DestinationCidrBlock)
+	}
+	switch {
+	case route.TransitGatewayID != "":
+		input.TransitGatewayId = aws.String(route.TransitGatewayID)
+	case route.VPCPeeringConnectionID != "":
+		input.VpcPeeringConnectionId = aws.String(route.VPCPeeringConnectionID)
+	case route.VPCEndpointID != "":
+		input.VpcEndpointId = aws.String(route.VPCEndpointID)
+	case route.NatGatewayID != "":
+		input.NatGatewayId = aws.String(route.NatGatewayID)
+	}
+
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if _, err := s.scope.EC2.CreateRoute(input); err != nil {
+			return false, err
+		}
+		return true, nil
+	}, awserrors.RouteTableNotFound); err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedCreateRoute", "Failed to create route for %q in RouteTable %q: %v", route.Destination(), routeTableID, err)
+		return errors.Wrapf(err, "failed to create route for %q in route table %q", route.Destination(), routeTableID)
+	}
+
+	record.Eventf(s.scope.AWSCluster, "SuccessfulCreateRoute", "Created route for %q in RouteTable %q", route.Destination(), routeTableID)
+	return nil
+}
+
+func (s *Service) deleteSubnetRoute(routeTableID, destination string) error {
+	input := &ec2.DeleteRouteInput{
+		RouteTableId: aws.String(routeTableID),
+	}
+	switch {
+	case strings.HasPrefix(destination, "pl-"):
+		input.DestinationPrefixListId = aws.String(destination)
+	case isIPv6CidrBlock(destination):
+		input.DestinationIpv6CidrBlock = aws.String(destination)
+	default:
+		input.DestinationCidrBlock = aws.String(destination)
+	}
+
+	if _, err := s.scope.EC2.DeleteRoute(input); err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedDeleteRoute", "Failed to delete route for %q in RouteTable %q: %v", destination, routeTableID, err)
+		return errors.Wrapf(err, "failed to delete route for %q in route table %q", destination, routeTableID)
+	}
+
+	record.Eventf(s.scope.AWSCluster, "SuccessfulDeleteRoute", "Deleted route for %q in RouteTable %q", destination, routeTableID)
+	return nil
+}
+
+// isSubnetRoute returns true if the route could have been created from the
+// routes of a subnet, that is a route added with one of the supported targets
+// that is not managed with the transit gateway or the VPC endpoints.
+func (s *Service) isSubnetRoute(route *ec2.Route) bool {
+	if aws.StringValue(route.Origin) != ec2.RouteOriginCreateRoute {
+		return false
+	}
+
+	switch {
+	case route.TransitGatewayId != nil:
+		tgw := s.scope.TransitGateway()
+		return tgw == nil || aws.StringValue(route.TransitGatewayId) != tgw.ID
+	case route.VpcPeeringConnectionId != nil, route.NatGatewayId != nil:
+		return true
+	case strings.HasPrefix(aws.StringValue(route.GatewayId), "vpce-"):
+		// The gateway VPC endpoints add routes to the prefix lists of their service.
+		return route.DestinationPrefixListId == nil
+	}
+	return false
+}
+
+// getDefaultRouteDestinations returns the destinations of the default routes
+// created by the provider in the route table of the subnet.
+func (s *Service) getDefaultRouteDestinations(sn *infrav1.SubnetSpec) map[string]struct{} {
+	destinations := make(map[string]struct{})
+	if s.scope.IsIsolated() {
+		return destinations
+	}
+
+	if sn.IsPublic || s.scope.NatGatewayStrategy() != infrav1.NatGatewayStrategyNone {
+		destinations[anyIPv4CidrBlock] = struct{}{}
+	}
+	if s.scope.VPC().IsIPv6Enabled() {
+		destinations[anyIPv6CidrBlock] = struct{}{}
+	}
+	return destinations
+}
+
+func getRouteDestination(route *ec2.Route) string {
+	switch {
+	case route.DestinationPrefixListId != nil:
+		return aws.StringValue(route.DestinationPrefixListId)
+	case route.DestinationIpv6CidrBlock != nil:
+		return aws.StringValue(route.DestinationIpv6CidrBlock)
+	}
+	return aws.StringValue(route.DestinationCidrBlock)
+}
+
+// routeTargetMatches returns true if the route goes through the target of the spec.
+func routeTargetMatches(spec infrav1.RouteSpec, route *ec2.Route) bool {
+	switch {
+	case spec.TransitGatewayID != "":
+		return aws.StringValue(route.TransitGatewayId) == spec.TransitGatewayID
+	case spec.VPCPeeringConnectionID != "":
+		return aws.StringValue(route.VpcPeeringConnectionId) == spec.VPCPeeringConnectionID
+	case spec.VPCEndpointID != "":
+		return aws.StringValue(route.GatewayId) == spec.VPCEndpointID
+	case spec.NatGatewayID != "":
+		return aws.StringValue(route.NatGatewayId) == spec.NatGatewayID
+	}
+	return false
+}
+
 func (s *Service) describeVpcRouteTablesBySubnet() (map[string]*ec2.RouteTable, error) {
 	rts, err := s.describeVpcRouteTables()
 	if err != nil {
@@ -265,21 +448,43 @@ func (s *Service) associateRouteTable(rt *infrav1.RouteTable, subnetID string) e
 }
 
 func (s *Service) getDefaultPrivateRoutes(natGatewayID string) []*ec2.Route {
-	return []*ec2.Route{
-		{
+	var routes []*ec2.Route
+
+	// Without NAT gateways, the private subnets have no IPv4 route to the internet.
+	if natGatewayID != "" {
+		routes = append(routes, &ec2.Route{
 			DestinationCidrBlock: aws.String(anyIPv4CidrBlock),
 			NatGatewayId:         aws.String(natGatewayID),
-		},
+		})
+	}
+
+	// NAT gateways don't support IPv6, outbound IPv6 traffic goes through the egress-only internet gateway.
+	if s.scope.VPC().IsIPv6Enabled() {
+		routes = append(routes, &ec2.Route{
+			DestinationIpv6CidrBlock:    aws.String(anyIPv6CidrBlock),
+			EgressOnlyInternetGatewayId: s.scope.VPC().IPv6.EgressOnlyInternetGatewayID,
+		})
 	}
+
+	return routes
 }
 
 func (s *Service) getDefaultPublicRoutes() []*ec2.Route {
-	return []*ec2.Route{
+	routes := []*ec2.Route{
 		{
 			DestinationCidrBlock: aws.String(anyIPv4CidrBlock),
 			GatewayId:            aws.String(*s.scope.VPC().InternetGatewayID),
 		},
 	}
+
+	if s.scope.VPC().IsIPv6Enabled() {
+		routes = append(routes, &ec2.Route{
+			DestinationIpv6CidrBlock: aws.String(anyIPv6CidrBlock),
+			GatewayId:                aws.String(*s.scope.VPC().InternetGatewayID),
+		})
+	}
+
+	return routes
 }
 
 func (s *Service) getRouteTableTagParams(id string, public bool) infrav1.BuildParams {
@@ -111,6 +111,43 @@ func TestReconcileRouteTables(t *testing.T) {
 					After(publicRouteTable)
 			},
 		},
+		{
+			name: "isolated network, only the local route",
+			input: &infrav1.NetworkSpec{
+				Isolated: true,
+				VPC: infrav1.VPCSpec{
+					ID: "vpc-routetables",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: infrav1.Subnets{
+					&infrav1.SubnetSpec{
+						ID:               "subnet-routetables-private",
+						IsPublic:         false,
+						AvailabilityZone: "us-east-1a",
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.CreateRouteTable(gomock.Eq(&ec2.CreateRouteTableInput{VpcId: aws.String("vpc-routetables")})).
+					Return(&ec2.CreateRouteTableOutput{RouteTable: &ec2.RouteTable{RouteTableId: aws.String("rt-1")}}, nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil)
+
+				m.CreateRoute(gomock.Any()).Times(0)
+
+				m.AssociateRouteTable(gomock.Eq(&ec2.AssociateRouteTableInput{
+					RouteTableId: aws.String("rt-1"),
+					SubnetId:     aws.String("subnet-routetables-private"),
+				})).
+					Return(&ec2.AssociateRouteTableOutput{}, nil)
+			},
+		},
 		{
 			name: "subnets in different availability zones, returns error",
 			input: &infrav1.NetworkSpec{
@@ -141,6 +178,135 @@ func TestReconcileRouteTables(t *testing.T) {
 			},
 			err: errors.New(`no nat gateways available in "us-east-1a"`),
 		},
+		{
+			name: "new route table, subnet routes are added after the default routes",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: "vpc-routetables",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				NatGatewayStrategy: infrav1.NatGatewayStrategyNone,
+				Subnets: infrav1.Subnets{
+					&infrav1.SubnetSpec{
+						ID:               "subnet-routetables-private",
+						AvailabilityZone: "us-east-1a",
+						Routes: []infrav1.RouteSpec{
+							{DestinationCidrBlock: "0.0.0.0/0", TransitGatewayID: "tgw-egress"},
+							{DestinationPrefixListID: "pl-1", VPCPeeringConnectionID: "pcx-1"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.CreateRouteTable(gomock.Eq(&ec2.CreateRouteTableInput{VpcId: aws.String("vpc-routetables")})).
+					Return(&ec2.CreateRouteTableOutput{RouteTable: &ec2.RouteTable{RouteTableId: aws.String("rt-1")}}, nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil)
+
+				association := m.AssociateRouteTable(gomock.Eq(&ec2.AssociateRouteTableInput{
+					RouteTableId: aws.String("rt-1"),
+					SubnetId:     aws.String("subnet-routetables-private"),
+				})).
+					Return(&ec2.AssociateRouteTableOutput{}, nil)
+
+				m.CreateRoute(gomock.Eq(&ec2.CreateRouteInput{
+					DestinationCidrBlock: aws.String("0.0.0.0/0"),
+					TransitGatewayId:     aws.String("tgw-egress"),
+					RouteTableId:         aws.String("rt-1"),
+				})).
+					After(association)
+
+				m.CreateRoute(gomock.Eq(&ec2.CreateRouteInput{
+					DestinationPrefixListId: aws.String("pl-1"),
+					VpcPeeringConnectionId:  aws.String("pcx-1"),
+					RouteTableId:            aws.String("rt-1"),
+				})).
+					After(association)
+			},
+		},
+		{
+			name: "existing route table, subnet routes are reconciled",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: "vpc-routetables",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				TransitGateway: &infrav1.TransitGatewaySpec{ID: "tgw-cluster"},
+				Subnets: infrav1.Subnets{
+					&infrav1.SubnetSpec{
+						ID:               "subnet-routetables-private",
+						AvailabilityZone: "us-east-1a",
+						Routes: []infrav1.RouteSpec{
+							{DestinationCidrBlock: "10.100.0.0/16", TransitGatewayID: "tgw-shared"},
+							{DestinationCidrBlock: "10.200.0.0/16", VPCPeeringConnectionID: "pcx-2"},
+							{DestinationCidrBlock: "2001:db8::/32", VPCEndpointID: "vpce-gwlb"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				createRoute := func(route *ec2.Route) *ec2.Route {
+					route.Origin = aws.String(ec2.RouteOriginCreateRoute)
+					return route
+				}
+
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("rt-1"),
+								Associations: []*ec2.RouteTableAssociation{
+									{SubnetId: aws.String("subnet-routetables-private")},
+								},
+								Routes: []*ec2.Route{
+									{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local"), Origin: aws.String(ec2.RouteOriginCreateRouteTable)},
+									createRoute(&ec2.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-01")}),
+									createRoute(&ec2.Route{DestinationCidrBlock: aws.String("10.50.0.0/16"), TransitGatewayId: aws.String("tgw-cluster")}),
+									createRoute(&ec2.Route{DestinationPrefixListId: aws.String("pl-s3"), GatewayId: aws.String("vpce-s3")}),
+									createRoute(&ec2.Route{DestinationCidrBlock: aws.String("10.100.0.0/16"), TransitGatewayId: aws.String("tgw-shared")}),
+									createRoute(&ec2.Route{DestinationCidrBlock: aws.String("10.200.0.0/16"), VpcPeeringConnectionId: aws.String("pcx-1")}),
+									createRoute(&ec2.Route{DestinationCidrBlock: aws.String("10.250.0.0/16"), VpcPeeringConnectionId: aws.String("pcx-1")}),
+								},
+							},
+						},
+					}, nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil).AnyTimes()
+
+				m.DeleteRoute(gomock.Eq(&ec2.DeleteRouteInput{
+					DestinationCidrBlock: aws.String("10.200.0.0/16"),
+					RouteTableId:         aws.String("rt-1"),
+				})).
+					Return(&ec2.DeleteRouteOutput{}, nil)
+
+				m.DeleteRoute(gomock.Eq(&ec2.DeleteRouteInput{
+					DestinationCidrBlock: aws.String("10.250.0.0/16"),
+					RouteTableId:         aws.String("rt-1"),
+				})).
+					Return(&ec2.DeleteRouteOutput{}, nil)
+
+				m.CreateRoute(gomock.Eq(&ec2.CreateRouteInput{
+					DestinationCidrBlock:   aws.String("10.200.0.0/16"),
+					VpcPeeringConnectionId: aws.String("pcx-2"),
+					RouteTableId:           aws.String("rt-1"),
+				}))
+
+				m.CreateRoute(gomock.Eq(&ec2.CreateRouteInput{
+					DestinationIpv6CidrBlock: aws.String("2001:db8::/32"),
+					VpcEndpointId:            aws.String("vpce-gwlb"),
+					RouteTableId:             aws.String("rt-1"),
+				}))
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -18,6 +18,7 @@ package ec2
 
 import (
 	"fmt"
+	"net"
 
 	errlist "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
@@ -59,6 +60,11 @@ func (s *Service) reconcileSecurityGroups() error {
 		return err
 	}
 
+	overrides, err := s.describeSecurityGroupOverrides()
+	if err != nil {
+		return err
+	}
+
 	// Declare all security group roles that the reconcile loop takes care of.
 	roles := []infrav1.SecurityGroupRole{
 		infrav1.SecurityGroupBastion,
@@ -66,9 +72,19 @@ func (s *Service) reconcileSecurityGroups() error {
 		infrav1.SecurityGroupControlPlane,
 		infrav1.SecurityGroupNode,
 	}
+	if s.hasInterfaceVPCEndpoints() {
+		roles = append(roles, infrav1.SecurityGroupVPCEndpoint)
+	}
 
 	// First iteration makes sure that the security group are valid and fully created.
 	for _, role := range roles {
+		// Security groups provided by the user are adopted as is.
+		if sg, ok := overrides[role]; ok {
+			s.scope.SecurityGroups()[role] = sg
+			s.scope.V(2).Info("Using security group override for role", "role", role, "security-group", sg)
+			continue
+		}
+
 		sg := s.getDefaultSecurityGroup(role)
 		existing, ok := sgs[*sg.GroupName]
 
@@ -105,6 +121,9 @@ func (s *Service) reconcileSecurityGroups() error {
 	// Second iteration creates or updates all permissions on the security group to match
 	// the specified ingress rules.
 	for role, sg := range s.scope.SecurityGroups() {
+		if _, ok := overrides[role]; ok {
+			continue
+		}
 		if sg.Tags.HasAWSCloudProviderOwned(s.scope.Name()) {
 			// skip rule reconciliation, as we expect the in-cluster cloud integration to manage them
 			continue
@@ -149,7 +168,10 @@ func (s *Service) reconcileSecurityGroups() error {
 }
 
 func (s *Service) deleteSecurityGroups() error {
-	for _, sg := range s.scope.SecurityGroups() {
+	for role, sg := range s.scope.SecurityGroups() {
+		if _, ok := s.scope.SecurityGroupOverrides()[role]; ok {
+			continue
+		}
 		current := sg.IngressRules
 
 		if err := s.revokeAllSecurityGroupIngressRules(sg.ID); awserrors.IsIgnorableSecurityGroupError(err) != nil {
@@ -159,7 +181,10 @@ func (s *Service) deleteSecurityGroups() error {
 		s.scope.V(2).Info("Revoked ingress rules from security group", "revoked-ingress-rules", current, "security-group-id", sg.ID)
 	}
 
-	for _, sg := range s.scope.SecurityGroups() {
+	for role, sg := range s.scope.SecurityGroups() {
+		if _, ok := s.scope.SecurityGroupOverrides()[role]; ok {
+			continue
+		}
 		s.deleteSecurityGroup(&sg, "managed")
 	}
 
@@ -250,6 +275,49 @@ func (s *Service) describeSecurityGroupsByName() (map[string]infrav1.SecurityGro
 	return res, nil
 }
 
+// describeSecurityGroupOverrides returns the security groups provided by the
+// user, by role.
+func (s *Service) describeSecurityGroupOverrides() (map[infrav1.SecurityGroupRole]infrav1.SecurityGroup, error) {
+	overrides := s.scope.SecurityGroupOverrides()
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]*string, 0, len(overrides))
+	for _, id := range overrides {
+		ids = append(ids, aws.String(id))
+	}
+
+	out, err := s.scope.EC2.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: ids})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe security group overrides in vpc %q", s.scope.VPC().ID)
+	}
+
+	byID := make(map[string]*ec2.SecurityGroup, len(out.SecurityGroups))
+	for _, ec2sg := range out.SecurityGroups {
+		byID[aws.StringValue(ec2sg.GroupId)] = ec2sg
+	}
+
+	res := make(map[infrav1.SecurityGroupRole]infrav1.SecurityGroup, len(overrides))
+	for role, id := range overrides {
+		ec2sg, ok := byID[id]
+		if !ok {
+			return nil, errors.Errorf("security group %q for role %q not found", id, role)
+		}
+		if aws.StringValue(ec2sg.VpcId) != s.scope.VPC().ID {
+			return nil, errors.Errorf("security group %q for role %q is not in vpc %q", id, role, s.scope.VPC().ID)
+		}
+
+		sg := makeInfraSecurityGroup(ec2sg)
+		for _, ec2rule := range ec2sg.IpPermissions {
+			sg.IngressRules = append(sg.IngressRules, ingressRuleFromSDKType(ec2rule))
+		}
+		res[role] = sg
+	}
+
+	return res, nil
+}
+
 func makeInfraSecurityGroup(ec2sg *ec2.SecurityGroup) infrav1.SecurityGroup {
 	return infrav1.SecurityGroup{
 		ID:   *ec2sg.GroupId,
@@ -358,27 +426,157 @@ func (s *Service) defaultSSHIngressRule(sourceSecurityGroupID string) *infrav1.I
 	}
 }
 
+// anyIPv6CidrBlocks returns the IPv6 CIDR blocks that match any address if the
+// VPC is dual-stack, so that rules open to the world cover both address families.
+func (s *Service) anyIPv6CidrBlocks() []string {
+	if !s.scope.VPC().IsIPv6Enabled() {
+		return nil
+	}
+	return []string{anyIPv6CidrBlock}
+}
+
+// ingressCidrBlocks splits the allowed CIDR blocks of an ingress rule into
+// IPv4 and IPv6 blocks, allowing any address when none are given.
+func (s *Service) ingressCidrBlocks(allowed []string) (ipv4 []string, ipv6 []string) {
+	if len(allowed) == 0 {
+		return []string{anyIPv4CidrBlock}, s.anyIPv6CidrBlocks()
+	}
+
+	for _, cidrBlock := range allowed {
+		if isIPv6CidrBlock(cidrBlock) {
+			ipv6 = append(ipv6, cidrBlock)
+			continue
+		}
+		ipv4 = append(ipv4, cidrBlock)
+	}
+
+	return ipv4, ipv6
+}
+
+// isIPv6CidrBlock returns true if the given CIDR block is an IPv6 block.
+func isIPv6CidrBlock(cidrBlock string) bool {
+	ip, _, err := net.ParseCIDR(cidrBlock)
+	return err == nil && ip.To4() == nil
+}
+
+// apiServerIngressCidrBlocks returns the IPv4 and IPv6 CIDR blocks allowed to
+// reach the API server. When the cluster restricts them, the VPC and the public
+// IPs of its NAT gateways are added so that the load balancer and the cluster
+// machines can still reach the API server.
+func (s *Service) apiServerIngressCidrBlocks() (ipv4 []string, ipv6 []string, err error) {
+	allowed := s.scope.AWSCluster.Spec.APIServerAllowedCIDRBlocks
+	ipv4, ipv6 = s.ingressCidrBlocks(allowed)
+	if len(allowed) == 0 {
+		return ipv4, ipv6, nil
+	}
+
+	if s.scope.VPC().CidrBlock != "" {
+		ipv4 = append(ipv4, s.scope.VPC().CidrBlock)
+	}
+	if s.scope.VPC().IsIPv6Enabled() && s.scope.VPC().IPv6.CidrBlock != "" {
+		ipv6 = append(ipv6, s.scope.VPC().IPv6.CidrBlock)
+	}
+
+	gateways, err := s.describeNatGatewaysBySubnet()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, ngw := range gateways {
+		for _, address := range ngw.NatGatewayAddresses {
+			if address.PublicIp != nil {
+				ipv4 = append(ipv4, aws.StringValue(address.PublicIp)+"/32")
+			}
+		}
+	}
+
+	return unique(ipv4), unique(ipv6), nil
+}
+
+// unique returns the given strings without duplicates, in their original order.
+func unique(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		res = append(res, v)
+	}
+	return res
+}
+
+// defaultCNIIngressRules are the rules for Calico, used when the cluster does
+// not configure the ingress rules of its CNI plugin.
+var defaultCNIIngressRules = infrav1.CNIIngressRules{
+	{
+		Description: "bgp (calico)",
+		Protocol:    infrav1.SecurityGroupProtocolTCP,
+		FromPort:    179,
+		ToPort:      179,
+	},
+	{
+		Description: "IP-in-IP (calico)",
+		Protocol:    infrav1.SecurityGroupProtocolIPinIP,
+		FromPort:    -1,
+		ToPort:      65535,
+	},
+}
+
+// getCNIIngressRules returns the rules allowing the CNI plugin traffic from the
+// control plane and node machines.
+func (s *Service) getCNIIngressRules() infrav1.IngressRules {
+	cniRules := defaultCNIIngressRules
+	if cni := s.scope.CNI(); cni != nil {
+		cniRules = cni.CNIIngressRules
+	}
+
+	rules := make(infrav1.IngressRules, 0, len(cniRules))
+	for _, r := range cniRules {
+		rules = append(rules, &infrav1.IngressRule{
+			Description: r.Description,
+			Protocol:    r.Protocol,
+			FromPort:    r.FromPort,
+			ToPort:      r.ToPort,
+			SourceSecurityGroupIDs: []string{
+				s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID,
+				s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
+			},
+		})
+	}
+
+	return rules
+}
+
 func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (infrav1.IngressRules, error) {
 	switch role {
 	case infrav1.SecurityGroupBastion:
+		ipv4, ipv6 := s.ingressCidrBlocks(s.scope.Bastion().AllowedCIDRBlocks)
 		return infrav1.IngressRules{
 			{
-				Description: "SSH",
-				Protocol:    infrav1.SecurityGroupProtocolTCP,
-				FromPort:    22,
-				ToPort:      22,
-				CidrBlocks:  []string{anyIPv4CidrBlock},
+				Description:    "SSH",
+				Protocol:       infrav1.SecurityGroupProtocolTCP,
+				FromPort:       22,
+				ToPort:         22,
+				CidrBlocks:     ipv4,
+				IPv6CidrBlocks: ipv6,
 			},
 		}, nil
 	case infrav1.SecurityGroupControlPlane:
-		return infrav1.IngressRules{
+		apiServerIPv4, apiServerIPv6, err := s.apiServerIngressCidrBlocks()
+		if err != nil {
+			return nil, err
+		}
+
+		rules := infrav1.IngressRules{
 			s.defaultSSHIngressRule(s.scope.SecurityGroups()[infrav1.SecurityGroupBastion].ID),
 			{
-				Description: "Kubernetes API",
-				Protocol:    infrav1.SecurityGroupProtocolTCP,
-				FromPort:    6443,
-				ToPort:      6443,
-				CidrBlocks:  []string{anyIPv4CidrBlock},
+				Description:    "Kubernetes API",
+				Protocol:       infrav1.SecurityGroupProtocolTCP,
+				FromPort:       6443,
+				ToPort:         6443,
+				CidrBlocks:     apiServerIPv4,
+				IPv6CidrBlocks: apiServerIPv6,
 			},
 			{
 				Description:            "etcd",
@@ -394,37 +592,20 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 				ToPort:                 2380,
 				SourceSecurityGroupIDs: []string{s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID},
 			},
-			{
-				Description: "bgp (calico)",
-				Protocol:    infrav1.SecurityGroupProtocolTCP,
-				FromPort:    179,
-				ToPort:      179,
-				SourceSecurityGroupIDs: []string{
-					s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID,
-					s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
-				},
-			},
-			{
-				Description: "IP-in-IP (calico)",
-				Protocol:    infrav1.SecurityGroupProtocolIPinIP,
-				FromPort:    -1,
-				ToPort:      65535,
-				SourceSecurityGroupIDs: []string{
-					s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID,
-					s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
-				},
-			},
-		}, nil
+		}
+		return append(rules, s.getCNIIngressRules()...), nil
 
 	case infrav1.SecurityGroupNode:
-		return infrav1.IngressRules{
+		nodePortIPv4, nodePortIPv6 := s.ingressCidrBlocks(s.scope.AWSCluster.Spec.NodePortAllowedCIDRBlocks)
+		rules := infrav1.IngressRules{
 			s.defaultSSHIngressRule(s.scope.SecurityGroups()[infrav1.SecurityGroupBastion].ID),
 			{
-				Description: "Node Port Services",
-				Protocol:    infrav1.SecurityGroupProtocolTCP,
-				FromPort:    30000,
-				ToPort:      32767,
-				CidrBlocks:  []string{anyIPv4CidrBlock},
+				Description:    "Node Port Services",
+				Protocol:       infrav1.SecurityGroupProtocolTCP,
+				FromPort:       30000,
+				ToPort:         32767,
+				CidrBlocks:     nodePortIPv4,
+				IPv6CidrBlocks: nodePortIPv6,
 			},
 			{
 				Description: "Kubelet API",
@@ -437,30 +618,24 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 					s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
 				},
 			},
-			{
-				Description: "bgp (calico)",
-				Protocol:    infrav1.SecurityGroupProtocolTCP,
-				FromPort:    179,
-				ToPort:      179,
-				SourceSecurityGroupIDs: []string{
-					s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID,
-					s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
-				},
-			},
-			{
-				Description: "IP-in-IP (calico)",
-				Protocol:    infrav1.SecurityGroupProtocolIPinIP,
-				FromPort:    -1,
-				ToPort:      65535,
-				SourceSecurityGroupIDs: []string{
-					s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
-					s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID,
-				},
-			},
-		}, nil
+		}
+		return append(rules, s.getCNIIngressRules()...), nil
 	case infrav1.SecurityGroupLB:
 		// We hand this group off to the in-cluster cloud provider, so these rules aren't used
 		return infrav1.IngressRules{}, nil
+	case infrav1.SecurityGroupVPCEndpoint:
+		rule := &infrav1.IngressRule{
+			Description: "HTTPS",
+			Protocol:    infrav1.SecurityGroupProtocolTCP,
+			FromPort:    443,
+			ToPort:      443,
+			// Pods on subnets carved from secondary CIDR blocks use the endpoints too.
+			CidrBlocks: append([]string{s.scope.VPC().CidrBlock}, s.scope.VPC().SecondaryCidrBlocks...),
+		}
+		if s.scope.VPC().IsIPv6Enabled() && s.scope.VPC().IPv6.CidrBlock != "" {
+			rule.IPv6CidrBlocks = []string{s.scope.VPC().IPv6.CidrBlock}
+		}
+		return infrav1.IngressRules{rule}, nil
 	}
 
 	return nil, errors.Errorf("Cannot determine ingress rules for unknown security group role %q", role)
@@ -527,6 +702,18 @@ func ingressRuleToSDKType(i *infrav1.IngressRule) (res *ec2.IpPermission) {
 		res.IpRanges = append(res.IpRanges, ipRange)
 	}
 
+	for _, cidr := range i.IPv6CidrBlocks {
+		ipv6Range := &ec2.Ipv6Range{
+			CidrIpv6: aws.String(cidr),
+		}
+
+		if i.Description != "" {
+			ipv6Range.Description = aws.String(i.Description)
+		}
+
+		res.Ipv6Ranges = append(res.Ipv6Ranges, ipv6Range)
+	}
+
 	for _, groupID := range i.SourceSecurityGroupIDs {
 		userIDGroupPair := &ec2.UserIdGroupPair{
 			GroupId: aws.String(groupID),
@@ -571,6 +758,14 @@ func ingressRuleFromSDKType(v *ec2.IpPermission) (res *infrav1.IngressRule) {
 		res.CidrBlocks = append(res.CidrBlocks, *ec2range.CidrIp)
 	}
 
+	for _, ec2range := range v.Ipv6Ranges {
+		if ec2range.Description != nil && *ec2range.Description != "" {
+			res.Description = *ec2range.Description
+		}
+
+		res.IPv6CidrBlocks = append(res.IPv6CidrBlocks, *ec2range.CidrIpv6)
+	}
+
 	for _, pair := range v.UserIdGroupPairs {
 		if pair.GroupId == nil {
 			continue
@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
@@ -205,6 +206,62 @@ func TestReconcileSecurityGroups(t *testing.T) {
 
 			},
 		},
+		{
+			name: "all security groups overridden",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: "vpc-securitygroups",
+				},
+				SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{
+					infrav1.SecurityGroupBastion:      "sg-bastion",
+					infrav1.SecurityGroupLB:           "sg-lb",
+					infrav1.SecurityGroupControlPlane: "sg-cp",
+					infrav1.SecurityGroupNode:         "sg-node",
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
+					Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+
+				var groups []*ec2.SecurityGroup
+				for _, id := range []string{"sg-bastion", "sg-lb", "sg-cp", "sg-node"} {
+					groups = append(groups, &ec2.SecurityGroup{
+						GroupId:   aws.String(id),
+						GroupName: aws.String(id),
+						VpcId:     aws.String("vpc-securitygroups"),
+					})
+				}
+				m.DescribeSecurityGroups(gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
+					Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: groups}, nil)
+			},
+		},
+		{
+			name: "security group override in another vpc",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: "vpc-securitygroups",
+				},
+				SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{
+					infrav1.SecurityGroupNode: "sg-node",
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
+					Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+
+				m.DescribeSecurityGroups(gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
+					Return(&ec2.DescribeSecurityGroupsOutput{
+						SecurityGroups: []*ec2.SecurityGroup{
+							{
+								GroupId:   aws.String("sg-node"),
+								GroupName: aws.String("node"),
+								VpcId:     aws.String("vpc-other"),
+							},
+						},
+					}, nil)
+			},
+			err: errors.New(`security group "sg-node" for role "node" is not in vpc "vpc-securitygroups"`),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -245,6 +302,282 @@ func TestReconcileSecurityGroups(t *testing.T) {
 	}
 }
 
+func TestBastionSecurityGroupIngressRules(t *testing.T) {
+	testCases := []struct {
+		name     string
+		vpc      infrav1.VPCSpec
+		bastion  infrav1.Bastion
+		wantIPv4 []string
+		wantIPv6 []string
+	}{
+		{
+			name:     "defaults to any address",
+			wantIPv4: []string{"0.0.0.0/0"},
+		},
+		{
+			name:     "defaults to any address in a dual-stack vpc",
+			vpc:      infrav1.VPCSpec{IPv6: &infrav1.IPv6{}},
+			wantIPv4: []string{"0.0.0.0/0"},
+			wantIPv6: []string{"::/0"},
+		},
+		{
+			name:     "allowed cidr blocks",
+			vpc:      infrav1.VPCSpec{IPv6: &infrav1.IPv6{}},
+			bastion:  infrav1.Bastion{AllowedCIDRBlocks: []string{"192.168.0.0/16", "2001:db8::/32", "10.0.0.0/8"}},
+			wantIPv4: []string{"192.168.0.0/16", "10.0.0.0/8"},
+			wantIPv6: []string{"2001:db8::/32"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{VPC: tc.vpc},
+						Bastion:     tc.bastion,
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			rules, err := NewService(scope).getSecurityGroupIngressRules(infrav1.SecurityGroupBastion)
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if len(rules) != 1 {
+				t.Fatalf("expected a single ingress rule, got %v", rules)
+			}
+			if !reflect.DeepEqual(rules[0].CidrBlocks, tc.wantIPv4) {
+				t.Fatalf("expected IPv4 CIDR blocks %v, got %v", tc.wantIPv4, rules[0].CidrBlocks)
+			}
+			if !reflect.DeepEqual(rules[0].IPv6CidrBlocks, tc.wantIPv6) {
+				t.Fatalf("expected IPv6 CIDR blocks %v, got %v", tc.wantIPv6, rules[0].IPv6CidrBlocks)
+			}
+		})
+	}
+}
+
+func TestAPIServerSecurityGroupIngressRules(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name     string
+		allowed  []string
+		expect   func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		wantIPv4 []string
+		wantIPv6 []string
+	}{
+		{
+			name:     "defaults to any address",
+			expect:   func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			wantIPv4: []string{"0.0.0.0/0"},
+			wantIPv6: []string{"::/0"},
+		},
+		{
+			name:    "allowed cidr blocks include the vpc and the nat gateways",
+			allowed: []string{"192.168.0.0/16", "2001:db8:1::/48"},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Do(func(_ *ec2.DescribeNatGatewaysInput, fn func(*ec2.DescribeNatGatewaysOutput, bool) bool) {
+						fn(&ec2.DescribeNatGatewaysOutput{
+							NatGateways: []*ec2.NatGateway{
+								{
+									NatGatewayId:        aws.String("nat-1"),
+									SubnetId:            aws.String("subnet-1"),
+									NatGatewayAddresses: []*ec2.NatGatewayAddress{{PublicIp: aws.String("203.0.113.10")}},
+								},
+								{
+									NatGatewayId:        aws.String("nat-2"),
+									SubnetId:            aws.String("subnet-2"),
+									NatGatewayAddresses: []*ec2.NatGatewayAddress{{PublicIp: aws.String("203.0.113.20")}},
+								},
+							},
+						}, true)
+					}).Return(nil)
+			},
+			wantIPv4: []string{"10.0.0.0/16", "192.168.0.0/16", "203.0.113.10/32", "203.0.113.20/32"},
+			wantIPv6: []string{"2001:db8:1::/48", "2001:db8::/56"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: scope.AWSClients{
+					EC2: ec2Mock,
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						APIServerAllowedCIDRBlocks: tc.allowed,
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC: infrav1.VPCSpec{
+								ID:        "vpc-1",
+								CidrBlock: "10.0.0.0/16",
+								IPv6:      &infrav1.IPv6{CidrBlock: "2001:db8::/56"},
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			rules, err := NewService(scope).getSecurityGroupIngressRules(infrav1.SecurityGroupControlPlane)
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+
+			for _, rule := range rules {
+				if rule.Description != "Kubernetes API" {
+					continue
+				}
+				// Equals sorts the blocks of both rules.
+				want := &infrav1.IngressRule{
+					Description:    rule.Description,
+					Protocol:       rule.Protocol,
+					FromPort:       rule.FromPort,
+					ToPort:         rule.ToPort,
+					CidrBlocks:     tc.wantIPv4,
+					IPv6CidrBlocks: tc.wantIPv6,
+				}
+				if !rule.Equals(want) {
+					t.Fatalf("expected API server rule %+v, got %+v", want, rule)
+				}
+				return
+			}
+			t.Fatalf("no API server rule in %v", rules)
+		})
+	}
+}
+
+func TestVPCEndpointSecurityGroupIngressRules(t *testing.T) {
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						ID:                  "vpc-1",
+						CidrBlock:           "10.0.0.0/16",
+						SecondaryCidrBlocks: []string{"100.64.0.0/16", "100.65.0.0/16"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	rules, err := NewService(scope).getSecurityGroupIngressRules(infrav1.SecurityGroupVPCEndpoint)
+	if err != nil {
+		t.Fatalf("got an unexpected error: %v", err)
+	}
+
+	want := infrav1.IngressRules{
+		{
+			Description: "HTTPS",
+			Protocol:    infrav1.SecurityGroupProtocolTCP,
+			FromPort:    443,
+			ToPort:      443,
+			CidrBlocks:  []string{"10.0.0.0/16", "100.64.0.0/16", "100.65.0.0/16"},
+		},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Fatalf("expected VPC endpoint rules %v, got %v", want, rules)
+	}
+}
+
+func TestCNISecurityGroupIngressRules(t *testing.T) {
+	testCases := []struct {
+		name             string
+		cni              *infrav1.CNISpec
+		wantDescriptions []string
+	}{
+		{
+			name:             "defaults to calico",
+			wantDescriptions: []string{"bgp (calico)", "IP-in-IP (calico)"},
+		},
+		{
+			name: "custom rules replace calico",
+			cni: &infrav1.CNISpec{
+				CNIIngressRules: infrav1.CNIIngressRules{
+					{Description: "vxlan (cilium)", Protocol: infrav1.SecurityGroupProtocolUDP, FromPort: 8472, ToPort: 8472},
+					{Description: "health (cilium)", Protocol: infrav1.SecurityGroupProtocolTCP, FromPort: 4240, ToPort: 4240},
+				},
+			},
+			wantDescriptions: []string{"vxlan (cilium)", "health (cilium)"},
+		},
+		{
+			name: "no rules",
+			cni:  &infrav1.CNISpec{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{CNI: tc.cni},
+					},
+					Status: infrav1.AWSClusterStatus{
+						Network: infrav1.Network{
+							SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+								infrav1.SecurityGroupControlPlane: {ID: "sg-cp"},
+								infrav1.SecurityGroupNode:         {ID: "sg-node"},
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			s := NewService(scope)
+			for _, role := range []infrav1.SecurityGroupRole{infrav1.SecurityGroupControlPlane, infrav1.SecurityGroupNode} {
+				rules, err := s.getSecurityGroupIngressRules(role)
+				if err != nil {
+					t.Fatalf("got an unexpected error: %v", err)
+				}
+
+				var descriptions []string
+				for _, rule := range rules {
+					// The CNI rules are the only ones allowing both roles, besides the kubelet API.
+					if !reflect.DeepEqual(rule.SourceSecurityGroupIDs, []string{"sg-cp", "sg-node"}) || rule.Description == "Kubelet API" {
+						continue
+					}
+					descriptions = append(descriptions, rule.Description)
+				}
+				if !reflect.DeepEqual(descriptions, tc.wantDescriptions) {
+					t.Fatalf("expected %s CNI rules %v, got %v", role, tc.wantDescriptions, descriptions)
+				}
+			}
+		})
+	}
+}
+
 func matchesTags(input *ec2.CreateTagsInput) gomock.Matcher {
 	return tagMatcher{input}
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package ec2
 
 import (
+	"net"
 	"strings"
 
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
@@ -29,12 +30,14 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/internal/cidr"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
 )
 
 const (
-	defaultPrivateSubnetCidr = "10.0.0.0/24"
-	defaultPublicSubnetCidr  = "10.0.1.0/24"
+	// defaultAvailabilityZoneUsageLimit is the number of availability zones the
+	// default subnets are spread across if the VPC doesn't specify a limit.
+	defaultAvailabilityZoneUsageLimit = 3
 
 	internalLoadBalancerTag = "kubernetes.io/role/internal-elb"
 	externalLoadBalancerTag = "kubernetes.io/role/elb"
@@ -48,45 +51,54 @@ func (s *Service) reconcileSubnets() error {
 		s.scope.AWSCluster.Spec.NetworkSpec.Subnets = subnets
 	}()
 
+	// The managed subnets missing from the spec are only deleted when the spec
+	// declares subnets, the existing subnets are adopted otherwise.
+	declared := len(subnets) > 0
+
 	// Describe subnets in the vpc.
-	existing, err := s.describeVpcSubnets()
+	existing, ec2Subnets, err := s.describeVpcSubnetsWithAttributes()
 	if err != nil {
 		return err
 	}
 
-	// If the subnets are empty, populate the slice with the default configuration.
-	// Adds a single private and public subnet in the first available zone.
-	if len(existing) < 2 && len(subnets) < 2 {
-		zones, err := s.getAvailableZones()
+	// If there are no subnets at all in a managed VPC, spread a private and a
+	// public subnet across multiple availability zones.
+	if len(existing) == 0 && len(subnets) == 0 && !s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		subnets, err = s.getDefaultSubnets()
 		if err != nil {
-			return err
+			record.Warnf(s.scope.AWSCluster, "FailedDefaultSubnets", "Failed getting default subnets: %v", err)
+			return errors.Wrap(err, "failed getting default subnets")
 		}
+	}
 
-		if len(subnets.FilterPrivate()) == 0 {
-			if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+	// If only some of the subnets are there, carve the missing private or
+	// public subnets out of the free space of the VPC CIDR block.
+	if len(existing) < 2 && len(subnets) < 2 {
+		missingPrivate := len(subnets.FilterPrivate()) == 0
+		// Isolated networks have no public subnets.
+		missingPublic := len(subnets.FilterPublic()) == 0 && !s.scope.IsIsolated()
+
+		if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+			if missingPrivate {
 				return errors.New("expected at least one private subnet available for use, got 0")
 			}
-
-			subnets = append(subnets, &infrav1.SubnetSpec{
-				CidrBlock:        defaultPrivateSubnetCidr,
-				AvailabilityZone: zones[0],
-				IsPublic:         false,
-			})
-		}
-
-		if len(subnets.FilterPublic()) == 0 {
-			if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+			if missingPublic {
 				return errors.New("expected at least one public subnet available for use, got 0")
 			}
+		}
 
-			subnets = append(subnets, &infrav1.SubnetSpec{
-				CidrBlock:        defaultPublicSubnetCidr,
-				AvailabilityZone: zones[0],
-				IsPublic:         true,
-			})
+		if missingPrivate || missingPublic {
+			missing, err := s.getMissingSubnets(append(append(infrav1.Subnets{}, subnets...), existing...), missingPrivate, missingPublic)
+			if err != nil {
+				record.Warnf(s.scope.AWSCluster, "FailedDefaultSubnets", "Failed getting default subnets: %v", err)
+				return errors.Wrap(err, "failed getting default subnets")
+			}
+			subnets = append(subnets, missing...)
 		}
 	}
 
+	var removed infrav1.Subnets
+
 LoopExisting:
 	for _, exsn := range existing {
 		// Check if the subnet already exists in the state, in that case reconcile it.
@@ -96,15 +108,22 @@ LoopExisting:
 			if (sn.ID != "" && exsn.ID == sn.ID) || (sn.CidrBlock == exsn.CidrBlock) {
 				if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 					// TODO(vincepri): Validate provided subnet passes some basic checks.
+					exsn.IsPod = exsn.IsPod || sn.IsPod
+					exsn.Routes = sn.Routes
 					exsn.DeepCopyInto(sn)
 					continue LoopExisting
 				}
 
-				// Make sure tags are up to date.
+				// Make sure tags are up to date, the spec decides whether a managed subnet is used by pods.
+				exsn.IsPod = sn.IsPod
+				tagParams := s.getSubnetTagParams(exsn.ID, exsn.IsPublic, exsn.IsPod, sn.Tags)
+				if drift := infrav1.Build(tagParams).Difference(exsn.Tags); len(drift) > 0 {
+					record.Warnf(s.scope.AWSCluster, "SubnetTagsDrift", "Managed Subnet %q has missing or modified tags, restoring: %v", exsn.ID, drift)
+				}
 				if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 					if err := tags.Ensure(exsn.Tags, &tags.ApplyParams{
 						EC2Client:   s.scope.EC2,
-						BuildParams: s.getSubnetTagParams(exsn.ID, exsn.IsPublic, sn.Tags),
+						BuildParams: tagParams,
 					}); err != nil {
 						return false, err
 					}
@@ -114,18 +133,42 @@ LoopExisting:
 					return errors.Wrapf(err, "failed to ensure tags on subnet %q", exsn.ID)
 				}
 
-				// TODO(vincepri): check if subnet needs to be updated.
+				// Only public subnets assign public IPs to the instances launched in them.
+				if ec2sn := ec2Subnets[exsn.ID]; ec2sn.MapPublicIpOnLaunch != nil && aws.BoolValue(ec2sn.MapPublicIpOnLaunch) != exsn.IsPublic {
+					record.Warnf(s.scope.AWSCluster, "SubnetAttributesDrift", "Managed Subnet %q has public IP on launch set to %v, restoring", exsn.ID, !exsn.IsPublic)
+					if err := s.modifySubnetMapPublicIPOnLaunch(exsn.ID, exsn.IsPublic); err != nil {
+						return err
+					}
+				}
+
+				exsn.Routes = sn.Routes
 				exsn.DeepCopyInto(sn)
 				continue LoopExisting
 			}
 		}
 
-		// TODO(vincepri): delete extra subnets that exist and are managed by us.
+		// The subnets created by the provider that are no longer declared are
+		// deleted, the other subnets of the VPC are kept in the spec.
+		if declared && !s.scope.VPC().IsUnmanaged(s.scope.Name()) && exsn.Tags.HasOwned(s.scope.Name()) {
+			removed = append(removed, exsn)
+			continue
+		}
 		subnets = append(subnets, exsn)
 	}
 
+	// Removed subnets are deleted first, so that their CIDR blocks can be reused.
+	if err := s.deleteRemovedSubnets(removed); err != nil {
+		return err
+	}
+
 	// Proceed to create the rest of the subnets that don't have an ID.
 	if !s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		if s.scope.VPC().IsIPv6Enabled() {
+			if err := s.assignSubnetIPv6CidrBlocks(subnets); err != nil {
+				return err
+			}
+		}
+
 		for _, subnet := range subnets {
 			if subnet.ID != "" {
 				continue
@@ -144,6 +187,135 @@ LoopExisting:
 	return nil
 }
 
+// getDefaultSubnets returns a private and a public subnet for each of the
+// first availability zones of the region, up to the usage limit of the VPC.
+// Isolated networks only get the private subnets. The VPC CIDR block is split
+// into equally sized blocks for the subnets.
+func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
+	return s.getMissingSubnets(nil, true, !s.scope.IsIsolated())
+}
+
+// getMissingSubnets returns a private subnet, a public subnet or both for each
+// of the first availability zones of the region, up to the usage limit of the
+// VPC. The subnets get the largest equally sized blocks of the VPC CIDR block
+// that don't overlap the CIDR blocks of the given subnets.
+func (s *Service) getMissingSubnets(used infrav1.Subnets, private, public bool) (infrav1.Subnets, error) {
+	zones, err := s.getAvailableZones()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(zones) == 0 {
+		return nil, errors.New("no availability zones available in the region")
+	}
+
+	limit := defaultAvailabilityZoneUsageLimit
+	if s.scope.VPC().AvailabilityZoneUsageLimit != nil {
+		limit = *s.scope.VPC().AvailabilityZoneUsageLimit
+	}
+	if len(zones) > limit {
+		zones = zones[:limit]
+	}
+
+	var roles []bool
+	if private {
+		roles = append(roles, false)
+	}
+	if public {
+		roles = append(roles, true)
+	}
+
+	vpcCidr := s.scope.VPC().CidrBlock
+	if vpcCidr == "" {
+		vpcCidr = defaultVPCCidr
+	}
+
+	usedBlocks := make([]*net.IPNet, 0, len(used))
+	for _, sn := range used {
+		if _, block, err := net.ParseCIDR(sn.CidrBlock); err == nil {
+			usedBlocks = append(usedBlocks, block)
+		}
+	}
+
+	// Halve the size of the blocks until enough of them are free, splitting
+	// fails once the blocks would be smaller than the smallest subnet allowed.
+	count := len(zones) * len(roles)
+	for n := count; ; n *= 2 {
+		blocks, err := cidr.SplitIntoSubnetsIPv4(vpcCidr, n)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to split VPC CIDR block %q into %d free subnets", vpcCidr, count)
+		}
+
+		free := make([]*net.IPNet, 0, len(blocks))
+		for _, block := range blocks {
+			if !overlapsAny(block, usedBlocks) {
+				free = append(free, block)
+			}
+		}
+		if len(free) < count {
+			continue
+		}
+
+		subnets := make(infrav1.Subnets, 0, count)
+		for i, zone := range zones {
+			for j, isPublic := range roles {
+				subnets = append(subnets, &infrav1.SubnetSpec{
+					CidrBlock:        free[i*len(roles)+j].String(),
+					AvailabilityZone: zone,
+					IsPublic:         isPublic,
+				})
+			}
+		}
+		return subnets, nil
+	}
+}
+
+// overlapsAny returns true if the block overlaps any of the other blocks.
+func overlapsAny(block *net.IPNet, others []*net.IPNet) bool {
+	for _, other := range others {
+		if block.Contains(other.IP) || other.Contains(block.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// assignSubnetIPv6CidrBlocks carves a /64 block out of the VPC IPv6 CIDR block
+// for each subnet that is still to be created without an IPv6 CIDR block.
+func (s *Service) assignSubnetIPv6CidrBlocks(subnets infrav1.Subnets) error {
+	used := make(map[string]struct{}, len(subnets))
+	for _, sn := range subnets {
+		if sn.IPv6CidrBlock != "" {
+			used[sn.IPv6CidrBlock] = struct{}{}
+		}
+	}
+
+	// There are at least as many free blocks among the first len(subnets) blocks
+	// as there are subnets without an IPv6 CIDR block.
+	vpcCidr := s.scope.VPC().IPv6.CidrBlock
+	blocks, err := cidr.SplitIntoSubnetsIPv6(vpcCidr, len(subnets))
+	if err != nil {
+		return errors.Wrapf(err, "failed to split VPC IPv6 CIDR block %q into subnets", vpcCidr)
+	}
+
+	next := 0
+	for _, sn := range subnets {
+		if sn.ID != "" || sn.IPv6CidrBlock != "" {
+			continue
+		}
+
+		for ; next < len(blocks); next++ {
+			if _, ok := used[blocks[next].String()]; !ok {
+				break
+			}
+		}
+		sn.IPv6CidrBlock = blocks[next].String()
+		next++
+	}
+
+	return nil
+}
+
 func (s *Service) deleteSubnets() error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.V(4).Info("Skipping subnets deletion in unmanaged mode")
@@ -166,6 +338,13 @@ func (s *Service) deleteSubnets() error {
 }
 
 func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
+	subnets, _, err := s.describeVpcSubnetsWithAttributes()
+	return subnets, err
+}
+
+// describeVpcSubnetsWithAttributes returns the subnets of the VPC, along with
+// the subnets described by AWS indexed by ID, to check their attributes.
+func (s *Service) describeVpcSubnetsWithAttributes() (infrav1.Subnets, map[string]*ec2.Subnet, error) {
 	input := &ec2.DescribeSubnetsInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
@@ -180,23 +359,25 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 
 	out, err := s.scope.EC2.DescribeSubnets(input)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to describe subnets in vpc %q", s.scope.VPC().ID)
+		return nil, nil, errors.Wrapf(err, "failed to describe subnets in vpc %q", s.scope.VPC().ID)
 	}
 
 	routeTables, err := s.describeVpcRouteTablesBySubnet()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	natGateways, err := s.describeNatGatewaysBySubnet()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	subnets := make([]*infrav1.SubnetSpec, 0, len(out.Subnets))
+	ec2Subnets := make(map[string]*ec2.Subnet, len(out.Subnets))
 	// Besides what the AWS API tells us directly about the subnets, we also want to discover whether the subnet is "public" (i.e. directly connected to the internet) and if there are any associated NAT gateways.
 	// We also look for a tag indicating that a particular subnet should be public, to try and determine whether a managed VPC's subnet should have such a route, but does not.
 	for _, ec2sn := range out.Subnets {
+		ec2Subnets[*ec2sn.SubnetId] = ec2sn
 		spec := &infrav1.SubnetSpec{
 			ID:               *ec2sn.SubnetId,
 			CidrBlock:        *ec2sn.CidrBlock,
@@ -204,11 +385,21 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 			Tags:             converters.TagsToMap(ec2sn.Tags),
 		}
 
+		for _, association := range ec2sn.Ipv6CidrBlockAssociationSet {
+			if association.Ipv6CidrBlockState != nil && aws.StringValue(association.Ipv6CidrBlockState.State) == ec2.SubnetCidrBlockStateCodeAssociated {
+				spec.IPv6CidrBlock = aws.StringValue(association.Ipv6CidrBlock)
+			}
+		}
+
 		// A subnet is public if it's tagged as such...
 		if spec.Tags.GetRole() == infrav1.PublicRoleTagValue {
 			spec.IsPublic = true
 		}
 
+		if spec.Tags.GetRole() == infrav1.PodRoleTagValue {
+			spec.IsPod = true
+		}
+
 		// ... or if it has an internet route
 		rt := routeTables[*ec2sn.SubnetId]
 		if rt == nil {
@@ -231,15 +422,21 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 		subnets = append(subnets, spec)
 	}
 
-	return subnets, nil
+	return subnets, ec2Subnets, nil
 }
 
 func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, error) {
-	out, err := s.scope.EC2.CreateSubnet(&ec2.CreateSubnetInput{
+	input := &ec2.CreateSubnetInput{
 		VpcId:            aws.String(s.scope.VPC().ID),
 		CidrBlock:        aws.String(sn.CidrBlock),
 		AvailabilityZone: aws.String(sn.AvailabilityZone),
-	})
+	}
+
+	if sn.IPv6CidrBlock != "" {
+		input.Ipv6CidrBlock = aws.String(sn.IPv6CidrBlock)
+	}
+
+	out, err := s.scope.EC2.CreateSubnet(input)
 
 	if err != nil {
 		record.Warnf(s.scope.AWSCluster, "FailedCreateSubnet", "Failed creating new managed Subnet %v", err)
@@ -256,7 +453,7 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 		if err := tags.Apply(&tags.ApplyParams{
 			EC2Client:   s.scope.EC2,
-			BuildParams: s.getSubnetTagParams(*out.Subnet.SubnetId, sn.IsPublic, sn.Tags),
+			BuildParams: s.getSubnetTagParams(*out.Subnet.SubnetId, sn.IsPublic, sn.IsPod, sn.Tags),
 		}); err != nil {
 			return false, err
 		}
@@ -269,8 +466,14 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 	record.Eventf(s.scope.AWSCluster, "SuccessfulTagSubnet", "Tagged managed Subnet %q", *out.Subnet.SubnetId)
 
 	if sn.IsPublic {
+		if err := s.modifySubnetMapPublicIPOnLaunch(*out.Subnet.SubnetId, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if sn.IPv6CidrBlock != "" {
 		attReq := &ec2.ModifySubnetAttributeInput{
-			MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
+			AssignIpv6AddressOnCreation: &ec2.AttributeBooleanValue{
 				Value: aws.Bool(true),
 			},
 			SubnetId: out.Subnet.SubnetId,
@@ -298,10 +501,100 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		ID:               *out.Subnet.SubnetId,
 		AvailabilityZone: *out.Subnet.AvailabilityZone,
 		CidrBlock:        *out.Subnet.CidrBlock,
+		IPv6CidrBlock:    sn.IPv6CidrBlock,
 		IsPublic:         sn.IsPublic,
+		IsPod:            sn.IsPod,
+		Routes:           sn.Routes,
 	}, nil
 }
 
+func (s *Service) modifySubnetMapPublicIPOnLaunch(id string, value bool) error {
+	attReq := &ec2.ModifySubnetAttributeInput{
+		MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
+			Value: aws.Bool(value),
+		},
+		SubnetId: aws.String(id),
+	}
+
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if _, err := s.scope.EC2.ModifySubnetAttribute(attReq); err != nil {
+			return false, err
+		}
+		return true, nil
+	}, awserrors.SubnetNotFound); err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedModifySubnetAttributes", "Failed modifying managed Subnet %q attributes: %v", id, err)
+		return errors.Wrapf(err, "failed to set subnet %q attributes", id)
+	}
+	record.Eventf(s.scope.AWSCluster, "SuccessfulModifySubnetAttributes", "Modified managed Subnet %q attributes", id)
+	return nil
+}
+
+// deleteRemovedSubnets deletes the managed subnets removed from the spec along
+// with their NAT gateway and route table. A subnet still in use, by instances
+// or by the routes of other subnets to its NAT gateway, is left in place until
+// a later reconciliation.
+func (s *Service) deleteRemovedSubnets(removed infrav1.Subnets) error {
+	if len(removed) == 0 {
+		return nil
+	}
+
+	subnetRouteMap, err := s.describeVpcRouteTablesBySubnet()
+	if err != nil {
+		return err
+	}
+
+LoopRemoved:
+	for _, sn := range removed {
+		if sn.NatGatewayID != nil {
+			for id, rt := range subnetRouteMap {
+				if id == mainRouteTableInVPCKey || removed.FindByID(id) != nil {
+					continue
+				}
+				for _, route := range rt.Routes {
+					if aws.StringValue(route.NatGatewayId) == *sn.NatGatewayID {
+						record.Warnf(s.scope.AWSCluster, "FailedDeleteSubnet", "Failed to delete managed Subnet %q: NAT Gateway %q is still used by Subnet %q", sn.ID, *sn.NatGatewayID, id)
+						continue LoopRemoved
+					}
+				}
+			}
+
+			if err := s.deleteNatGateway(*sn.NatGatewayID); err != nil {
+				return err
+			}
+		}
+
+		// Deleting the subnet removes its route table association.
+		if err := s.deleteSubnet(sn.ID); err != nil {
+			if code, ok := awserrors.Code(errors.Cause(err)); ok && code == awserrors.DependencyViolation {
+				s.scope.Info("Subnet removed from the spec is still in use, retrying later", "subnet-id", sn.ID)
+				continue
+			}
+			return err
+		}
+
+		if rt, ok := subnetRouteMap[sn.ID]; ok && !isRouteTableShared(rt, sn.ID) {
+			if _, err := s.scope.EC2.DeleteRouteTable(&ec2.DeleteRouteTableInput{RouteTableId: rt.RouteTableId}); err != nil {
+				record.Warnf(s.scope.AWSCluster, "FailedDeleteRouteTable", "Failed to delete managed RouteTable %q: %v", *rt.RouteTableId, err)
+				return errors.Wrapf(err, "failed to delete route table %q", *rt.RouteTableId)
+			}
+			record.Eventf(s.scope.AWSCluster, "SuccessfulDeleteRouteTable", "Deleted managed RouteTable %q", *rt.RouteTableId)
+		}
+	}
+
+	return nil
+}
+
+// isRouteTableShared returns true if the route table is the main route table of
+// the VPC or is associated with other subnets than the given one.
+func isRouteTableShared(rt *ec2.RouteTable, subnetID string) bool {
+	for _, as := range rt.Associations {
+		if aws.BoolValue(as.Main) || (as.SubnetId != nil && *as.SubnetId != subnetID) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Service) deleteSubnet(id string) error {
 	_, err := s.scope.EC2.DeleteSubnet(&ec2.DeleteSubnetInput{
 		SubnetId: aws.String(id),
@@ -317,20 +610,26 @@ func (s *Service) deleteSubnet(id string) error {
 	return nil
 }
 
-func (s *Service) getSubnetTagParams(id string, public bool, manualTags infrav1.Tags) infrav1.BuildParams {
+func (s *Service) getSubnetTagParams(id string, public, pod bool, manualTags infrav1.Tags) infrav1.BuildParams {
 	var role string
 	additionalTags := s.scope.AdditionalTags()
 
-	if public {
+	switch {
+	case public:
 		role = infrav1.PublicRoleTagValue
 		additionalTags[externalLoadBalancerTag] = "1"
-	} else {
+	case pod:
+		role = infrav1.PodRoleTagValue
+	default:
 		role = infrav1.PrivateRoleTagValue
 		additionalTags[internalLoadBalancerTag] = "1"
 	}
 
-	// Add tag needed for Service type=LoadBalancer
-	additionalTags[infrav1.NameKubernetesAWSCloudProviderPrefix+s.scope.Name()] = string(infrav1.ResourceLifecycleShared)
+	// Add tag needed for Service type=LoadBalancer, the subnets dedicated to
+	// pods are left out so that the cloud provider does not pick them.
+	if !pod {
+		additionalTags[infrav1.NameKubernetesAWSCloudProviderPrefix+s.scope.Name()] = string(infrav1.ResourceLifecycleShared)
+	}
 
 	for k, v := range manualTags {
 		additionalTags[k] = v
@@ -350,3 +649,30 @@ func (s *Service) getSubnetTagParams(id string, public bool, manualTags infrav1.
 		Additional:  additionalTags,
 	}
 }
+
+// getPrivateSubnetIDsByZone returns a private subnet for each availability zone
+// not covered by the given subnets, for the resources that can only have one
+// subnet per availability zone, such as interface endpoints and transit
+// gateway attachments.
+func (s *Service) getPrivateSubnetIDsByZone(current []string) []string {
+	zones := make(map[string]struct{})
+	for _, id := range current {
+		if sn := s.scope.Subnets().FindByID(id); sn != nil {
+			zones[sn.AvailabilityZone] = struct{}{}
+		}
+	}
+
+	var ids []string
+	for _, sn := range s.scope.Subnets().FilterPrivate() {
+		if sn.ID == "" {
+			continue
+		}
+		if _, ok := zones[sn.AvailabilityZone]; ok {
+			continue
+		}
+		zones[sn.AvailabilityZone] = struct{}{}
+		ids = append(ids, sn.ID)
+	}
+
+	return ids
+}
@@ -18,15 +18,18 @@ package ec2
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb/mock_elbiface"
@@ -139,7 +142,7 @@ func TestReconcileSubnets(t *testing.T) {
 
 				m.CreateSubnet(gomock.Eq(&ec2.CreateSubnetInput{
 					VpcId:            aws.String(subnetsVPCID),
-					CidrBlock:        aws.String(defaultPublicSubnetCidr),
+					CidrBlock:        aws.String("10.0.128.0/17"),
 					AvailabilityZone: aws.String("us-east-1a"),
 				})).
 					Return(&ec2.CreateSubnetOutput{
@@ -281,10 +284,11 @@ func TestReconcileSubnets(t *testing.T) {
 			},
 		},
 		{
-			name: "no subnet exist, expect one private and one public from defaults",
+			name: "no subnet exist, expect one private and one public carved out of the vpc in the only zone",
 			input: &infrav1.NetworkSpec{
 				VPC: infrav1.VPCSpec{
-					ID: subnetsVPCID,
+					ID:        subnetsVPCID,
+					CidrBlock: "10.0.0.0/16",
 					Tags: infrav1.Tags{
 						infrav1.ClusterTagKey("test-cluster"): "owned",
 					},
@@ -335,14 +339,14 @@ func TestReconcileSubnets(t *testing.T) {
 
 				firstSubnet := m.CreateSubnet(gomock.Eq(&ec2.CreateSubnetInput{
 					VpcId:            aws.String(subnetsVPCID),
-					CidrBlock:        aws.String(defaultPrivateSubnetCidr),
+					CidrBlock:        aws.String("10.0.0.0/17"),
 					AvailabilityZone: aws.String("us-east-1c"),
 				})).
 					Return(&ec2.CreateSubnetOutput{
 						Subnet: &ec2.Subnet{
 							VpcId:               aws.String(subnetsVPCID),
 							SubnetId:            aws.String("subnet-1"),
-							CidrBlock:           aws.String(defaultPrivateSubnetCidr),
+							CidrBlock:           aws.String("10.0.0.0/17"),
 							AvailabilityZone:    aws.String("us-east-1c"),
 							MapPublicIpOnLaunch: aws.Bool(false),
 						},
@@ -357,14 +361,14 @@ func TestReconcileSubnets(t *testing.T) {
 
 				secondSubnet := m.CreateSubnet(gomock.Eq(&ec2.CreateSubnetInput{
 					VpcId:            aws.String(subnetsVPCID),
-					CidrBlock:        aws.String(defaultPublicSubnetCidr),
+					CidrBlock:        aws.String("10.0.128.0/17"),
 					AvailabilityZone: aws.String("us-east-1c"),
 				})).
 					Return(&ec2.CreateSubnetOutput{
 						Subnet: &ec2.Subnet{
 							VpcId:               aws.String(subnetsVPCID),
 							SubnetId:            aws.String("subnet-2"),
-							CidrBlock:           aws.String(defaultPublicSubnetCidr),
+							CidrBlock:           aws.String("10.0.128.0/17"),
 							AvailabilityZone:    aws.String("us-east-1c"),
 							MapPublicIpOnLaunch: aws.Bool(false),
 						},
@@ -387,6 +391,238 @@ func TestReconcileSubnets(t *testing.T) {
 
 			},
 		},
+		{
+			name: "no subnet exist, expect private and public subnets spread across zones up to the usage limit",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                         subnetsVPCID,
+					CidrBlock:                  "10.0.0.0/16",
+					AvailabilityZoneUsageLimit: aws.Int(2),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeAvailabilityZones(gomock.Any()).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{
+							{ZoneName: aws.String("us-east-1c")},
+							{ZoneName: aws.String("us-east-1a")},
+							{ZoneName: aws.String("us-east-1b")},
+						},
+					}, nil)
+
+				describeCall := m.DescribeSubnets(gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{}, nil)
+
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				expected := []struct {
+					zone     string
+					cidr     string
+					isPublic bool
+				}{
+					{zone: "us-east-1a", cidr: "10.0.0.0/18"},
+					{zone: "us-east-1a", cidr: "10.0.64.0/18", isPublic: true},
+					{zone: "us-east-1b", cidr: "10.0.128.0/18"},
+					{zone: "us-east-1b", cidr: "10.0.192.0/18", isPublic: true},
+				}
+
+				previous := describeCall
+				for i, sn := range expected {
+					subnetID := fmt.Sprintf("subnet-%d", i+1)
+					previous = m.CreateSubnet(gomock.Eq(&ec2.CreateSubnetInput{
+						VpcId:            aws.String(subnetsVPCID),
+						CidrBlock:        aws.String(sn.cidr),
+						AvailabilityZone: aws.String(sn.zone),
+					})).
+						Return(&ec2.CreateSubnetOutput{
+							Subnet: &ec2.Subnet{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String(subnetID),
+								CidrBlock:           aws.String(sn.cidr),
+								AvailabilityZone:    aws.String(sn.zone),
+								MapPublicIpOnLaunch: aws.Bool(false),
+							},
+						}, nil).
+						After(previous)
+
+					m.WaitUntilSubnetAvailable(gomock.Any()).
+						After(previous)
+
+					m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+						Return(nil, nil)
+
+					if sn.isPublic {
+						m.ModifySubnetAttribute(&ec2.ModifySubnetAttributeInput{
+							MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
+								Value: aws.Bool(true),
+							},
+							SubnetId: aws.String(subnetID),
+						}).
+							Return(&ec2.ModifySubnetAttributeOutput{}, nil).
+							After(previous)
+					}
+				}
+			},
+		},
+		{
+			name: "single private subnet declared, expect public subnets carved out of the free blocks of the vpc",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                         subnetsVPCID,
+					CidrBlock:                  "192.168.0.0/16",
+					AvailabilityZoneUsageLimit: aws.Int(2),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: []*infrav1.SubnetSpec{
+					{
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "192.168.0.0/24",
+						IsPublic:         false,
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeAvailabilityZones(gomock.Any()).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{
+							{ZoneName: aws.String("us-east-1a")},
+							{ZoneName: aws.String("us-east-1b")},
+							{ZoneName: aws.String("us-east-1c")},
+						},
+					}, nil)
+
+				describeCall := m.DescribeSubnets(gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{}, nil)
+
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				expected := []struct {
+					zone     string
+					cidr     string
+					isPublic bool
+				}{
+					{zone: "us-east-1a", cidr: "192.168.0.0/24"},
+					{zone: "us-east-1a", cidr: "192.168.64.0/18", isPublic: true},
+					{zone: "us-east-1b", cidr: "192.168.128.0/18", isPublic: true},
+				}
+
+				previous := describeCall
+				for i, sn := range expected {
+					subnetID := fmt.Sprintf("subnet-%d", i+1)
+					previous = m.CreateSubnet(gomock.Eq(&ec2.CreateSubnetInput{
+						VpcId:            aws.String(subnetsVPCID),
+						CidrBlock:        aws.String(sn.cidr),
+						AvailabilityZone: aws.String(sn.zone),
+					})).
+						Return(&ec2.CreateSubnetOutput{
+							Subnet: &ec2.Subnet{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String(subnetID),
+								CidrBlock:           aws.String(sn.cidr),
+								AvailabilityZone:    aws.String(sn.zone),
+								MapPublicIpOnLaunch: aws.Bool(false),
+							},
+						}, nil).
+						After(previous)
+
+					m.WaitUntilSubnetAvailable(gomock.Any()).
+						After(previous)
+
+					m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+						Return(nil, nil)
+
+					if sn.isPublic {
+						m.ModifySubnetAttribute(&ec2.ModifySubnetAttributeInput{
+							MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
+								Value: aws.Bool(true),
+							},
+							SubnetId: aws.String(subnetID),
+						}).
+							Return(&ec2.ModifySubnetAttributeOutput{}, nil).
+							After(previous)
+					}
+				}
+			},
+		},
+		{
+			name: "no subnet exist in an isolated network, expect only private subnets spread across zones",
+			input: &infrav1.NetworkSpec{
+				Isolated: true,
+				VPC: infrav1.VPCSpec{
+					ID:                         subnetsVPCID,
+					CidrBlock:                  "10.0.0.0/16",
+					AvailabilityZoneUsageLimit: aws.Int(2),
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeAvailabilityZones(gomock.Any()).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{
+							{ZoneName: aws.String("us-east-1a")},
+							{ZoneName: aws.String("us-east-1b")},
+							{ZoneName: aws.String("us-east-1c")},
+						},
+					}, nil)
+
+				describeCall := m.DescribeSubnets(gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{}, nil)
+
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				expected := []struct {
+					zone string
+					cidr string
+				}{
+					{zone: "us-east-1a", cidr: "10.0.0.0/17"},
+					{zone: "us-east-1b", cidr: "10.0.128.0/17"},
+				}
+
+				previous := describeCall
+				for i, sn := range expected {
+					previous = m.CreateSubnet(gomock.Eq(&ec2.CreateSubnetInput{
+						VpcId:            aws.String(subnetsVPCID),
+						CidrBlock:        aws.String(sn.cidr),
+						AvailabilityZone: aws.String(sn.zone),
+					})).
+						Return(&ec2.CreateSubnetOutput{
+							Subnet: &ec2.Subnet{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String(fmt.Sprintf("subnet-%d", i+1)),
+								CidrBlock:           aws.String(sn.cidr),
+								AvailabilityZone:    aws.String(sn.zone),
+								MapPublicIpOnLaunch: aws.Bool(false),
+							},
+						}, nil).
+						After(previous)
+
+					m.WaitUntilSubnetAvailable(gomock.Any()).
+						After(previous)
+
+					m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+						Return(nil, nil)
+				}
+			},
+		},
 		{
 			name: "managed VPC respects public tag",
 			input: &infrav1.NetworkSpec{
@@ -477,7 +713,7 @@ func TestReconcileSubnets(t *testing.T) {
 
 				m.CreateSubnet(gomock.Eq(&ec2.CreateSubnetInput{
 					VpcId:            aws.String(subnetsVPCID),
-					CidrBlock:        aws.String(defaultPrivateSubnetCidr),
+					CidrBlock:        aws.String("10.0.128.0/17"),
 					AvailabilityZone: aws.String("us-east-1a"),
 				})).
 					Return(&ec2.CreateSubnetOutput{
@@ -499,6 +735,215 @@ func TestReconcileSubnets(t *testing.T) {
 					Return(nil, nil)
 			},
 		},
+		{
+			name: "managed subnet removed from the spec is deleted with its route table",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: []*infrav1.SubnetSpec{
+					{
+						ID:               "subnet-1",
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.0.10.0/24",
+						IsPublic:         false,
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String("subnet-1"),
+								AvailabilityZone:    aws.String("us-east-1a"),
+								CidrBlock:           aws.String("10.0.10.0/24"),
+								MapPublicIpOnLaunch: aws.Bool(false),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+							{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String("subnet-2"),
+								AvailabilityZone:    aws.String("us-east-1a"),
+								CidrBlock:           aws.String("10.0.20.0/24"),
+								MapPublicIpOnLaunch: aws.Bool(false),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("rtb-2"),
+								Associations: []*ec2.RouteTableAssociation{
+									{SubnetId: aws.String("subnet-2"), RouteTableId: aws.String("rtb-2")},
+								},
+							},
+						},
+					}, nil).Times(2)
+
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil)
+
+				m.DeleteSubnet(gomock.Eq(&ec2.DeleteSubnetInput{SubnetId: aws.String("subnet-2")})).
+					Return(&ec2.DeleteSubnetOutput{}, nil)
+
+				m.DeleteRouteTable(gomock.Eq(&ec2.DeleteRouteTableInput{RouteTableId: aws.String("rtb-2")})).
+					Return(&ec2.DeleteRouteTableOutput{}, nil)
+			},
+		},
+		{
+			name: "managed subnet removed from the spec and still in use is kept",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: []*infrav1.SubnetSpec{
+					{
+						ID:               "subnet-1",
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.0.10.0/24",
+						IsPublic:         false,
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String("subnet-1"),
+								AvailabilityZone:    aws.String("us-east-1a"),
+								CidrBlock:           aws.String("10.0.10.0/24"),
+								MapPublicIpOnLaunch: aws.Bool(false),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+							{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String("subnet-2"),
+								AvailabilityZone:    aws.String("us-east-1a"),
+								CidrBlock:           aws.String("10.0.20.0/24"),
+								MapPublicIpOnLaunch: aws.Bool(false),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil).Times(2)
+
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil)
+
+				m.DeleteSubnet(gomock.Eq(&ec2.DeleteSubnetInput{SubnetId: aws.String("subnet-2")})).
+					Return(nil, awserr.New(awserrors.DependencyViolation, "the subnet has dependencies", nil))
+			},
+		},
+		{
+			name: "public IP on launch is restored on a managed public subnet",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: []*infrav1.SubnetSpec{
+					{
+						ID:               "subnet-1",
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.0.10.0/24",
+						IsPublic:         false,
+					},
+					{
+						ID:               "subnet-2",
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.0.20.0/24",
+						IsPublic:         true,
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSubnets(gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String("subnet-1"),
+								AvailabilityZone:    aws.String("us-east-1a"),
+								CidrBlock:           aws.String("10.0.10.0/24"),
+								MapPublicIpOnLaunch: aws.Bool(false),
+							},
+							{
+								VpcId:               aws.String(subnetsVPCID),
+								SubnetId:            aws.String("subnet-2"),
+								AvailabilityZone:    aws.String("us-east-1a"),
+								CidrBlock:           aws.String("10.0.20.0/24"),
+								MapPublicIpOnLaunch: aws.Bool(false),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("public"),
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil).Times(2)
+
+				m.ModifySubnetAttribute(gomock.Eq(&ec2.ModifySubnetAttributeInput{
+					MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
+						Value: aws.Bool(true),
+					},
+					SubnetId: aws.String("subnet-2"),
+				})).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -726,3 +1171,65 @@ func TestDiscoverSubnets(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSubnetTagParams(t *testing.T) {
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	s := NewService(clusterScope)
+
+	testCases := []struct {
+		name          string
+		public        bool
+		pod           bool
+		role          string
+		elbTag        string
+		cloudProvider bool
+	}{
+		{
+			name:          "public subnet",
+			public:        true,
+			role:          infrav1.PublicRoleTagValue,
+			elbTag:        externalLoadBalancerTag,
+			cloudProvider: true,
+		},
+		{
+			name:          "private subnet",
+			role:          infrav1.PrivateRoleTagValue,
+			elbTag:        internalLoadBalancerTag,
+			cloudProvider: true,
+		},
+		{
+			name: "pod subnet",
+			pod:  true,
+			role: infrav1.PodRoleTagValue,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tags := infrav1.Build(s.getSubnetTagParams("subnet-1", tc.public, tc.pod, infrav1.Tags{"custom": "value"}))
+
+			if role := tags.GetRole(); role != tc.role {
+				t.Errorf("Expected role %q, got %q", tc.role, role)
+			}
+			for _, elbTag := range []string{externalLoadBalancerTag, internalLoadBalancerTag} {
+				if _, ok := tags[elbTag]; ok != (elbTag == tc.elbTag) {
+					t.Errorf("Unexpected presence of tag %q: %v", elbTag, ok)
+				}
+			}
+			if _, ok := tags[infrav1.NameKubernetesAWSCloudProviderPrefix+"test-cluster"]; ok != tc.cloudProvider {
+				t.Errorf("Expected cloud provider tag: %v, got %v", tc.cloudProvider, ok)
+			}
+			if tags["custom"] != "value" {
+				t.Errorf("Expected the manual tags to be kept, got %v", tags)
+			}
+		})
+	}
+}
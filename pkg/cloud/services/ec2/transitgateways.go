@@ -0,0 +1,313 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/wait"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+func (s *Service) reconcileTransitGateway() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.V(4).Info("Skipping transit gateway reconcile in unmanaged mode")
+		return nil
+	}
+
+	tgw := s.scope.TransitGateway()
+	if tgw == nil {
+		return nil
+	}
+
+	s.scope.V(2).Info("Reconciling transit gateway attachment", "transit-gateway-id", tgw.ID)
+
+	attachment, err := s.describeTransitGatewayAttachment(tgw.ID)
+	if awserrors.IsNotFound(err) {
+		attachment, err = s.createTransitGatewayAttachment(tgw)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.waitTransitGatewayAttachmentAvailable(attachment); err != nil {
+		return err
+	}
+
+	return s.reconcileTransitGatewayRoutes(tgw)
+}
+
+func (s *Service) deleteTransitGateway() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.V(4).Info("Skipping transit gateway deletion in unmanaged mode")
+		return nil
+	}
+
+	tgw := s.scope.TransitGateway()
+	if tgw == nil {
+		return nil
+	}
+
+	attachment, err := s.describeTransitGatewayAttachment(tgw.ID)
+	if awserrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	id := aws.StringValue(attachment.TransitGatewayAttachmentId)
+	if aws.StringValue(attachment.State) != ec2.TransitGatewayAttachmentStateDeleting {
+		if _, err := s.scope.EC2.DeleteTransitGatewayVpcAttachment(&ec2.DeleteTransitGatewayVpcAttachmentInput{
+			TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+		}); err != nil {
+			record.Warnf(s.scope.AWSCluster, "FailedDeleteTransitGatewayAttachment", "Failed to delete Transit Gateway Attachment %q: %v", id, err)
+			return errors.Wrapf(err, "failed to delete transit gateway attachment %q", id)
+		}
+		record.Eventf(s.scope.AWSCluster, "SuccessfulDeleteTransitGatewayAttachment", "Deleted Transit Gateway Attachment %q", id)
+	}
+
+	// The network interfaces of the attachment prevent the deletion of the
+	// subnets until it is gone.
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		_, err := s.describeTransitGatewayAttachment(tgw.ID)
+		if awserrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}); err != nil {
+		return errors.Wrapf(err, "failed to wait for transit gateway attachment deletion %q", id)
+	}
+
+	s.scope.Info("Deleted transit gateway attachment", "transit-gateway-attachment-id", id, "transit-gateway-id", tgw.ID)
+	return nil
+}
+
+// describeTransitGatewayAttachment returns the attachment of the VPC to the
+// given transit gateway, if it is not deleted yet.
+func (s *Service) describeTransitGatewayAttachment(transitGatewayID string) (*ec2.TransitGatewayVpcAttachment, error) {
+	out, err := s.scope.EC2.DescribeTransitGatewayVpcAttachments(&ec2.DescribeTransitGatewayVpcAttachmentsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.TransitGateway(transitGatewayID),
+			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.TransitGatewayAttachmentStates(
+				ec2.TransitGatewayAttachmentStatePending,
+				ec2.TransitGatewayAttachmentStatePendingAcceptance,
+				ec2.TransitGatewayAttachmentStateAvailable,
+				ec2.TransitGatewayAttachmentStateModifying,
+				ec2.TransitGatewayAttachmentStateDeleting,
+			),
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe transit gateway attachments in vpc %q", s.scope.VPC().ID)
+	}
+
+	if len(out.TransitGatewayVpcAttachments) == 0 {
+		return nil, awserrors.NewNotFound(errors.Errorf("no transit gateway attachment found for %q in vpc %q", transitGatewayID, s.scope.VPC().ID))
+	}
+
+	return out.TransitGatewayVpcAttachments[0], nil
+}
+
+func (s *Service) createTransitGatewayAttachment(tgw *infrav1.TransitGatewaySpec) (*ec2.TransitGatewayVpcAttachment, error) {
+	subnetIDs := tgw.SubnetIDs
+	if len(subnetIDs) == 0 {
+		subnetIDs = s.getPrivateSubnetIDsByZone(nil)
+	}
+	if len(subnetIDs) == 0 {
+		return nil, errors.Errorf("failed to create transit gateway attachment for %q: no private subnets available", tgw.ID)
+	}
+
+	out, err := s.scope.EC2.CreateTransitGatewayVpcAttachment(&ec2.CreateTransitGatewayVpcAttachmentInput{
+		TransitGatewayId: aws.String(tgw.ID),
+		VpcId:            aws.String(s.scope.VPC().ID),
+		SubnetIds:        aws.StringSlice(subnetIDs),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeTransitGatewayAttachment),
+				Tags:         converters.MapToTags(infrav1.Build(s.getTransitGatewayAttachmentTagParams())),
+			},
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedCreateTransitGatewayAttachment", "Failed to create new managed Transit Gateway Attachment to %q: %v", tgw.ID, err)
+		return nil, errors.Wrapf(err, "failed to create transit gateway attachment for %q", tgw.ID)
+	}
+
+	id := aws.StringValue(out.TransitGatewayVpcAttachment.TransitGatewayAttachmentId)
+	record.Eventf(s.scope.AWSCluster, "SuccessfulCreateTransitGatewayAttachment", "Created new managed Transit Gateway Attachment %q to %q", id, tgw.ID)
+	s.scope.Info("Created transit gateway attachment", "transit-gateway-attachment-id", id, "transit-gateway-id", tgw.ID)
+
+	return out.TransitGatewayVpcAttachment, nil
+}
+
+// waitTransitGatewayAttachmentAvailable waits for the attachment to become
+// available, routes to the transit gateway can't be created before that.
+func (s *Service) waitTransitGatewayAttachmentAvailable(attachment *ec2.TransitGatewayVpcAttachment) error {
+	id := aws.StringValue(attachment.TransitGatewayAttachmentId)
+	if aws.StringValue(attachment.State) == ec2.TransitGatewayAttachmentStateAvailable {
+		return nil
+	}
+
+	s.scope.V(2).Info("Waiting for transit gateway attachment to become available", "transit-gateway-attachment-id", id)
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		out, err := s.scope.EC2.DescribeTransitGatewayVpcAttachments(&ec2.DescribeTransitGatewayVpcAttachmentsInput{
+			TransitGatewayAttachmentIds: []*string{attachment.TransitGatewayAttachmentId},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		if len(out.TransitGatewayVpcAttachments) == 0 {
+			return false, errors.Errorf("no transit gateway attachment returned for id %q", id)
+		}
+
+		switch state := aws.StringValue(out.TransitGatewayVpcAttachments[0].State); state {
+		case ec2.TransitGatewayAttachmentStateAvailable:
+			return true, nil
+		case ec2.TransitGatewayAttachmentStatePending, ec2.TransitGatewayAttachmentStateModifying:
+			return false, nil
+		case ec2.TransitGatewayAttachmentStatePendingAcceptance:
+			return false, errors.New("waiting for the transit gateway owner to accept the attachment")
+		default:
+			return false, errors.Errorf("in %s state", state)
+		}
+	}); err != nil {
+		return errors.Wrapf(err, "failed to wait for transit gateway attachment %q to become available", id)
+	}
+
+	return nil
+}
+
+// reconcileTransitGatewayRoutes makes sure the route tables of the cluster
+// subnets route the transit gateway CIDR blocks to the transit gateway, and
+// removes the routes to the transit gateway that are no longer wanted.
+func (s *Service) reconcileTransitGatewayRoutes(tgw *infrav1.TransitGatewaySpec) error {
+	subnetRouteMap, err := s.describeVpcRouteTablesBySubnet()
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]struct{}, len(tgw.Routes))
+	for _, cidrBlock := range tgw.Routes {
+		wanted[cidrBlock] = struct{}{}
+	}
+
+	reconciled := make(map[string]struct{})
+	for _, sn := range s.scope.Subnets() {
+		rt, ok := subnetRouteMap[sn.ID]
+		if !ok {
+			continue
+		}
+
+		id := aws.StringValue(rt.RouteTableId)
+		if _, ok := reconciled[id]; ok {
+			continue
+		}
+		reconciled[id] = struct{}{}
+
+		current := make(map[string]struct{})
+		for _, route := range rt.Routes {
+			if aws.StringValue(route.TransitGatewayId) != tgw.ID {
+				continue
+			}
+
+			cidrBlock := aws.StringValue(route.DestinationCidrBlock)
+			if route.DestinationIpv6CidrBlock != nil {
+				cidrBlock = aws.StringValue(route.DestinationIpv6CidrBlock)
+			}
+			if _, ok := wanted[cidrBlock]; ok {
+				current[cidrBlock] = struct{}{}
+				continue
+			}
+
+			if err := s.deleteTransitGatewayRoute(id, cidrBlock); err != nil {
+				return err
+			}
+		}
+
+		for _, cidrBlock := range tgw.Routes {
+			if _, ok := current[cidrBlock]; ok {
+				continue
+			}
+			if err := s.createTransitGatewayRoute(id, tgw.ID, cidrBlock); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) createTransitGatewayRoute(routeTableID, transitGatewayID, cidrBlock string) error {
+	input := &ec2.CreateRouteInput{
+		RouteTableId:     aws.String(routeTableID),
+		TransitGatewayId: aws.String(transitGatewayID),
+	}
+	if isIPv6CidrBlock(cidrBlock) {
+		input.DestinationIpv6CidrBlock = aws.String(cidrBlock)
+	} else {
+		input.DestinationCidrBlock = aws.String(cidrBlock)
+	}
+
+	if _, err := s.scope.EC2.CreateRoute(input); err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedCreateRoute", "Failed to create route to Transit Gateway %q for %q in RouteTable %q: %v", transitGatewayID, cidrBlock, routeTableID, err)
+		return errors.Wrapf(err, "failed to create route to transit gateway %q for %q in route table %q", transitGatewayID, cidrBlock, routeTableID)
+	}
+
+	record.Eventf(s.scope.AWSCluster, "SuccessfulCreateRoute", "Created route to Transit Gateway %q for %q in RouteTable %q", transitGatewayID, cidrBlock, routeTableID)
+	return nil
+}
+
+func (s *Service) deleteTransitGatewayRoute(routeTableID, cidrBlock string) error {
+	input := &ec2.DeleteRouteInput{
+		RouteTableId: aws.String(routeTableID),
+	}
+	if isIPv6CidrBlock(cidrBlock) {
+		input.DestinationIpv6CidrBlock = aws.String(cidrBlock)
+	} else {
+		input.DestinationCidrBlock = aws.String(cidrBlock)
+	}
+
+	if _, err := s.scope.EC2.DeleteRoute(input); err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedDeleteRoute", "Failed to delete route for %q in RouteTable %q: %v", cidrBlock, routeTableID, err)
+		return errors.Wrapf(err, "failed to delete route for %q in route table %q", cidrBlock, routeTableID)
+	}
+
+	record.Eventf(s.scope.AWSCluster, "SuccessfulDeleteRoute", "Deleted route for %q in RouteTable %q", cidrBlock, routeTableID)
+	return nil
+}
+
+func (s *Service) getTransitGatewayAttachmentTagParams() infrav1.BuildParams {
+	name := fmt.Sprintf("%s-tgw-attachment", s.scope.Name())
+
+	return infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(infrav1.CommonRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface" //nolint
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb/mock_elbiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestReconcileTransitGateway(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	subnets := infrav1.Subnets{
+		{ID: "subnet-public", AvailabilityZone: "us-east-1a", IsPublic: true},
+		{ID: "subnet-private-1a", AvailabilityZone: "us-east-1a"},
+		{ID: "subnet-private-1b", AvailabilityZone: "us-east-1b"},
+	}
+	describeAttachment := func(m *mock_ec2iface.MockEC2APIMockRecorder, attachments ...*ec2.TransitGatewayVpcAttachment) {
+		m.DescribeTransitGatewayVpcAttachments(gomock.AssignableToTypeOf(&ec2.DescribeTransitGatewayVpcAttachmentsInput{})).
+			Return(&ec2.DescribeTransitGatewayVpcAttachmentsOutput{TransitGatewayVpcAttachments: attachments}, nil)
+	}
+
+	testCases := []struct {
+		name      string
+		tgw       *infrav1.TransitGatewaySpec
+		expect    func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectErr bool
+	}{
+		{
+			name:   "no transit gateway",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "creates attachment and routes",
+			tgw:  &infrav1.TransitGatewaySpec{ID: "tgw-1", Routes: []string{"10.100.0.0/16"}},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeAttachment(m)
+				m.CreateTransitGatewayVpcAttachment(gomock.AssignableToTypeOf(&ec2.CreateTransitGatewayVpcAttachmentInput{})).
+					DoAndReturn(func(input *ec2.CreateTransitGatewayVpcAttachmentInput) (*ec2.CreateTransitGatewayVpcAttachmentOutput, error) {
+						if aws.StringValue(input.TransitGatewayId) != "tgw-1" || aws.StringValue(input.VpcId) != "vpc-tgw" {
+							t.Fatalf("unexpected attachment input: %v", input)
+						}
+						if !reflect.DeepEqual(aws.StringValueSlice(input.SubnetIds), []string{"subnet-private-1a", "subnet-private-1b"}) {
+							t.Fatalf("unexpected attachment subnets: %v", aws.StringValueSlice(input.SubnetIds))
+						}
+						return &ec2.CreateTransitGatewayVpcAttachmentOutput{
+							TransitGatewayVpcAttachment: &ec2.TransitGatewayVpcAttachment{
+								TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+								State:                      aws.String(ec2.TransitGatewayAttachmentStatePending),
+							},
+						}, nil
+					})
+				m.DescribeTransitGatewayVpcAttachments(gomock.Eq(&ec2.DescribeTransitGatewayVpcAttachmentsInput{
+					TransitGatewayAttachmentIds: aws.StringSlice([]string{"tgw-attach-1"}),
+				})).
+					Return(&ec2.DescribeTransitGatewayVpcAttachmentsOutput{
+						TransitGatewayVpcAttachments: []*ec2.TransitGatewayVpcAttachment{
+							{
+								TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+								State:                      aws.String(ec2.TransitGatewayAttachmentStateAvailable),
+							},
+						},
+					}, nil)
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("rtb-1"),
+								Associations: []*ec2.RouteTableAssociation{
+									{SubnetId: aws.String("subnet-private-1a")},
+									{SubnetId: aws.String("subnet-private-1b")},
+								},
+							},
+						},
+					}, nil)
+				m.CreateRoute(gomock.Eq(&ec2.CreateRouteInput{
+					RouteTableId:         aws.String("rtb-1"),
+					DestinationCidrBlock: aws.String("10.100.0.0/16"),
+					TransitGatewayId:     aws.String("tgw-1"),
+				})).
+					Return(&ec2.CreateRouteOutput{}, nil)
+			},
+		},
+		{
+			name: "existing attachment, updates routes",
+			tgw:  &infrav1.TransitGatewaySpec{ID: "tgw-1", Routes: []string{"10.100.0.0/16", "2001:db8::/32"}},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeAttachment(m, &ec2.TransitGatewayVpcAttachment{
+					TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+					State:                      aws.String(ec2.TransitGatewayAttachmentStateAvailable),
+				})
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("rtb-1"),
+								Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-public")}},
+								Routes: []*ec2.Route{
+									{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")},
+									{DestinationCidrBlock: aws.String("10.100.0.0/16"), TransitGatewayId: aws.String("tgw-1")},
+									{DestinationCidrBlock: aws.String("10.200.0.0/16"), TransitGatewayId: aws.String("tgw-1")},
+								},
+							},
+						},
+					}, nil)
+				m.DeleteRoute(gomock.Eq(&ec2.DeleteRouteInput{
+					RouteTableId:         aws.String("rtb-1"),
+					DestinationCidrBlock: aws.String("10.200.0.0/16"),
+				})).
+					Return(&ec2.DeleteRouteOutput{}, nil)
+				m.CreateRoute(gomock.Eq(&ec2.CreateRouteInput{
+					RouteTableId:             aws.String("rtb-1"),
+					DestinationIpv6CidrBlock: aws.String("2001:db8::/32"),
+					TransitGatewayId:         aws.String("tgw-1"),
+				})).
+					Return(&ec2.CreateRouteOutput{}, nil)
+			},
+		},
+		{
+			name: "attachment pending acceptance",
+			tgw:  &infrav1.TransitGatewaySpec{ID: "tgw-1", SubnetIDs: []string{"subnet-private-1a"}},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeAttachment(m, &ec2.TransitGatewayVpcAttachment{
+					TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+					State:                      aws.String(ec2.TransitGatewayAttachmentStatePendingAcceptance),
+				})
+				m.DescribeTransitGatewayVpcAttachments(gomock.Eq(&ec2.DescribeTransitGatewayVpcAttachmentsInput{
+					TransitGatewayAttachmentIds: aws.StringSlice([]string{"tgw-attach-1"}),
+				})).
+					Return(&ec2.DescribeTransitGatewayVpcAttachmentsOutput{
+						TransitGatewayVpcAttachments: []*ec2.TransitGatewayVpcAttachment{
+							{
+								TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+								State:                      aws.String(ec2.TransitGatewayAttachmentStatePendingAcceptance),
+							},
+						},
+					}, nil)
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: scope.AWSClients{
+					EC2: ec2Mock,
+					ELB: elbMock,
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC: infrav1.VPCSpec{
+								ID:   "vpc-tgw",
+								Tags: infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+							},
+							Subnets:        subnets.DeepCopy(),
+							TransitGateway: tc.tgw,
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			err = s.reconcileTransitGateway()
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error but didn't get one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -53,6 +53,21 @@ func (s *Service) reconcileVPC() error {
 		return errors.Wrap(err, "failed to describe VPCs")
 	}
 
+	// Keep the options that only exist in the spec, the VPC is copied back into it below.
+	vpc.AvailabilityZoneUsageLimit = s.scope.VPC().AvailabilityZoneUsageLimit
+	vpc.Endpoints = s.scope.VPC().Endpoints
+
+	if s.scope.VPC().IsIPv6Enabled() {
+		if vpc.IPv6 == nil {
+			record.Warnf(s.scope.AWSCluster, "FailedIPv6VPC", "VPC %q has no IPv6 CIDR block associated", vpc.ID)
+			return errors.Errorf("vpc %q has no IPv6 CIDR block associated", vpc.ID)
+		}
+		vpc.IPv6.EgressOnlyInternetGatewayID = s.scope.VPC().IPv6.EgressOnlyInternetGatewayID
+	} else {
+		// The cluster network is only dual-stack when IPv6 is requested.
+		vpc.IPv6 = nil
+	}
+
 	if vpc.IsUnmanaged(s.scope.Name()) {
 		vpc.DeepCopyInto(s.scope.VPC())
 		s.scope.V(2).Info("Working on unmanaged VPC", "vpc-id", vpc.ID)
@@ -83,6 +98,10 @@ func (s *Service) reconcileVPC() error {
 		return errors.Wrapf(err, "failed to to set vpc attributes for %q", vpc.ID)
 	}
 
+	if err := s.reconcileVPCSecondaryCidrBlocks(vpc); err != nil {
+		return err
+	}
+
 	vpc.DeepCopyInto(s.scope.VPC())
 	s.scope.V(2).Info("Working on managed VPC", "vpc-id", vpc.ID)
 	return nil
@@ -145,6 +164,106 @@ func (s *Service) ensureManagedVPCAttributes(vpc *infrav1.VPCSpec) error {
 	return nil
 }
 
+// reconcileVPCSecondaryCidrBlocks associates the secondary CIDR blocks of the
+// spec with the managed VPC, waiting for them to be usable by the subnets, and
+// disassociates the ones that were removed from the spec.
+func (s *Service) reconcileVPCSecondaryCidrBlocks(vpc *infrav1.VPCSpec) error {
+	wanted := s.scope.VPC().SecondaryCidrBlocks
+	toAssociate := difference(wanted, vpc.SecondaryCidrBlocks)
+	toDisassociate := difference(vpc.SecondaryCidrBlocks, wanted)
+
+	if len(toDisassociate) > 0 {
+		removed := make(map[string]struct{}, len(toDisassociate))
+		for _, cidrBlock := range toDisassociate {
+			removed[cidrBlock] = struct{}{}
+		}
+
+		out, err := s.scope.EC2.DescribeVpcs(&ec2.DescribeVpcsInput{VpcIds: []*string{aws.String(vpc.ID)}})
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe vpc %q", vpc.ID)
+		}
+		if len(out.Vpcs) == 0 {
+			return errors.Errorf("failed to describe vpc %q", vpc.ID)
+		}
+		for _, association := range getVPCSecondaryCidrBlockAssociations(out.Vpcs[0]) {
+			if _, ok := removed[aws.StringValue(association.CidrBlock)]; !ok {
+				continue
+			}
+			if _, err := s.scope.EC2.DisassociateVpcCidrBlock(&ec2.DisassociateVpcCidrBlockInput{
+				AssociationId: association.AssociationId,
+			}); err != nil {
+				record.Warnf(s.scope.AWSCluster, "FailedDisassociateVPCCidrBlock", "Failed to disassociate CIDR block %q from managed VPC %q: %v", aws.StringValue(association.CidrBlock), vpc.ID, err)
+				return errors.Wrapf(err, "failed to disassociate cidr block %q from vpc %q", aws.StringValue(association.CidrBlock), vpc.ID)
+			}
+			record.Eventf(s.scope.AWSCluster, "SuccessfulDisassociateVPCCidrBlock", "Disassociated CIDR block %q from managed VPC %q", aws.StringValue(association.CidrBlock), vpc.ID)
+		}
+	}
+
+	for _, cidrBlock := range toAssociate {
+		if _, err := s.scope.EC2.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{
+			VpcId:     aws.String(vpc.ID),
+			CidrBlock: aws.String(cidrBlock),
+		}); err != nil {
+			record.Warnf(s.scope.AWSCluster, "FailedAssociateVPCCidrBlock", "Failed to associate CIDR block %q with managed VPC %q: %v", cidrBlock, vpc.ID, err)
+			return errors.Wrapf(err, "failed to associate cidr block %q with vpc %q", cidrBlock, vpc.ID)
+		}
+		if err := s.waitForVPCSecondaryCidrBlock(vpc.ID, cidrBlock); err != nil {
+			return err
+		}
+		record.Eventf(s.scope.AWSCluster, "SuccessfulAssociateVPCCidrBlock", "Associated CIDR block %q with managed VPC %q", cidrBlock, vpc.ID)
+	}
+
+	vpc.SecondaryCidrBlocks = wanted
+	return nil
+}
+
+// waitForVPCSecondaryCidrBlock waits for a secondary CIDR block to be associated
+// with the VPC, subnets cannot be created in it before.
+func (s *Service) waitForVPCSecondaryCidrBlock(vpcID, cidrBlock string) error {
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		out, err := s.scope.EC2.DescribeVpcs(&ec2.DescribeVpcsInput{VpcIds: []*string{aws.String(vpcID)}})
+		if err != nil {
+			return false, err
+		}
+		if len(out.Vpcs) == 0 {
+			return false, nil
+		}
+		for _, association := range getVPCSecondaryCidrBlockAssociations(out.Vpcs[0]) {
+			if aws.StringValue(association.CidrBlock) != cidrBlock {
+				continue
+			}
+			switch state := aws.StringValue(association.CidrBlockState.State); state {
+			case ec2.VpcCidrBlockStateCodeAssociated:
+				return true, nil
+			case ec2.VpcCidrBlockStateCodeFailed:
+				return false, errors.Errorf("cidr block %q is in state %q: %s", cidrBlock, state, aws.StringValue(association.CidrBlockState.StatusMessage))
+			}
+		}
+		return false, nil
+	}, awserrors.VPCNotFound); err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedAssociateVPCCidrBlock", "Failed to associate CIDR block %q with managed VPC %q: %v", cidrBlock, vpcID, err)
+		return errors.Wrapf(err, "failed to wait for cidr block %q of vpc %q", cidrBlock, vpcID)
+	}
+
+	return nil
+}
+
+// getVPCSecondaryCidrBlockAssociations returns the associations of the IPv4 CIDR
+// blocks of the VPC other than its primary block, that are or are being associated.
+func getVPCSecondaryCidrBlockAssociations(vpc *ec2.Vpc) []*ec2.VpcCidrBlockAssociation {
+	var associations []*ec2.VpcCidrBlockAssociation
+	for _, association := range vpc.CidrBlockAssociationSet {
+		if aws.StringValue(association.CidrBlock) == aws.StringValue(vpc.CidrBlock) || association.CidrBlockState == nil {
+			continue
+		}
+		switch aws.StringValue(association.CidrBlockState.State) {
+		case ec2.VpcCidrBlockStateCodeAssociating, ec2.VpcCidrBlockStateCodeAssociated, ec2.VpcCidrBlockStateCodeFailing, ec2.VpcCidrBlockStateCodeFailed:
+			associations = append(associations, association)
+		}
+	}
+	return associations
+}
+
 func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		return nil, errors.Errorf("cannot create a managed vpc in unmanaged mode")
@@ -158,6 +277,10 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 		CidrBlock: aws.String(s.scope.VPC().CidrBlock),
 	}
 
+	if s.scope.VPC().IsIPv6Enabled() {
+		input.AmazonProvidedIpv6CidrBlock = aws.Bool(true)
+	}
+
 	out, err := s.scope.EC2.CreateVpc(input)
 	if err != nil {
 		record.Warnf(s.scope.AWSCluster, "FailedCreateVPC", "Failed to create new managed VPC: %v", err)
@@ -192,11 +315,53 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 	}
 	record.Eventf(s.scope.AWSCluster, "SuccesfulTagVPC", "Tagged managed VPC %q", *out.Vpc.VpcId)
 
-	return &infrav1.VPCSpec{
+	vpc := &infrav1.VPCSpec{
 		ID:        *out.Vpc.VpcId,
 		CidrBlock: *out.Vpc.CidrBlock,
 		Tags:      infrav1.Build(tagParams),
-	}, nil
+	}
+
+	if s.scope.VPC().IsIPv6Enabled() {
+		ipv6CidrBlock, err := s.waitForVPCIPv6CidrBlock(*out.Vpc.VpcId)
+		if err != nil {
+			record.Warnf(s.scope.AWSCluster, "FailedAssociateVPCIPv6CidrBlock", "Failed to associate an IPv6 CIDR block with managed VPC %q: %v", *out.Vpc.VpcId, err)
+			return nil, err
+		}
+		vpc.IPv6 = &infrav1.IPv6{CidrBlock: ipv6CidrBlock}
+	}
+
+	return vpc, nil
+}
+
+// waitForVPCIPv6CidrBlock waits for the Amazon-provided IPv6 CIDR block
+// requested when the VPC was created to be associated with it.
+func (s *Service) waitForVPCIPv6CidrBlock(vpcID string) (string, error) {
+	var ipv6CidrBlock string
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		out, err := s.scope.EC2.DescribeVpcs(&ec2.DescribeVpcsInput{VpcIds: []*string{aws.String(vpcID)}})
+		if err != nil {
+			return false, err
+		}
+		if len(out.Vpcs) == 0 {
+			return false, nil
+		}
+		ipv6CidrBlock = getVPCIPv6CidrBlock(out.Vpcs[0])
+		return ipv6CidrBlock != "", nil
+	}, awserrors.VPCNotFound); err != nil {
+		return "", errors.Wrapf(err, "failed to wait for IPv6 CIDR block of vpc %q", vpcID)
+	}
+
+	return ipv6CidrBlock, nil
+}
+
+// getVPCIPv6CidrBlock returns the IPv6 CIDR block associated with the VPC, if any.
+func getVPCIPv6CidrBlock(vpc *ec2.Vpc) string {
+	for _, association := range vpc.Ipv6CidrBlockAssociationSet {
+		if association.Ipv6CidrBlockState != nil && aws.StringValue(association.Ipv6CidrBlockState.State) == ec2.VpcCidrBlockStateCodeAssociated {
+			return aws.StringValue(association.Ipv6CidrBlock)
+		}
+	}
+	return ""
 }
 
 func (s *Service) deleteVPC() error {
@@ -270,11 +435,23 @@ func (s *Service) describeVPC() (*infrav1.VPCSpec, error) {
 		return nil, awserrors.NewNotFound(errors.Errorf("could not find available or pending vpc"))
 	}
 
-	return &infrav1.VPCSpec{
+	vpc := &infrav1.VPCSpec{
 		ID:        *out.Vpcs[0].VpcId,
 		CidrBlock: *out.Vpcs[0].CidrBlock,
 		Tags:      converters.TagsToMap(out.Vpcs[0].Tags),
-	}, nil
+	}
+
+	if ipv6CidrBlock := getVPCIPv6CidrBlock(out.Vpcs[0]); ipv6CidrBlock != "" {
+		vpc.IPv6 = &infrav1.IPv6{CidrBlock: ipv6CidrBlock}
+	}
+
+	for _, association := range getVPCSecondaryCidrBlockAssociations(out.Vpcs[0]) {
+		if aws.StringValue(association.CidrBlockState.State) == ec2.VpcCidrBlockStateCodeAssociated {
+			vpc.SecondaryCidrBlocks = append(vpc.SecondaryCidrBlocks, aws.StringValue(association.CidrBlock))
+		}
+	}
+
+	return vpc, nil
 }
 
 func (s *Service) getVPCTagParams(id string) infrav1.BuildParams {
@@ -61,6 +61,39 @@ func TestReconcileVPC(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
+	managedTags := []*ec2.Tag{
+		{
+			Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+			Value: aws.String("common"),
+		},
+		{
+			Key:   aws.String("Name"),
+			Value: aws.String("test-cluster-vpc"),
+		},
+		{
+			Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+			Value: aws.String("owned"),
+		},
+	}
+	describeManagedVPC := &ec2.DescribeVpcsInput{
+		VpcIds: []*string{
+			aws.String("vpc-exists"),
+		},
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("state"),
+				Values: aws.StringSlice([]string{ec2.VpcStatePending, ec2.VpcStateAvailable}),
+			},
+		},
+	}
+	cidrBlockAssociation := func(id, cidrBlock, state string) *ec2.VpcCidrBlockAssociation {
+		return &ec2.VpcCidrBlockAssociation{
+			AssociationId:  aws.String(id),
+			CidrBlock:      aws.String(cidrBlock),
+			CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(state)},
+		}
+	}
+
 	testCases := []struct {
 		name   string
 		input  *infrav1.VPCSpec
@@ -154,6 +187,83 @@ func TestReconcileVPC(t *testing.T) {
 					Return(nil, nil)
 			},
 		},
+		{
+			name:   "managed vpc associates secondary cidr blocks",
+			input:  &infrav1.VPCSpec{ID: "vpc-exists", SecondaryCidrBlocks: []string{"100.64.0.0/16"}},
+			output: &infrav1.VPCSpec{ID: "vpc-exists", CidrBlock: "10.0.0.0/16", SecondaryCidrBlocks: []string{"100.64.0.0/16"}},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeVpcs(gomock.Eq(describeManagedVPC)).
+					Return(&ec2.DescribeVpcsOutput{
+						Vpcs: []*ec2.Vpc{
+							{
+								State:     aws.String("available"),
+								VpcId:     aws.String("vpc-exists"),
+								CidrBlock: aws.String("10.0.0.0/16"),
+								CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+									cidrBlockAssociation("vpc-cidr-assoc-1", "10.0.0.0/16", ec2.VpcCidrBlockStateCodeAssociated),
+								},
+								Tags: managedTags,
+							},
+						},
+					}, nil)
+
+				m.DescribeVpcAttribute(gomock.AssignableToTypeOf(&ec2.DescribeVpcAttributeInput{})).
+					DoAndReturn(describeVpcAttributeTrue).AnyTimes()
+
+				m.AssociateVpcCidrBlock(gomock.Eq(&ec2.AssociateVpcCidrBlockInput{
+					VpcId:     aws.String("vpc-exists"),
+					CidrBlock: aws.String("100.64.0.0/16"),
+				})).
+					Return(&ec2.AssociateVpcCidrBlockOutput{}, nil)
+
+				m.DescribeVpcs(gomock.Eq(&ec2.DescribeVpcsInput{VpcIds: []*string{aws.String("vpc-exists")}})).
+					Return(&ec2.DescribeVpcsOutput{
+						Vpcs: []*ec2.Vpc{
+							{
+								VpcId:     aws.String("vpc-exists"),
+								CidrBlock: aws.String("10.0.0.0/16"),
+								CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+									cidrBlockAssociation("vpc-cidr-assoc-1", "10.0.0.0/16", ec2.VpcCidrBlockStateCodeAssociated),
+									cidrBlockAssociation("vpc-cidr-assoc-2", "100.64.0.0/16", ec2.VpcCidrBlockStateCodeAssociated),
+								},
+							},
+						},
+					}, nil)
+			},
+		},
+		{
+			name:   "managed vpc disassociates removed secondary cidr blocks",
+			input:  &infrav1.VPCSpec{ID: "vpc-exists", SecondaryCidrBlocks: []string{"100.64.0.0/16"}},
+			output: &infrav1.VPCSpec{ID: "vpc-exists", CidrBlock: "10.0.0.0/16", SecondaryCidrBlocks: []string{"100.64.0.0/16"}},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				vpc := &ec2.Vpc{
+					State:     aws.String("available"),
+					VpcId:     aws.String("vpc-exists"),
+					CidrBlock: aws.String("10.0.0.0/16"),
+					CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+						cidrBlockAssociation("vpc-cidr-assoc-1", "10.0.0.0/16", ec2.VpcCidrBlockStateCodeAssociated),
+						cidrBlockAssociation("vpc-cidr-assoc-2", "100.64.0.0/16", ec2.VpcCidrBlockStateCodeAssociated),
+						cidrBlockAssociation("vpc-cidr-assoc-3", "100.65.0.0/16", ec2.VpcCidrBlockStateCodeAssociated),
+						cidrBlockAssociation("vpc-cidr-assoc-4", "100.66.0.0/16", ec2.VpcCidrBlockStateCodeDisassociated),
+					},
+					Tags: managedTags,
+				}
+
+				m.DescribeVpcs(gomock.Eq(describeManagedVPC)).
+					Return(&ec2.DescribeVpcsOutput{Vpcs: []*ec2.Vpc{vpc}}, nil)
+
+				m.DescribeVpcAttribute(gomock.AssignableToTypeOf(&ec2.DescribeVpcAttributeInput{})).
+					DoAndReturn(describeVpcAttributeTrue).AnyTimes()
+
+				m.DescribeVpcs(gomock.Eq(&ec2.DescribeVpcsInput{VpcIds: []*string{aws.String("vpc-exists")}})).
+					Return(&ec2.DescribeVpcsOutput{Vpcs: []*ec2.Vpc{vpc}}, nil)
+
+				m.DisassociateVpcCidrBlock(gomock.Eq(&ec2.DisassociateVpcCidrBlockInput{
+					AssociationId: aws.String("vpc-cidr-assoc-3"),
+				})).
+					Return(&ec2.DisassociateVpcCidrBlockOutput{}, nil)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
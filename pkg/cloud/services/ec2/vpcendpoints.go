@@ -0,0 +1,341 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/wait"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+const (
+	// VPC endpoint states, as returned by the EC2 API.
+	vpcEndpointStateDeleting = "deleting"
+	vpcEndpointStateDeleted  = "deleted"
+
+	// vpcEndpointResourceType is the resource type used when tagging VPC endpoints.
+	vpcEndpointResourceType = "vpc-endpoint"
+)
+
+func (s *Service) reconcileVPCEndpoints() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.V(4).Info("Skipping VPC endpoints reconcile in unmanaged mode")
+		return nil
+	}
+
+	s.scope.V(2).Info("Reconciling VPC endpoints")
+
+	existing, err := s.describeVPCEndpoints()
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]struct{}, len(s.scope.VPC().Endpoints))
+	for _, spec := range s.scope.VPC().Endpoints {
+		serviceName := s.getVPCEndpointServiceName(spec.ServiceName)
+		wanted[serviceName] = struct{}{}
+
+		if ep, ok := existing[serviceName]; ok {
+			if err := s.updateVPCEndpoint(ep); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := s.createVPCEndpoint(serviceName, getVPCEndpointType(spec)); err != nil {
+			return err
+		}
+	}
+
+	// Delete the endpoints that have been removed from the spec.
+	for serviceName, ep := range existing {
+		if _, ok := wanted[serviceName]; ok {
+			continue
+		}
+		if err := s.deleteVPCEndpoint(ep); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deleteVPCEndpoints() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.V(4).Info("Skipping VPC endpoints deletion in unmanaged mode")
+		return nil
+	}
+
+	existing, err := s.describeVPCEndpoints()
+	if err != nil {
+		return err
+	}
+
+	for _, ep := range existing {
+		if err := s.deleteVPCEndpoint(ep); err != nil {
+			return err
+		}
+	}
+
+	if len(existing) == 0 {
+		return nil
+	}
+
+	// The network interfaces of the interface endpoints prevent the deletion of
+	// the security groups and subnets until the endpoints are gone.
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		remaining, err := s.describeVPCEndpoints()
+		if err != nil {
+			return false, err
+		}
+		return len(remaining) == 0, nil
+	}); err != nil {
+		return errors.Wrapf(err, "failed to wait for VPC endpoints deletion in vpc %q", s.scope.VPC().ID)
+	}
+
+	return nil
+}
+
+// hasInterfaceVPCEndpoints returns true if the managed VPC of the cluster has
+// interface endpoints, which need a security group of their own.
+func (s *Service) hasInterfaceVPCEndpoints() bool {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		return false
+	}
+	for _, spec := range s.scope.VPC().Endpoints {
+		if getVPCEndpointType(spec) == infrav1.VPCEndpointTypeInterface {
+			return true
+		}
+	}
+	return false
+}
+
+// describeVPCEndpoints returns the VPC endpoints of the cluster that are not
+// being deleted, by service name.
+func (s *Service) describeVPCEndpoints() (map[string]*ec2.VpcEndpoint, error) {
+	input := &ec2.DescribeVpcEndpointsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.ClusterOwned(s.scope.Name()),
+		},
+	}
+
+	endpoints := make(map[string]*ec2.VpcEndpoint)
+	err := s.scope.EC2.DescribeVpcEndpointsPages(input, func(page *ec2.DescribeVpcEndpointsOutput, lastPage bool) bool {
+		for _, ep := range page.VpcEndpoints {
+			state := aws.StringValue(ep.State)
+			if strings.EqualFold(state, vpcEndpointStateDeleting) || strings.EqualFold(state, vpcEndpointStateDeleted) {
+				continue
+			}
+			endpoints[aws.StringValue(ep.ServiceName)] = ep
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe VPC endpoints in vpc %q", s.scope.VPC().ID)
+	}
+
+	return endpoints, nil
+}
+
+func (s *Service) createVPCEndpoint(serviceName string, endpointType infrav1.VPCEndpointType) error {
+	input := &ec2.CreateVpcEndpointInput{
+		VpcId:           aws.String(s.scope.VPC().ID),
+		ServiceName:     aws.String(serviceName),
+		VpcEndpointType: aws.String(string(endpointType)),
+		// Endpoints are only found by their tags, tag them at creation so
+		// that they can't be left behind untagged.
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(vpcEndpointResourceType),
+				Tags:         converters.MapToTags(infrav1.Build(s.getVPCEndpointTagParams("", serviceName))),
+			},
+		},
+	}
+
+	if endpointType == infrav1.VPCEndpointTypeGateway {
+		routeTableIDs, err := s.getVPCEndpointRouteTableIDs()
+		if err != nil {
+			return err
+		}
+		input.RouteTableIds = aws.StringSlice(routeTableIDs)
+	} else {
+		sg, ok := s.scope.SecurityGroups()[infrav1.SecurityGroupVPCEndpoint]
+		if !ok {
+			return errors.Errorf("failed to create VPC endpoint for %q: %s security group not available", serviceName, infrav1.SecurityGroupVPCEndpoint)
+		}
+		input.SubnetIds = aws.StringSlice(s.getPrivateSubnetIDsByZone(nil))
+		input.SecurityGroupIds = aws.StringSlice([]string{sg.ID})
+		input.PrivateDnsEnabled = aws.Bool(true)
+	}
+
+	out, err := s.scope.EC2.CreateVpcEndpoint(input)
+	if err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedCreateVPCEndpoint", "Failed to create new managed VPC endpoint for %q: %v", serviceName, err)
+		return errors.Wrapf(err, "failed to create VPC endpoint for %q", serviceName)
+	}
+	id := aws.StringValue(out.VpcEndpoint.VpcEndpointId)
+	record.Eventf(s.scope.AWSCluster, "SuccessfulCreateVPCEndpoint", "Created new managed VPC endpoint %q for %q", id, serviceName)
+
+	s.scope.Info("Created VPC endpoint", "vpc-endpoint-id", id, "service-name", serviceName)
+	return nil
+}
+
+// updateVPCEndpoint attaches the endpoint to the subnets added to the cluster
+// since its creation, and makes sure its tags are up to date.
+func (s *Service) updateVPCEndpoint(ep *ec2.VpcEndpoint) error {
+	id := aws.StringValue(ep.VpcEndpointId)
+	input := &ec2.ModifyVpcEndpointInput{VpcEndpointId: ep.VpcEndpointId}
+
+	if strings.EqualFold(aws.StringValue(ep.VpcEndpointType), string(infrav1.VPCEndpointTypeGateway)) {
+		routeTableIDs, err := s.getVPCEndpointRouteTableIDs()
+		if err != nil {
+			return err
+		}
+		input.AddRouteTableIds = aws.StringSlice(difference(routeTableIDs, aws.StringValueSlice(ep.RouteTableIds)))
+	} else {
+		input.AddSubnetIds = aws.StringSlice(s.getPrivateSubnetIDsByZone(aws.StringValueSlice(ep.SubnetIds)))
+	}
+
+	if len(input.AddRouteTableIds) > 0 || len(input.AddSubnetIds) > 0 {
+		if _, err := s.scope.EC2.ModifyVpcEndpoint(input); err != nil {
+			record.Warnf(s.scope.AWSCluster, "FailedModifyVPCEndpoint", "Failed to modify managed VPC endpoint %q: %v", id, err)
+			return errors.Wrapf(err, "failed to modify VPC endpoint %q", id)
+		}
+		record.Eventf(s.scope.AWSCluster, "SuccessfulModifyVPCEndpoint", "Modified managed VPC endpoint %q", id)
+	}
+
+	// Make sure tags are up to date.
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if err := tags.Ensure(converters.TagsToMap(ep.Tags), &tags.ApplyParams{
+			EC2Client:   s.scope.EC2,
+			BuildParams: s.getVPCEndpointTagParams(id, aws.StringValue(ep.ServiceName)),
+		}); err != nil {
+			return false, err
+		}
+		return true, nil
+	}, awserrors.VPCEndpointNotFound); err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedTagVPCEndpoint", "Failed to tag managed VPC endpoint %q: %v", id, err)
+		return errors.Wrapf(err, "failed to tag VPC endpoint %q", id)
+	}
+
+	return nil
+}
+
+func (s *Service) deleteVPCEndpoint(ep *ec2.VpcEndpoint) error {
+	id := aws.StringValue(ep.VpcEndpointId)
+
+	out, err := s.scope.EC2.DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{
+		VpcEndpointIds: []*string{ep.VpcEndpointId},
+	})
+	if err == nil && len(out.Unsuccessful) > 0 && out.Unsuccessful[0].Error != nil {
+		err = errors.New(aws.StringValue(out.Unsuccessful[0].Error.Message))
+	}
+	if err != nil {
+		record.Warnf(s.scope.AWSCluster, "FailedDeleteVPCEndpoint", "Failed to delete managed VPC endpoint %q: %v", id, err)
+		return errors.Wrapf(err, "failed to delete VPC endpoint %q", id)
+	}
+
+	record.Eventf(s.scope.AWSCluster, "SuccessfulDeleteVPCEndpoint", "Deleted managed VPC endpoint %q", id)
+	s.scope.Info("Deleted VPC endpoint", "vpc-endpoint-id", id, "service-name", aws.StringValue(ep.ServiceName))
+	return nil
+}
+
+// getVPCEndpointRouteTableIDs returns the route tables of the cluster subnets.
+func (s *Service) getVPCEndpointRouteTableIDs() ([]string, error) {
+	subnetRouteMap, err := s.describeVpcRouteTablesBySubnet()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, sn := range s.scope.Subnets() {
+		if rt, ok := subnetRouteMap[sn.ID]; ok {
+			ids = append(ids, aws.StringValue(rt.RouteTableId))
+		}
+	}
+
+	ids = unique(ids)
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// getVPCEndpointServiceName returns the full name of the service of a VPC
+// endpoint in the cluster region.
+func (s *Service) getVPCEndpointServiceName(serviceName string) string {
+	if strings.Contains(serviceName, ".") {
+		return serviceName
+	}
+	return fmt.Sprintf("com.amazonaws.%s.%s", s.scope.Region(), serviceName)
+}
+
+func (s *Service) getVPCEndpointTagParams(id, serviceName string) infrav1.BuildParams {
+	parts := strings.Split(serviceName, ".")
+	name := fmt.Sprintf("%s-vpce-%s", s.scope.Name(), parts[len(parts)-1])
+
+	return infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		ResourceID:  id,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(infrav1.CommonRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	}
+}
+
+// getVPCEndpointType returns the type of a VPC endpoint, defaulting to gateway
+// endpoints for the services supporting them.
+func getVPCEndpointType(spec infrav1.VPCEndpointSpec) infrav1.VPCEndpointType {
+	if spec.Type != "" {
+		return spec.Type
+	}
+
+	parts := strings.Split(spec.ServiceName, ".")
+	switch parts[len(parts)-1] {
+	case "s3", "dynamodb":
+		return infrav1.VPCEndpointTypeGateway
+	default:
+		return infrav1.VPCEndpointTypeInterface
+	}
+}
+
+// difference returns the values of a that are not in b.
+func difference(a, b []string) []string {
+	in := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		in[v] = struct{}{}
+	}
+
+	var res []string
+	for _, v := range a {
+		if _, ok := in[v]; !ok {
+			res = append(res, v)
+		}
+	}
+	return res
+}
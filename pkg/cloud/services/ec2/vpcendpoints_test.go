@@ -0,0 +1,258 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface" //nolint
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb/mock_elbiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestReconcileVPCEndpoints(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	subnets := infrav1.Subnets{
+		{ID: "subnet-public", AvailabilityZone: "us-east-1a", IsPublic: true},
+		{ID: "subnet-private-1a", AvailabilityZone: "us-east-1a"},
+		{ID: "subnet-private-1b", AvailabilityZone: "us-east-1b"},
+	}
+	routeTables := &ec2.DescribeRouteTablesOutput{
+		RouteTables: []*ec2.RouteTable{
+			{
+				RouteTableId: aws.String("rtb-public"),
+				Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-public")}},
+			},
+			{
+				RouteTableId: aws.String("rtb-private"),
+				Associations: []*ec2.RouteTableAssociation{
+					{SubnetId: aws.String("subnet-private-1a")},
+					{SubnetId: aws.String("subnet-private-1b")},
+				},
+			},
+		},
+	}
+	expectVPCEndpointTags := func(input *ec2.CreateVpcEndpointInput, name string) {
+		if len(input.TagSpecifications) != 1 || aws.StringValue(input.TagSpecifications[0].ResourceType) != "vpc-endpoint" {
+			t.Fatalf("expected the endpoint to be tagged at creation, got %v", input.TagSpecifications)
+		}
+		tags := converters.TagsToMap(input.TagSpecifications[0].Tags)
+		if !tags.HasOwned("test-cluster") || tags["Name"] != name {
+			t.Fatalf("unexpected tags: %v", tags)
+		}
+	}
+	describeEndpoints := func(m *mock_ec2iface.MockEC2APIMockRecorder, endpoints ...*ec2.VpcEndpoint) {
+		m.DescribeVpcEndpointsPages(gomock.AssignableToTypeOf(&ec2.DescribeVpcEndpointsInput{}), gomock.Any()).
+			DoAndReturn(func(_ *ec2.DescribeVpcEndpointsInput, fn func(*ec2.DescribeVpcEndpointsOutput, bool) bool) error {
+				fn(&ec2.DescribeVpcEndpointsOutput{VpcEndpoints: endpoints}, true)
+				return nil
+			})
+	}
+
+	testCases := []struct {
+		name      string
+		vpc       infrav1.VPCSpec
+		expect    func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectErr bool
+	}{
+		{
+			name: "unmanaged vpc",
+			vpc: infrav1.VPCSpec{
+				ID:        "vpc-endpoints",
+				Endpoints: []infrav1.VPCEndpointSpec{{ServiceName: "s3"}},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "creates gateway and interface endpoints",
+			vpc: infrav1.VPCSpec{
+				ID:        "vpc-endpoints",
+				Tags:      infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				Endpoints: []infrav1.VPCEndpointSpec{{ServiceName: "s3"}, {ServiceName: "ec2"}},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeEndpoints(m)
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(routeTables, nil)
+				m.CreateVpcEndpoint(gomock.AssignableToTypeOf(&ec2.CreateVpcEndpointInput{})).
+					Do(func(input *ec2.CreateVpcEndpointInput) {
+						expectVPCEndpointTags(input, "test-cluster-vpce-s3")
+						expected := &ec2.CreateVpcEndpointInput{
+							VpcId:           aws.String("vpc-endpoints"),
+							ServiceName:     aws.String("com.amazonaws.us-east-1.s3"),
+							VpcEndpointType: aws.String(ec2.VpcEndpointTypeGateway),
+							RouteTableIds:   aws.StringSlice([]string{"rtb-private", "rtb-public"}),
+						}
+						got := *input
+						got.TagSpecifications = nil
+						if !reflect.DeepEqual(&got, expected) {
+							t.Fatalf("unexpected input: %v", input)
+						}
+					}).
+					Return(&ec2.CreateVpcEndpointOutput{VpcEndpoint: &ec2.VpcEndpoint{VpcEndpointId: aws.String("vpce-s3")}}, nil)
+				m.CreateVpcEndpoint(gomock.AssignableToTypeOf(&ec2.CreateVpcEndpointInput{})).
+					Do(func(input *ec2.CreateVpcEndpointInput) {
+						expectVPCEndpointTags(input, "test-cluster-vpce-ec2")
+						expected := &ec2.CreateVpcEndpointInput{
+							VpcId:             aws.String("vpc-endpoints"),
+							ServiceName:       aws.String("com.amazonaws.us-east-1.ec2"),
+							VpcEndpointType:   aws.String(ec2.VpcEndpointTypeInterface),
+							SubnetIds:         aws.StringSlice([]string{"subnet-private-1a", "subnet-private-1b"}),
+							SecurityGroupIds:  aws.StringSlice([]string{"sg-vpce"}),
+							PrivateDnsEnabled: aws.Bool(true),
+						}
+						got := *input
+						got.TagSpecifications = nil
+						if !reflect.DeepEqual(&got, expected) {
+							t.Fatalf("unexpected input: %v", input)
+						}
+					}).
+					Return(&ec2.CreateVpcEndpointOutput{VpcEndpoint: &ec2.VpcEndpoint{VpcEndpointId: aws.String("vpce-ec2")}}, nil)
+			},
+		},
+		{
+			name: "updates existing endpoints and deletes removed ones",
+			vpc: infrav1.VPCSpec{
+				ID:        "vpc-endpoints",
+				Tags:      infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				Endpoints: []infrav1.VPCEndpointSpec{{ServiceName: "s3"}, {ServiceName: "ec2"}},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeEndpoints(m,
+					&ec2.VpcEndpoint{
+						VpcEndpointId:   aws.String("vpce-s3"),
+						ServiceName:     aws.String("com.amazonaws.us-east-1.s3"),
+						VpcEndpointType: aws.String("Gateway"),
+						State:           aws.String("available"),
+						RouteTableIds:   aws.StringSlice([]string{"rtb-public"}),
+					},
+					&ec2.VpcEndpoint{
+						VpcEndpointId:   aws.String("vpce-ec2"),
+						ServiceName:     aws.String("com.amazonaws.us-east-1.ec2"),
+						VpcEndpointType: aws.String("Interface"),
+						State:           aws.String("available"),
+						SubnetIds:       aws.StringSlice([]string{"subnet-private-1a"}),
+					},
+					&ec2.VpcEndpoint{
+						VpcEndpointId:   aws.String("vpce-ecr"),
+						ServiceName:     aws.String("com.amazonaws.us-east-1.ecr.api"),
+						VpcEndpointType: aws.String("Interface"),
+						State:           aws.String("available"),
+					},
+					&ec2.VpcEndpoint{
+						VpcEndpointId: aws.String("vpce-sts"),
+						ServiceName:   aws.String("com.amazonaws.us-east-1.sts"),
+						State:         aws.String("deleting"),
+					},
+				)
+				m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(routeTables, nil)
+				m.ModifyVpcEndpoint(gomock.Eq(&ec2.ModifyVpcEndpointInput{
+					VpcEndpointId:    aws.String("vpce-s3"),
+					AddRouteTableIds: aws.StringSlice([]string{"rtb-private"}),
+				})).
+					Return(&ec2.ModifyVpcEndpointOutput{}, nil)
+				m.ModifyVpcEndpoint(gomock.Eq(&ec2.ModifyVpcEndpointInput{
+					VpcEndpointId: aws.String("vpce-ec2"),
+					AddSubnetIds:  aws.StringSlice([]string{"subnet-private-1b"}),
+				})).
+					Return(&ec2.ModifyVpcEndpointOutput{}, nil)
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil).Times(2)
+				m.DeleteVpcEndpoints(gomock.Eq(&ec2.DeleteVpcEndpointsInput{
+					VpcEndpointIds: aws.StringSlice([]string{"vpce-ecr"}),
+				})).
+					Return(&ec2.DeleteVpcEndpointsOutput{}, nil)
+			},
+		},
+		{
+			name: "fails to create endpoint",
+			vpc: infrav1.VPCSpec{
+				ID:        "vpc-endpoints",
+				Tags:      infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): "owned"},
+				Endpoints: []infrav1.VPCEndpointSpec{{ServiceName: "com.amazonaws.us-east-1.ecr.dkr", Type: infrav1.VPCEndpointTypeInterface}},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeEndpoints(m)
+				m.CreateVpcEndpoint(gomock.AssignableToTypeOf(&ec2.CreateVpcEndpointInput{})).
+					Return(nil, awserr.New("InvalidServiceName", "the service does not exist", nil))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: scope.AWSClients{
+					EC2: ec2Mock,
+					ELB: elbMock,
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						Region: "us-east-1",
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC:     tc.vpc,
+							Subnets: subnets.DeepCopy(),
+						},
+					},
+					Status: infrav1.AWSClusterStatus{
+						Network: infrav1.Network{
+							SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+								infrav1.SecurityGroupVPCEndpoint: {ID: "sg-vpce"},
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			err = s.reconcileVPCEndpoints()
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error but didn't get one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/wait"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/conditions"
 )
 
 // ResourceGroups are filtered by ARN identifier: https://docs.aws.amazon.com/general/latest/gr/aws-arns-and-namespaces.html#arns-syntax
@@ -41,6 +42,29 @@ const elbResourceType = "elasticloadbalancing:loadbalancer"
 func (s *Service) ReconcileLoadbalancers() error {
 	s.scope.V(2).Info("Reconciling load balancers")
 
+	var (
+		apiELB *infrav1.ClassicELB
+		err    error
+	)
+	switch s.scope.ControlPlaneLoadBalancerType() {
+	case infrav1.LoadBalancerTypeNetwork:
+		apiELB, err = s.reconcileAPIServerNLB()
+	default:
+		apiELB, err = s.reconcileAPIServerClassicELB()
+	}
+	if err != nil {
+		conditions.MarkFalse(s.scope.AWSCluster, infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerFailedReason, infrav1.ConditionSeverityWarning, "%v", err)
+		return err
+	}
+
+	apiELB.DeepCopyInto(&s.scope.Network().APIServerELB)
+	s.scope.V(4).Info("Control plane load balancer", "api-server-elb", apiELB)
+
+	s.scope.V(2).Info("Reconcile load balancers completed successfully")
+	return nil
+}
+
+func (s *Service) reconcileAPIServerClassicELB() (*infrav1.ClassicELB, error) {
 	// Get default api server spec.
 	spec := s.getAPIServerClassicELBSpec()
 
@@ -49,42 +73,226 @@ func (s *Service) ReconcileLoadbalancers() error {
 	if IsNotFound(err) {
 		apiELB, err = s.createClassicELB(spec)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		s.scope.V(2).Info("Created new classic load balancer for apiserver", "api-server-elb-name", apiELB.Name)
 	} else if err != nil {
-		return err
+		return nil, err
+	}
+
+	// The scheme cannot be modified, the load balancer would have to be
+	// recreated with a new DNS name.
+	if !strings.EqualFold(string(apiELB.Scheme), string(spec.Scheme)) {
+		return nil, errors.Errorf("apiserver load balancer %q has scheme %q instead of %q, it must be deleted to change its scheme", apiELB.Name, apiELB.Scheme, spec.Scheme)
 	}
 
 	if !reflect.DeepEqual(spec.Attributes, apiELB.Attributes) {
 		err := s.configureAttributes(apiELB.Name, spec.Attributes)
 		if err != nil {
-			return err
+			return nil, err
+		}
+	}
+
+	if err := s.reconcileClassicELBListeners(apiELB, spec); err != nil {
+		return nil, err
+	}
+
+	if spec.HealthCheck != nil && !reflect.DeepEqual(spec.HealthCheck, apiELB.HealthCheck) {
+		if err := s.configureHealthCheck(apiELB.Name, spec.HealthCheck); err != nil {
+			return nil, err
+		}
+	}
+
+	if !sameStrings(apiELB.SecurityGroupIDs, spec.SecurityGroupIDs) {
+		if _, err := s.scope.ELB.ApplySecurityGroupsToLoadBalancer(&elb.ApplySecurityGroupsToLoadBalancerInput{
+			LoadBalancerName: aws.String(apiELB.Name),
+			SecurityGroups:   aws.StringSlice(spec.SecurityGroupIDs),
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to apply security groups to apiserver load balancer %q", apiELB.Name)
 		}
 	}
 
 	// Reconciliate the subnets from the spec and the ones currently attached to the load balancer.
-	if len(apiELB.SubnetIDs) != len(spec.SubnetIDs) {
-		_, err := s.scope.ELB.AttachLoadBalancerToSubnets(&elb.AttachLoadBalancerToSubnetsInput{
-			Subnets: aws.StringSlice(spec.SubnetIDs),
+	if err := s.reconcileClassicELBSubnets(apiELB, spec); err != nil {
+		return nil, err
+	}
+
+	if err := s.reconcileClassicELBTags(apiELB.Name, spec.Tags); err != nil {
+		return nil, err
+	}
+
+	res := spec.DeepCopy()
+	res.DNSName = apiELB.DNSName
+	return res, nil
+}
+
+// reconcileClassicELBListeners replaces the listeners that differ from the spec
+// and creates the missing ones. Listeners are identified by their port.
+func (s *Service) reconcileClassicELBListeners(apiELB, spec *infrav1.ClassicELB) error {
+	current := make(map[int64]*infrav1.ClassicELBListener, len(apiELB.Listeners))
+	for _, ln := range apiELB.Listeners {
+		current[ln.Port] = ln
+	}
+	desired := make(map[int64]*infrav1.ClassicELBListener, len(spec.Listeners))
+	for _, ln := range spec.Listeners {
+		desired[ln.Port] = ln
+	}
+
+	var removed []int64
+	for port, ln := range current {
+		if !reflect.DeepEqual(ln, desired[port]) {
+			removed = append(removed, port)
+		}
+	}
+	if len(removed) > 0 {
+		if _, err := s.scope.ELB.DeleteLoadBalancerListeners(&elb.DeleteLoadBalancerListenersInput{
+			LoadBalancerName:  aws.String(apiELB.Name),
+			LoadBalancerPorts: aws.Int64Slice(removed),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to delete listeners of apiserver load balancer %q", apiELB.Name)
+		}
+	}
+
+	var added []*elb.Listener
+	for _, ln := range spec.Listeners {
+		if reflect.DeepEqual(ln, current[ln.Port]) {
+			continue
+		}
+		added = append(added, &elb.Listener{
+			Protocol:         aws.String(string(ln.Protocol)),
+			LoadBalancerPort: aws.Int64(ln.Port),
+			InstanceProtocol: aws.String(string(ln.InstanceProtocol)),
+			InstancePort:     aws.Int64(ln.InstancePort),
 		})
-		if err != nil {
+	}
+	if len(added) > 0 {
+		if _, err := s.scope.ELB.CreateLoadBalancerListeners(&elb.CreateLoadBalancerListenersInput{
+			LoadBalancerName: aws.String(apiELB.Name),
+			Listeners:        added,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to create listeners of apiserver load balancer %q", apiELB.Name)
+		}
+	}
+
+	return nil
+}
+
+// reconcileClassicELBSubnets attaches the subnets of the spec missing from the
+// load balancer and detaches the ones that are no longer in the spec.
+func (s *Service) reconcileClassicELBSubnets(apiELB, spec *infrav1.ClassicELB) error {
+	added := stringsDifference(spec.SubnetIDs, apiELB.SubnetIDs)
+	removed := stringsDifference(apiELB.SubnetIDs, spec.SubnetIDs)
+
+	detach := func() error {
+		if len(removed) == 0 {
+			return nil
+		}
+		if _, err := s.scope.ELB.DetachLoadBalancerFromSubnets(&elb.DetachLoadBalancerFromSubnetsInput{
+			LoadBalancerName: aws.String(apiELB.Name),
+			Subnets:          aws.StringSlice(removed),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to detach apiserver load balancer %q from subnets", apiELB.Name)
+		}
+		return nil
+	}
+
+	// A load balancer can only be attached to one subnet per zone, the removed
+	// subnets are detached first if an added subnet is in a zone the load
+	// balancer is attached to, or if the load balancer keeps other subnets.
+	attachedZones := make(map[string]struct{}, len(apiELB.AvailabilityZones))
+	for _, zone := range apiELB.AvailabilityZones {
+		attachedZones[zone] = struct{}{}
+	}
+	detachFirst := len(removed) < len(apiELB.SubnetIDs)
+	for _, id := range added {
+		if sn := s.scope.Subnets().FindByID(id); sn != nil {
+			if _, ok := attachedZones[sn.AvailabilityZone]; ok {
+				detachFirst = true
+			}
+		}
+	}
+	if detachFirst {
+		if err := detach(); err != nil {
+			return err
+		}
+	}
+
+	if len(added) > 0 {
+		if _, err := s.scope.ELB.AttachLoadBalancerToSubnets(&elb.AttachLoadBalancerToSubnetsInput{
+			LoadBalancerName: aws.String(apiELB.Name),
+			Subnets:          aws.StringSlice(added),
+		}); err != nil {
 			return errors.Wrapf(err, "failed to attach apiserver load balancer %q to subnets", apiELB.Name)
 		}
 	}
 
-	// TODO(vincepri): check if anything has changed and reconcile as necessary.
-	apiELB.DeepCopyInto(&s.scope.Network().APIServerELB)
-	s.scope.V(4).Info("Control plane load balancer", "api-server-elb", apiELB)
+	if !detachFirst {
+		return detach()
+	}
+	return nil
+}
+
+// reconcileClassicELBTags adds the missing or modified tags to the load balancer.
+// The tags previously applied by the provider and no longer desired, such as
+// removed additional tags, are removed. Tags added by other tools are kept.
+func (s *Service) reconcileClassicELBTags(name string, desired infrav1.Tags) error {
+	out, err := s.scope.ELB.DescribeTags(&elb.DescribeTagsInput{
+		LoadBalancerNames: aws.StringSlice([]string{name}),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe tags of classic load balancer %q", name)
+	}
+
+	current := infrav1.Tags{}
+	for _, desc := range out.TagDescriptions {
+		current.Merge(converters.ELBTagsToMap(desc.Tags))
+	}
+
+	if added := desired.Difference(current); len(added) > 0 {
+		if _, err := s.scope.ELB.AddTags(&elb.AddTagsInput{
+			LoadBalancerNames: aws.StringSlice([]string{name}),
+			Tags:              converters.MapToELBTags(added),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to add tags to classic load balancer %q", name)
+		}
+	}
+
+	var removed []*elb.TagKeyOnly
+	for key := range s.scope.Network().APIServerELB.Tags {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		if _, ok := current[key]; ok {
+			removed = append(removed, &elb.TagKeyOnly{Key: aws.String(key)})
+		}
+	}
+	if len(removed) > 0 {
+		if _, err := s.scope.ELB.RemoveTags(&elb.RemoveTagsInput{
+			LoadBalancerNames: aws.StringSlice([]string{name}),
+			Tags:              removed,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to remove tags from classic load balancer %q", name)
+		}
+	}
 
-	s.scope.V(2).Info("Reconcile load balancers completed successfully")
 	return nil
 }
 
 // GetAPIServerDNSName returns the DNS name endpoint for the API server
 func (s *Service) GetAPIServerDNSName() (string, error) {
-	apiELB, err := s.describeClassicELB(GenerateELBName(s.scope.Name(), infrav1.APIServerRoleTagValue))
+	name := GenerateELBName(s.scope.Name(), infrav1.APIServerRoleTagValue)
+
+	var (
+		apiELB *infrav1.ClassicELB
+		err    error
+	)
+	switch s.scope.ControlPlaneLoadBalancerType() {
+	case infrav1.LoadBalancerTypeNetwork:
+		apiELB, err = s.describeNLB(name)
+	default:
+		apiELB, err = s.describeClassicELB(name)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -95,14 +303,18 @@ func (s *Service) GetAPIServerDNSName() (string, error) {
 func (s *Service) DeleteLoadbalancers() error {
 	s.scope.V(2).Info("Deleting load balancers")
 
-	elbs, err := s.listOwnedELBs()
+	arns, err := s.listOwnedELBs()
 	if err != nil {
 		return err
 	}
 
-	for _, elb := range elbs {
-		s.scope.V(3).Info("deleting load balancer", "arn", elb)
-		s.deleteClassicELB(elb)
+	for _, arn := range arns {
+		s.scope.V(3).Info("deleting load balancer", "arn", arn)
+		if isELBV2ARN(arn) {
+			s.deleteNLB(arn)
+		} else {
+			s.deleteClassicELB(nameFromARN(arn))
+		}
 	}
 
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (done bool, err error) {
@@ -116,6 +328,11 @@ func (s *Service) DeleteLoadbalancers() error {
 		return errors.Wrapf(err, "failed to wait for %q ELB deletions", s.scope.Name())
 	}
 
+	// Target groups can only be deleted once the load balancers using them are gone.
+	if err := s.deleteOwnedTargetGroups(); err != nil {
+		return err
+	}
+
 	s.scope.V(2).Info("Deleting load balancers completed successfully")
 	return nil
 }
@@ -135,25 +352,20 @@ func (s *Service) RegisterInstanceWithClassicELB(instanceID string, loadBalancer
 	return nil
 }
 
-// RegisterInstanceWithAPIServerELB registers an instance with a classic ELB
+// RegisterInstanceWithAPIServerELB registers an instance with the API server load balancer
 func (s *Service) RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error {
+	if s.scope.ControlPlaneLoadBalancerType() == infrav1.LoadBalancerTypeNetwork {
+		return s.registerInstanceWithAPIServerNLB(i)
+	}
+
 	name := GenerateELBName(s.scope.Name(), infrav1.APIServerRoleTagValue)
 	out, err := s.describeClassicELB(name)
 	if err != nil {
 		return err
 	}
 
-	// Validate that the subnets associated with the load balancer has the instance AZ.
-	instanceAZ := s.scope.Subnets().FindByID(i.SubnetID).AvailabilityZone
-	found := false
-	for _, subnetID := range out.SubnetIDs {
-		if subnet := s.scope.Subnets().FindByID(subnetID); subnet != nil && instanceAZ == subnet.AvailabilityZone {
-			found = true
-			break
-		}
-	}
-	if !found {
-		return errors.Errorf("failed to register instance with APIServer ELB %q: instance is in availability zone %q, no public subnets attached to the ELB in the same zone", name, instanceAZ)
+	if err := s.validateInstanceZone(name, out.SubnetIDs, i); err != nil {
+		return err
 	}
 
 	input := &elb.RegisterInstancesWithLoadBalancerInput{
@@ -169,6 +381,17 @@ func (s *Service) RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error {
 	return nil
 }
 
+// validateInstanceZone validates that the subnets associated with the load balancer have the instance AZ.
+func (s *Service) validateInstanceZone(name string, subnetIDs []string, i *infrav1.Instance) error {
+	instanceAZ := s.scope.Subnets().FindByID(i.SubnetID).AvailabilityZone
+	for _, subnetID := range subnetIDs {
+		if subnet := s.scope.Subnets().FindByID(subnetID); subnet != nil && instanceAZ == subnet.AvailabilityZone {
+			return nil
+		}
+	}
+	return errors.Errorf("failed to register instance with APIServer ELB %q: instance is in availability zone %q, no public subnets attached to the ELB in the same zone", name, instanceAZ)
+}
+
 // GenerateELBName generates a formatted ELB name
 func GenerateELBName(clusterName string, elbName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, elbName)
@@ -176,8 +399,9 @@ func GenerateELBName(clusterName string, elbName string) string {
 
 func (s *Service) getAPIServerClassicELBSpec() *infrav1.ClassicELB {
 	res := &infrav1.ClassicELB{
-		Name:   GenerateELBName(s.scope.Name(), infrav1.APIServerRoleTagValue),
-		Scheme: s.scope.ControlPlaneLoadBalancerScheme(),
+		Name:             GenerateELBName(s.scope.Name(), infrav1.APIServerRoleTagValue),
+		Scheme:           s.scope.ControlPlaneLoadBalancerScheme(),
+		LoadBalancerType: infrav1.LoadBalancerTypeClassic,
 		Listeners: []*infrav1.ClassicELBListener{
 			{
 				Protocol:         infrav1.ClassicELBProtocolTCP,
@@ -199,16 +423,43 @@ func (s *Service) getAPIServerClassicELBSpec() *infrav1.ClassicELB {
 		},
 	}
 
-	res.Tags = infrav1.Build(infrav1.BuildParams{
+	if lb := s.scope.ControlPlaneLoadBalancer(); lb != nil {
+		res.Attributes.CrossZoneLoadBalancing = lb.CrossZoneLoadBalancing
+		if lb.ConnectionDrainingTimeoutSeconds != nil {
+			res.Attributes.ConnectionDrainingTimeout = time.Duration(*lb.ConnectionDrainingTimeoutSeconds) * time.Second
+		}
+		if lb.AccessLog != nil {
+			res.Attributes.AccessLog = lb.AccessLog.DeepCopy()
+			if res.Attributes.AccessLog.EmitInterval == 0 {
+				res.Attributes.AccessLog.EmitInterval = 60
+			}
+		}
+	}
+
+	res.Tags = s.apiServerLoadBalancerTags()
+	res.SubnetIDs = s.apiServerLoadBalancerSubnets()
+	for _, id := range res.SubnetIDs {
+		res.AvailabilityZones = append(res.AvailabilityZones, s.scope.Subnets().FindByID(id).AvailabilityZone)
+	}
+
+	return res
+}
+
+func (s *Service) apiServerLoadBalancerTags() infrav1.Tags {
+	return infrav1.Build(infrav1.BuildParams{
 		ClusterName: s.scope.Name(),
 		Lifecycle:   infrav1.ResourceLifecycleOwned,
 		Role:        aws.String(infrav1.APIServerRoleTagValue),
 		Additional:  s.scope.AdditionalTags(),
 	})
+}
 
+// apiServerLoadBalancerSubnets returns the subnets the API server load balancer should be attached to.
+func (s *Service) apiServerLoadBalancerSubnets() []string {
 	// The load balancer APIs require us to only attach one subnet for each AZ.
 	zones := map[string]struct{}{}
 	subnets := s.scope.Subnets()
+	ids := []string{}
 
 	if s.scope.ControlPlaneLoadBalancerScheme() == infrav1.ClassicELBSchemeInternetFacing {
 		subnets = subnets.FilterPublic()
@@ -219,11 +470,11 @@ func (s *Service) getAPIServerClassicELBSpec() *infrav1.ClassicELB {
 	for _, sn := range subnets {
 		if _, ok := zones[sn.AvailabilityZone]; !ok {
 			zones[sn.AvailabilityZone] = struct{}{}
-			res.SubnetIDs = append(res.SubnetIDs, sn.ID)
+			ids = append(ids, sn.ID)
 		}
 	}
 
-	return res
+	return ids
 }
 
 func (s *Service) createClassicELB(spec *infrav1.ClassicELB) (*infrav1.ClassicELB, error) {
@@ -250,32 +501,43 @@ func (s *Service) createClassicELB(spec *infrav1.ClassicELB) (*infrav1.ClassicEL
 	}
 
 	if spec.HealthCheck != nil {
-		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if _, err := s.scope.ELB.ConfigureHealthCheck(&elb.ConfigureHealthCheckInput{
-				LoadBalancerName: aws.String(spec.Name),
-				HealthCheck: &elb.HealthCheck{
-					Target:             aws.String(spec.HealthCheck.Target),
-					Interval:           aws.Int64(int64(spec.HealthCheck.Interval.Seconds())),
-					Timeout:            aws.Int64(int64(spec.HealthCheck.Timeout.Seconds())),
-					HealthyThreshold:   aws.Int64(spec.HealthCheck.HealthyThreshold),
-					UnhealthyThreshold: aws.Int64(spec.HealthCheck.UnhealthyThreshold),
-				},
-			}); err != nil {
-				return false, err
-			}
-			return true, nil
-		}, awserrors.LoadBalancerNotFound); err != nil {
-			return nil, errors.Wrapf(err, "failed to configure health check for classic load balancer: %v", spec)
+		if err := s.configureHealthCheck(spec.Name, spec.HealthCheck); err != nil {
+			return nil, err
 		}
 	}
 
 	s.scope.V(2).Info("Created classic load balancer", "dns-name", *out.DNSName)
 
+	// The attributes are configured right after, as the load balancer is
+	// created with the default ones.
 	res := spec.DeepCopy()
 	res.DNSName = *out.DNSName
+	res.Attributes = infrav1.ClassicELBAttributes{}
 	return res, nil
 }
 
+func (s *Service) configureHealthCheck(name string, healthCheck *infrav1.ClassicELBHealthCheck) error {
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if _, err := s.scope.ELB.ConfigureHealthCheck(&elb.ConfigureHealthCheckInput{
+			LoadBalancerName: aws.String(name),
+			HealthCheck: &elb.HealthCheck{
+				Target:             aws.String(healthCheck.Target),
+				Interval:           aws.Int64(int64(healthCheck.Interval.Seconds())),
+				Timeout:            aws.Int64(int64(healthCheck.Timeout.Seconds())),
+				HealthyThreshold:   aws.Int64(healthCheck.HealthyThreshold),
+				UnhealthyThreshold: aws.Int64(healthCheck.UnhealthyThreshold),
+			},
+		}); err != nil {
+			return false, err
+		}
+		return true, nil
+	}, awserrors.LoadBalancerNotFound); err != nil {
+		return errors.Wrapf(err, "failed to configure health check for classic load balancer: %v", name)
+	}
+
+	return nil
+}
+
 func (s *Service) configureAttributes(name string, attributes infrav1.ClassicELBAttributes) error {
 	attrs := &elb.ModifyLoadBalancerAttributesInput{
 		LoadBalancerName:       aws.String(name),
@@ -288,6 +550,26 @@ func (s *Service) configureAttributes(name string, attributes infrav1.ClassicELB
 		}
 	}
 
+	attrs.LoadBalancerAttributes.CrossZoneLoadBalancing = &elb.CrossZoneLoadBalancing{
+		Enabled: aws.Bool(attributes.CrossZoneLoadBalancing),
+	}
+
+	attrs.LoadBalancerAttributes.ConnectionDraining = &elb.ConnectionDraining{
+		Enabled: aws.Bool(attributes.ConnectionDrainingTimeout > 0),
+	}
+	if attributes.ConnectionDrainingTimeout > 0 {
+		attrs.LoadBalancerAttributes.ConnectionDraining.Timeout = aws.Int64(int64(attributes.ConnectionDrainingTimeout.Seconds()))
+	}
+
+	attrs.LoadBalancerAttributes.AccessLog = &elb.AccessLog{
+		Enabled: aws.Bool(attributes.AccessLog != nil),
+	}
+	if attributes.AccessLog != nil {
+		attrs.LoadBalancerAttributes.AccessLog.S3BucketName = aws.String(attributes.AccessLog.S3BucketName)
+		attrs.LoadBalancerAttributes.AccessLog.S3BucketPrefix = aws.String(attributes.AccessLog.S3BucketPrefix)
+		attrs.LoadBalancerAttributes.AccessLog.EmitInterval = aws.Int64(attributes.AccessLog.EmitInterval)
+	}
+
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 		if _, err := s.scope.ELB.ModifyLoadBalancerAttributes(attrs); err != nil {
 			return false, err
@@ -311,9 +593,9 @@ func (s *Service) deleteClassicELB(name string) error {
 	return nil
 }
 
-func (s *Service) listByTag(tag string) ([]string, error) {
+func (s *Service) listByTag(resourceType, tag string) ([]string, error) {
 	input := rgapi.GetResourcesInput{
-		ResourceTypeFilters: aws.StringSlice([]string{elbResourceType}),
+		ResourceTypeFilters: aws.StringSlice([]string{resourceType}),
 		TagFilters: []*rgapi.TagFilter{
 			{
 				Key:    aws.String(tag),
@@ -322,19 +604,16 @@ func (s *Service) listByTag(tag string) ([]string, error) {
 		},
 	}
 
-	names := []string{}
+	arns := []string{}
 
 	err := s.scope.ResourceTagging.GetResourcesPages(&input, func(r *rgapi.GetResourcesOutput, last bool) bool {
 		for _, tagmapping := range r.ResourceTagMappingList {
 			if tagmapping.ResourceARN != nil {
-				// We can't use arn.Parse because the "Resource" is loadbalancer/<name>
-				parts := strings.Split(*tagmapping.ResourceARN, "/")
-				name := parts[len(parts)-1]
-				if name == "" {
+				if nameFromARN(*tagmapping.ResourceARN) == "" {
 					s.scope.Info("failed to parse ARN", "arn", *tagmapping.ResourceARN, "tag", tag)
 					continue
 				}
-				names = append(names, name)
+				arns = append(arns, *tagmapping.ResourceARN)
 			}
 		}
 		return true
@@ -344,20 +623,21 @@ func (s *Service) listByTag(tag string) ([]string, error) {
 		return nil, errors.Wrapf(err, "failed to list %s ELBs by tag group", s.scope.Name())
 	}
 
-	return names, nil
+	return arns, nil
 }
 
-func (s *Service) listOwnedELBs() ([]string, error) {
+// listOwned returns the ARNs of the resources of the given type owned by the cluster.
+func (s *Service) listOwned(resourceType string) ([]string, error) {
 	// k8s.io/cluster/<name>, created by k/k cloud provider
 	serviceTag := infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())
-	arns, err := s.listByTag(serviceTag)
+	arns, err := s.listByTag(resourceType, serviceTag)
 	if err != nil {
 		return nil, err
 	}
 
 	// sigs.k8s.io/cluster-api-provider-aws/cluster/<name>, created by CAPA
 	capaTag := infrav1.ClusterTagKey(s.scope.Name())
-	clusterArns, err := s.listByTag(capaTag)
+	clusterArns, err := s.listByTag(resourceType, capaTag)
 	if err != nil {
 		return nil, err
 	}
@@ -365,6 +645,23 @@ func (s *Service) listOwnedELBs() ([]string, error) {
 	return append(arns, clusterArns...), nil
 }
 
+func (s *Service) listOwnedELBs() ([]string, error) {
+	return s.listOwned(elbResourceType)
+}
+
+// nameFromARN returns the last component of a load balancer or target group ARN.
+// We can't use arn.Parse because the "Resource" is loadbalancer/<name>.
+func nameFromARN(arn string) string {
+	parts := strings.Split(arn, "/")
+	return parts[len(parts)-1]
+}
+
+// isELBV2ARN returns true if the ARN belongs to a network or application load balancer,
+// whose resource is loadbalancer/net/<name>/<id> or loadbalancer/app/<name>/<id>.
+func isELBV2ARN(arn string) bool {
+	return strings.Contains(arn, ":loadbalancer/net/") || strings.Contains(arn, ":loadbalancer/app/")
+}
+
 func (s *Service) describeClassicELB(name string) (*infrav1.ClassicELB, error) {
 	input := &elb.DescribeLoadBalancersInput{
 		LoadBalancerNames: aws.StringSlice([]string{name}),
@@ -409,16 +706,77 @@ func (s *Service) describeClassicELB(name string) (*infrav1.ClassicELB, error) {
 
 func fromSDKTypeToClassicELB(v *elb.LoadBalancerDescription, attrs *elb.LoadBalancerAttributes) *infrav1.ClassicELB {
 	res := &infrav1.ClassicELB{
-		Name:             aws.StringValue(v.LoadBalancerName),
-		Scheme:           infrav1.ClassicELBScheme(*v.Scheme),
-		SubnetIDs:        aws.StringValueSlice(v.Subnets),
-		SecurityGroupIDs: aws.StringValueSlice(v.SecurityGroups),
-		DNSName:          aws.StringValue(v.DNSName),
+		Name:              aws.StringValue(v.LoadBalancerName),
+		Scheme:            infrav1.ClassicELBScheme(*v.Scheme),
+		SubnetIDs:         aws.StringValueSlice(v.Subnets),
+		AvailabilityZones: aws.StringValueSlice(v.AvailabilityZones),
+		SecurityGroupIDs:  aws.StringValueSlice(v.SecurityGroups),
+		DNSName:           aws.StringValue(v.DNSName),
+		LoadBalancerType:  infrav1.LoadBalancerTypeClassic,
+	}
+
+	for _, desc := range v.ListenerDescriptions {
+		if desc.Listener == nil {
+			continue
+		}
+		res.Listeners = append(res.Listeners, &infrav1.ClassicELBListener{
+			Protocol:         infrav1.ClassicELBProtocol(aws.StringValue(desc.Listener.Protocol)),
+			Port:             aws.Int64Value(desc.Listener.LoadBalancerPort),
+			InstanceProtocol: infrav1.ClassicELBProtocol(aws.StringValue(desc.Listener.InstanceProtocol)),
+			InstancePort:     aws.Int64Value(desc.Listener.InstancePort),
+		})
+	}
+
+	if v.HealthCheck != nil {
+		res.HealthCheck = &infrav1.ClassicELBHealthCheck{
+			Target:             aws.StringValue(v.HealthCheck.Target),
+			Interval:           time.Duration(aws.Int64Value(v.HealthCheck.Interval)) * time.Second,
+			Timeout:            time.Duration(aws.Int64Value(v.HealthCheck.Timeout)) * time.Second,
+			HealthyThreshold:   aws.Int64Value(v.HealthCheck.HealthyThreshold),
+			UnhealthyThreshold: aws.Int64Value(v.HealthCheck.UnhealthyThreshold),
+		}
 	}
 
 	if attrs.ConnectionSettings != nil && attrs.ConnectionSettings.IdleTimeout != nil {
 		res.Attributes.IdleTimeout = time.Duration(*attrs.ConnectionSettings.IdleTimeout) * time.Second
 	}
 
+	if attrs.CrossZoneLoadBalancing != nil {
+		res.Attributes.CrossZoneLoadBalancing = aws.BoolValue(attrs.CrossZoneLoadBalancing.Enabled)
+	}
+
+	if attrs.ConnectionDraining != nil && aws.BoolValue(attrs.ConnectionDraining.Enabled) {
+		res.Attributes.ConnectionDrainingTimeout = time.Duration(aws.Int64Value(attrs.ConnectionDraining.Timeout)) * time.Second
+	}
+
+	if attrs.AccessLog != nil && aws.BoolValue(attrs.AccessLog.Enabled) {
+		res.Attributes.AccessLog = &infrav1.ClassicELBAccessLog{
+			S3BucketName:   aws.StringValue(attrs.AccessLog.S3BucketName),
+			S3BucketPrefix: aws.StringValue(attrs.AccessLog.S3BucketPrefix),
+			EmitInterval:   aws.Int64Value(attrs.AccessLog.EmitInterval),
+		}
+	}
+
+	return res
+}
+
+// sameStrings returns true if both slices contain the same strings, in any order.
+func sameStrings(a, b []string) bool {
+	return len(stringsDifference(a, b)) == 0 && len(stringsDifference(b, a)) == 0
+}
+
+// stringsDifference returns the strings of a that are not in b.
+func stringsDifference(a, b []string) []string {
+	set := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		set[v] = struct{}{}
+	}
+
+	var res []string
+	for _, v := range a {
+		if _, ok := set[v]; !ok {
+			res = append(res, v)
+		}
+	}
 	return res
 }
@@ -0,0 +1,385 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb/mock_elbiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func newClassicELBTestScope(t *testing.T, elbMock *mock_elbiface.MockELBAPI) *scope.ClusterScope {
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+		AWSClients: scope.AWSClients{
+			ELB: elbMock,
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					CrossZoneLoadBalancing: true,
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						ID: "vpc-1",
+					},
+					Subnets: infrav1.Subnets{
+						{ID: "subnet-private-a", AvailabilityZone: "us-east-1a"},
+						{ID: "subnet-private-b", AvailabilityZone: "us-east-1b"},
+						{ID: "subnet-public-a", AvailabilityZone: "us-east-1a", IsPublic: true},
+						{ID: "subnet-public-b", AvailabilityZone: "us-east-1b", IsPublic: true},
+					},
+				},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: infrav1.Network{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+						infrav1.SecurityGroupControlPlane: {ID: "sg-control-plane"},
+					},
+					APIServerELB: infrav1.ClassicELB{
+						Tags: infrav1.Tags{"removed-additional-tag": "value"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	return cs
+}
+
+// classicELBDescription returns the description of a load balancer matching the test scope.
+func classicELBDescription() *elb.LoadBalancerDescription {
+	return &elb.LoadBalancerDescription{
+		LoadBalancerName:  aws.String("test-cluster-apiserver"),
+		DNSName:           aws.String("elb.example.com"),
+		Scheme:            aws.String("internet-facing"),
+		VPCId:             aws.String("vpc-1"),
+		Subnets:           aws.StringSlice([]string{"subnet-public-b", "subnet-public-a"}),
+		AvailabilityZones: aws.StringSlice([]string{"us-east-1b", "us-east-1a"}),
+		SecurityGroups:    aws.StringSlice([]string{"sg-control-plane"}),
+		ListenerDescriptions: []*elb.ListenerDescription{
+			{
+				Listener: &elb.Listener{
+					Protocol:         aws.String("TCP"),
+					LoadBalancerPort: aws.Int64(6443),
+					InstanceProtocol: aws.String("TCP"),
+					InstancePort:     aws.Int64(6443),
+				},
+			},
+		},
+		HealthCheck: &elb.HealthCheck{
+			Target:             aws.String("SSL:6443"),
+			Interval:           aws.Int64(10),
+			Timeout:            aws.Int64(5),
+			HealthyThreshold:   aws.Int64(5),
+			UnhealthyThreshold: aws.Int64(3),
+		},
+	}
+}
+
+// classicELBAttributes returns the attributes of a load balancer matching the test scope.
+func classicELBAttributes() *elb.LoadBalancerAttributes {
+	return &elb.LoadBalancerAttributes{
+		ConnectionSettings:     &elb.ConnectionSettings{IdleTimeout: aws.Int64(600)},
+		CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(true)},
+		ConnectionDraining:     &elb.ConnectionDraining{Enabled: aws.Bool(false), Timeout: aws.Int64(300)},
+		AccessLog:              &elb.AccessLog{Enabled: aws.Bool(false)},
+	}
+}
+
+// classicELBTags returns the tags of a load balancer matching the test scope.
+func classicELBTags() infrav1.Tags {
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: "test-cluster",
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Role:        aws.String(infrav1.APIServerRoleTagValue),
+	})
+}
+
+func TestReconcileAPIServerClassicELB(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name   string
+		expect func(m *mock_elbiface.MockELBAPIMockRecorder)
+		check  func(lb *infrav1.ClassicELB, err error)
+	}{
+		{
+			name: "creates load balancer and configures it",
+			expect: func(m *mock_elbiface.MockELBAPIMockRecorder) {
+				m.DescribeLoadBalancers(gomock.AssignableToTypeOf(&elb.DescribeLoadBalancersInput{})).
+					Return(&elb.DescribeLoadBalancersOutput{}, nil)
+				m.CreateLoadBalancer(gomock.AssignableToTypeOf(&elb.CreateLoadBalancerInput{})).
+					Return(&elb.CreateLoadBalancerOutput{DNSName: aws.String("elb.example.com")}, nil)
+				m.ConfigureHealthCheck(gomock.AssignableToTypeOf(&elb.ConfigureHealthCheckInput{})).
+					Return(&elb.ConfigureHealthCheckOutput{}, nil)
+				m.ModifyLoadBalancerAttributes(gomock.Eq(&elb.ModifyLoadBalancerAttributesInput{
+					LoadBalancerName: aws.String("test-cluster-apiserver"),
+					LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+						ConnectionSettings:     &elb.ConnectionSettings{IdleTimeout: aws.Int64(600)},
+						CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(true)},
+						ConnectionDraining:     &elb.ConnectionDraining{Enabled: aws.Bool(false)},
+						AccessLog:              &elb.AccessLog{Enabled: aws.Bool(false)},
+					},
+				})).
+					Return(&elb.ModifyLoadBalancerAttributesOutput{}, nil)
+				m.DescribeTags(gomock.AssignableToTypeOf(&elb.DescribeTagsInput{})).
+					Return(&elb.DescribeTagsOutput{
+						TagDescriptions: []*elb.TagDescription{{Tags: converters.MapToELBTags(classicELBTags())}},
+					}, nil)
+			},
+			check: func(lb *infrav1.ClassicELB, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if lb.DNSName != "elb.example.com" || !lb.Attributes.CrossZoneLoadBalancing {
+					t.Fatalf("unexpected load balancer: %+v", lb)
+				}
+			},
+		},
+		{
+			name: "load balancer matching the spec is left unchanged",
+			expect: func(m *mock_elbiface.MockELBAPIMockRecorder) {
+				m.DescribeLoadBalancers(gomock.AssignableToTypeOf(&elb.DescribeLoadBalancersInput{})).
+					Return(&elb.DescribeLoadBalancersOutput{
+						LoadBalancerDescriptions: []*elb.LoadBalancerDescription{classicELBDescription()},
+					}, nil)
+				m.DescribeLoadBalancerAttributes(gomock.AssignableToTypeOf(&elb.DescribeLoadBalancerAttributesInput{})).
+					Return(&elb.DescribeLoadBalancerAttributesOutput{LoadBalancerAttributes: classicELBAttributes()}, nil)
+				m.DescribeTags(gomock.AssignableToTypeOf(&elb.DescribeTagsInput{})).
+					Return(&elb.DescribeTagsOutput{
+						TagDescriptions: []*elb.TagDescription{{Tags: converters.MapToELBTags(classicELBTags())}},
+					}, nil)
+			},
+			check: func(lb *infrav1.ClassicELB, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if lb.DNSName != "elb.example.com" {
+					t.Fatalf("unexpected load balancer: %+v", lb)
+				}
+			},
+		},
+		{
+			name: "drifted load balancer is restored",
+			expect: func(m *mock_elbiface.MockELBAPIMockRecorder) {
+				desc := classicELBDescription()
+				desc.Subnets = aws.StringSlice([]string{"subnet-public-a", "subnet-private-b"})
+				desc.SecurityGroups = aws.StringSlice([]string{"sg-other"})
+				desc.ListenerDescriptions[0].Listener.LoadBalancerPort = aws.Int64(443)
+				desc.HealthCheck.Timeout = aws.Int64(10)
+
+				attrs := classicELBAttributes()
+				attrs.ConnectionDraining = &elb.ConnectionDraining{Enabled: aws.Bool(true), Timeout: aws.Int64(300)}
+
+				tags := classicELBTags()
+				delete(tags, infrav1.NameAWSClusterAPIRole)
+				tags["removed-additional-tag"] = "value"
+				tags["other-tool-tag"] = "value"
+
+				m.DescribeLoadBalancers(gomock.AssignableToTypeOf(&elb.DescribeLoadBalancersInput{})).
+					Return(&elb.DescribeLoadBalancersOutput{
+						LoadBalancerDescriptions: []*elb.LoadBalancerDescription{desc},
+					}, nil)
+				m.DescribeLoadBalancerAttributes(gomock.AssignableToTypeOf(&elb.DescribeLoadBalancerAttributesInput{})).
+					Return(&elb.DescribeLoadBalancerAttributesOutput{LoadBalancerAttributes: attrs}, nil)
+				m.ModifyLoadBalancerAttributes(gomock.AssignableToTypeOf(&elb.ModifyLoadBalancerAttributesInput{})).
+					Return(&elb.ModifyLoadBalancerAttributesOutput{}, nil)
+				m.DeleteLoadBalancerListeners(gomock.Eq(&elb.DeleteLoadBalancerListenersInput{
+					LoadBalancerName:  aws.String("test-cluster-apiserver"),
+					LoadBalancerPorts: aws.Int64Slice([]int64{443}),
+				})).
+					Return(&elb.DeleteLoadBalancerListenersOutput{}, nil)
+				m.CreateLoadBalancerListeners(gomock.Eq(&elb.CreateLoadBalancerListenersInput{
+					LoadBalancerName: aws.String("test-cluster-apiserver"),
+					Listeners: []*elb.Listener{
+						{
+							Protocol:         aws.String("TCP"),
+							LoadBalancerPort: aws.Int64(6443),
+							InstanceProtocol: aws.String("TCP"),
+							InstancePort:     aws.Int64(6443),
+						},
+					},
+				})).
+					Return(&elb.CreateLoadBalancerListenersOutput{}, nil)
+				m.ConfigureHealthCheck(gomock.AssignableToTypeOf(&elb.ConfigureHealthCheckInput{})).
+					Return(&elb.ConfigureHealthCheckOutput{}, nil)
+				m.ApplySecurityGroupsToLoadBalancer(gomock.Eq(&elb.ApplySecurityGroupsToLoadBalancerInput{
+					LoadBalancerName: aws.String("test-cluster-apiserver"),
+					SecurityGroups:   aws.StringSlice([]string{"sg-control-plane"}),
+				})).
+					Return(&elb.ApplySecurityGroupsToLoadBalancerOutput{}, nil)
+				m.DetachLoadBalancerFromSubnets(gomock.Eq(&elb.DetachLoadBalancerFromSubnetsInput{
+					LoadBalancerName: aws.String("test-cluster-apiserver"),
+					Subnets:          aws.StringSlice([]string{"subnet-private-b"}),
+				})).
+					Return(&elb.DetachLoadBalancerFromSubnetsOutput{}, nil)
+				m.AttachLoadBalancerToSubnets(gomock.Eq(&elb.AttachLoadBalancerToSubnetsInput{
+					LoadBalancerName: aws.String("test-cluster-apiserver"),
+					Subnets:          aws.StringSlice([]string{"subnet-public-b"}),
+				})).
+					Return(&elb.AttachLoadBalancerToSubnetsOutput{}, nil)
+				m.DescribeTags(gomock.AssignableToTypeOf(&elb.DescribeTagsInput{})).
+					Return(&elb.DescribeTagsOutput{
+						TagDescriptions: []*elb.TagDescription{{Tags: converters.MapToELBTags(tags)}},
+					}, nil)
+				m.AddTags(gomock.Eq(&elb.AddTagsInput{
+					LoadBalancerNames: aws.StringSlice([]string{"test-cluster-apiserver"}),
+					Tags: []*elb.Tag{
+						{Key: aws.String(infrav1.NameAWSClusterAPIRole), Value: aws.String(infrav1.APIServerRoleTagValue)},
+					},
+				})).
+					Return(&elb.AddTagsOutput{}, nil)
+				m.RemoveTags(gomock.Eq(&elb.RemoveTagsInput{
+					LoadBalancerNames: aws.StringSlice([]string{"test-cluster-apiserver"}),
+					Tags:              []*elb.TagKeyOnly{{Key: aws.String("removed-additional-tag")}},
+				})).
+					Return(&elb.RemoveTagsOutput{}, nil)
+			},
+			check: func(lb *infrav1.ClassicELB, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if lb.HealthCheck.Timeout != 5*time.Second || lb.Attributes.ConnectionDrainingTimeout != 0 {
+					t.Fatalf("expected the load balancer to match the spec, got %+v", lb)
+				}
+			},
+		},
+		{
+			name: "subnets replaced in the same zones are detached first",
+			expect: func(m *mock_elbiface.MockELBAPIMockRecorder) {
+				desc := classicELBDescription()
+				desc.Subnets = aws.StringSlice([]string{"subnet-old-public-b", "subnet-old-public-a"})
+
+				m.DescribeLoadBalancers(gomock.AssignableToTypeOf(&elb.DescribeLoadBalancersInput{})).
+					Return(&elb.DescribeLoadBalancersOutput{
+						LoadBalancerDescriptions: []*elb.LoadBalancerDescription{desc},
+					}, nil)
+				m.DescribeLoadBalancerAttributes(gomock.AssignableToTypeOf(&elb.DescribeLoadBalancerAttributesInput{})).
+					Return(&elb.DescribeLoadBalancerAttributesOutput{LoadBalancerAttributes: classicELBAttributes()}, nil)
+				gomock.InOrder(
+					m.DetachLoadBalancerFromSubnets(gomock.Eq(&elb.DetachLoadBalancerFromSubnetsInput{
+						LoadBalancerName: aws.String("test-cluster-apiserver"),
+						Subnets:          aws.StringSlice([]string{"subnet-old-public-b", "subnet-old-public-a"}),
+					})).
+						Return(&elb.DetachLoadBalancerFromSubnetsOutput{}, nil),
+					m.AttachLoadBalancerToSubnets(gomock.Eq(&elb.AttachLoadBalancerToSubnetsInput{
+						LoadBalancerName: aws.String("test-cluster-apiserver"),
+						Subnets:          aws.StringSlice([]string{"subnet-public-a", "subnet-public-b"}),
+					})).
+						Return(&elb.AttachLoadBalancerToSubnetsOutput{}, nil),
+				)
+				m.DescribeTags(gomock.AssignableToTypeOf(&elb.DescribeTagsInput{})).
+					Return(&elb.DescribeTagsOutput{
+						TagDescriptions: []*elb.TagDescription{{Tags: converters.MapToELBTags(classicELBTags())}},
+					}, nil)
+			},
+			check: func(lb *infrav1.ClassicELB, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if len(lb.AvailabilityZones) != 2 || lb.AvailabilityZones[0] != "us-east-1a" || lb.AvailabilityZones[1] != "us-east-1b" {
+					t.Fatalf("expected the load balancer to be attached to both zones, got %+v", lb.AvailabilityZones)
+				}
+			},
+		},
+		{
+			name: "subnets replaced in other zones are attached first",
+			expect: func(m *mock_elbiface.MockELBAPIMockRecorder) {
+				desc := classicELBDescription()
+				desc.Subnets = aws.StringSlice([]string{"subnet-public-c"})
+				desc.AvailabilityZones = aws.StringSlice([]string{"us-east-1c"})
+
+				m.DescribeLoadBalancers(gomock.AssignableToTypeOf(&elb.DescribeLoadBalancersInput{})).
+					Return(&elb.DescribeLoadBalancersOutput{
+						LoadBalancerDescriptions: []*elb.LoadBalancerDescription{desc},
+					}, nil)
+				m.DescribeLoadBalancerAttributes(gomock.AssignableToTypeOf(&elb.DescribeLoadBalancerAttributesInput{})).
+					Return(&elb.DescribeLoadBalancerAttributesOutput{LoadBalancerAttributes: classicELBAttributes()}, nil)
+				gomock.InOrder(
+					m.AttachLoadBalancerToSubnets(gomock.Eq(&elb.AttachLoadBalancerToSubnetsInput{
+						LoadBalancerName: aws.String("test-cluster-apiserver"),
+						Subnets:          aws.StringSlice([]string{"subnet-public-a", "subnet-public-b"}),
+					})).
+						Return(&elb.AttachLoadBalancerToSubnetsOutput{}, nil),
+					m.DetachLoadBalancerFromSubnets(gomock.Eq(&elb.DetachLoadBalancerFromSubnetsInput{
+						LoadBalancerName: aws.String("test-cluster-apiserver"),
+						Subnets:          aws.StringSlice([]string{"subnet-public-c"}),
+					})).
+						Return(&elb.DetachLoadBalancerFromSubnetsOutput{}, nil),
+				)
+				m.DescribeTags(gomock.AssignableToTypeOf(&elb.DescribeTagsInput{})).
+					Return(&elb.DescribeTagsOutput{
+						TagDescriptions: []*elb.TagDescription{{Tags: converters.MapToELBTags(classicELBTags())}},
+					}, nil)
+			},
+			check: func(lb *infrav1.ClassicELB, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "existing load balancer with another scheme",
+			expect: func(m *mock_elbiface.MockELBAPIMockRecorder) {
+				desc := classicELBDescription()
+				desc.Scheme = aws.String("internal")
+
+				m.DescribeLoadBalancers(gomock.AssignableToTypeOf(&elb.DescribeLoadBalancersInput{})).
+					Return(&elb.DescribeLoadBalancersOutput{
+						LoadBalancerDescriptions: []*elb.LoadBalancerDescription{desc},
+					}, nil)
+				m.DescribeLoadBalancerAttributes(gomock.AssignableToTypeOf(&elb.DescribeLoadBalancerAttributesInput{})).
+					Return(&elb.DescribeLoadBalancerAttributesOutput{LoadBalancerAttributes: classicELBAttributes()}, nil)
+			},
+			check: func(lb *infrav1.ClassicELB, err error) {
+				if err == nil {
+					t.Fatalf("expected an error but got none.")
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+			cs := newClassicELBTestScope(t, elbMock)
+
+			tc.expect(elbMock.EXPECT())
+
+			s := NewService(cs)
+			lb, err := s.reconcileAPIServerClassicELB()
+			tc.check(lb, err)
+		})
+	}
+}
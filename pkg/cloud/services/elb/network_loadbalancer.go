@@ -0,0 +1,441 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/wait"
+)
+
+const (
+	// targetGroupResourceType is the resource groups tagging API identifier for elbv2 target groups.
+	targetGroupResourceType = "elasticloadbalancing:targetgroup"
+
+	// crossZoneAttribute is the load balancer attribute enabling cross-zone load balancing.
+	crossZoneAttribute = "load_balancing.cross_zone.enabled"
+
+	// apiServerTargetPort is the port the API server listens on in the control plane instances.
+	apiServerTargetPort = 6443
+)
+
+// reconcileAPIServerNLB reconciles the network load balancer, target group and listener
+// fronting the API server.
+func (s *Service) reconcileAPIServerNLB() (*infrav1.ClassicELB, error) {
+	spec := s.getAPIServerNLBSpec()
+
+	// Describe or create.
+	apiNLB, err := s.describeNLB(spec.Name)
+	if IsNotFound(err) {
+		apiNLB, err = s.createNLB(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		s.scope.V(2).Info("Created new network load balancer for apiserver", "api-server-elb-name", apiNLB.Name)
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Reconciliate the subnets from the spec and the ones currently attached to the load balancer.
+	current := append([]string{}, apiNLB.SubnetIDs...)
+	desired := append([]string{}, spec.SubnetIDs...)
+	sort.Strings(current)
+	sort.Strings(desired)
+	if strings.Join(current, ",") != strings.Join(desired, ",") {
+		if _, err := s.scope.ELBV2.SetSubnets(&elbv2.SetSubnetsInput{
+			LoadBalancerArn: aws.String(apiNLB.ARN),
+			Subnets:         aws.StringSlice(spec.SubnetIDs),
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to set subnets of apiserver load balancer %q", apiNLB.Name)
+		}
+		apiNLB.SubnetIDs = spec.SubnetIDs
+	}
+
+	// Make sure the target group and listener exist, they might be missing
+	// if a previous reconciliation failed half-way through.
+	targetGroupARN, err := s.reconcileAPIServerTargetGroup(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reconcileAPIServerListener(apiNLB.ARN, targetGroupARN); err != nil {
+		return nil, err
+	}
+
+	apiNLB.Listeners = spec.Listeners
+	apiNLB.HealthCheck = spec.HealthCheck
+	return apiNLB, nil
+}
+
+func (s *Service) getAPIServerNLBSpec() *infrav1.ClassicELB {
+	res := &infrav1.ClassicELB{
+		Name:             GenerateELBName(s.scope.Name(), infrav1.APIServerRoleTagValue),
+		Scheme:           s.scope.ControlPlaneLoadBalancerScheme(),
+		LoadBalancerType: infrav1.LoadBalancerTypeNetwork,
+		Listeners: []*infrav1.ClassicELBListener{
+			{
+				Protocol:         infrav1.ClassicELBProtocolTCP,
+				Port:             s.scope.APIServerPort(),
+				InstanceProtocol: infrav1.ClassicELBProtocolTCP,
+				InstancePort:     apiServerTargetPort,
+			},
+		},
+		HealthCheck: &infrav1.ClassicELBHealthCheck{
+			Target:             fmt.Sprintf("%v:%d", infrav1.ClassicELBProtocolTCP, apiServerTargetPort),
+			Interval:           10 * time.Second,
+			HealthyThreshold:   3,
+			UnhealthyThreshold: 3,
+		},
+	}
+
+	res.Tags = s.apiServerLoadBalancerTags()
+	res.SubnetIDs = s.apiServerLoadBalancerSubnets()
+
+	return res
+}
+
+func (s *Service) createNLB(spec *infrav1.ClassicELB) (*infrav1.ClassicELB, error) {
+	input := &elbv2.CreateLoadBalancerInput{
+		Name:    aws.String(spec.Name),
+		Type:    aws.String(elbv2.LoadBalancerTypeEnumNetwork),
+		Scheme:  aws.String(strings.ToLower(string(spec.Scheme))),
+		Subnets: aws.StringSlice(spec.SubnetIDs),
+		Tags:    converters.MapToELBV2Tags(spec.Tags),
+	}
+
+	out, err := s.scope.ELBV2.CreateLoadBalancer(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create network load balancer: %v", spec)
+	}
+
+	if len(out.LoadBalancers) == 0 {
+		return nil, errors.Errorf("no network load balancer returned for %q", spec.Name)
+	}
+
+	res := fromSDKTypeToNLB(out.LoadBalancers[0])
+	res.Tags = spec.Tags
+
+	// Network load balancers don't balance across zones by default.
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if _, err := s.scope.ELBV2.ModifyLoadBalancerAttributes(&elbv2.ModifyLoadBalancerAttributesInput{
+			LoadBalancerArn: aws.String(res.ARN),
+			Attributes: []*elbv2.LoadBalancerAttribute{
+				{
+					Key:   aws.String(crossZoneAttribute),
+					Value: aws.String("true"),
+				},
+			},
+		}); err != nil {
+			return false, err
+		}
+		return true, nil
+	}, awserrors.LoadBalancerNotFound); err != nil {
+		return nil, errors.Wrapf(err, "failed to configure attributes for network load balancer: %v", spec.Name)
+	}
+
+	s.scope.V(2).Info("Created network load balancer", "dns-name", res.DNSName)
+	return res, nil
+}
+
+// reconcileAPIServerTargetGroup ensures the API server target group exists and returns its ARN.
+func (s *Service) reconcileAPIServerTargetGroup(spec *infrav1.ClassicELB) (string, error) {
+	tg, err := s.describeTargetGroup(spec.Name)
+	if err != nil && !IsNotFound(err) {
+		return "", err
+	}
+	if tg != nil {
+		if err := s.reconcileAPIServerTargetGroupHealthCheck(tg, spec.HealthCheck); err != nil {
+			return "", err
+		}
+		return aws.StringValue(tg.TargetGroupArn), nil
+	}
+
+	input := &elbv2.CreateTargetGroupInput{
+		Name:                       aws.String(spec.Name),
+		Port:                       aws.Int64(apiServerTargetPort),
+		Protocol:                   aws.String(elbv2.ProtocolEnumTcp),
+		TargetType:                 aws.String(elbv2.TargetTypeEnumInstance),
+		VpcId:                      aws.String(s.scope.VPC().ID),
+		HealthCheckProtocol:        aws.String(elbv2.ProtocolEnumTcp),
+		HealthCheckPort:            aws.String(fmt.Sprintf("%d", apiServerTargetPort)),
+		HealthCheckIntervalSeconds: aws.Int64(int64(spec.HealthCheck.Interval.Seconds())),
+		HealthyThresholdCount:      aws.Int64(spec.HealthCheck.HealthyThreshold),
+		UnhealthyThresholdCount:    aws.Int64(spec.HealthCheck.UnhealthyThreshold),
+	}
+
+	out, err := s.scope.ELBV2.CreateTargetGroup(input)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create target group for network load balancer %q", spec.Name)
+	}
+
+	if len(out.TargetGroups) == 0 {
+		return "", errors.Errorf("no target group returned for %q", spec.Name)
+	}
+
+	arn := aws.StringValue(out.TargetGroups[0].TargetGroupArn)
+	if _, err := s.scope.ELBV2.AddTags(&elbv2.AddTagsInput{
+		ResourceArns: aws.StringSlice([]string{arn}),
+		Tags:         converters.MapToELBV2Tags(spec.Tags),
+	}); err != nil {
+		return "", errors.Wrapf(err, "failed to tag target group %q", arn)
+	}
+
+	s.scope.V(2).Info("Created target group for network load balancer", "arn", arn)
+	return arn, nil
+}
+
+// reconcileAPIServerTargetGroupHealthCheck restores the health check of an
+// existing target group. The port and protocol of a target group cannot be
+// modified, the target group has to be deleted to change them.
+func (s *Service) reconcileAPIServerTargetGroupHealthCheck(tg *elbv2.TargetGroup, healthCheck *infrav1.ClassicELBHealthCheck) error {
+	arn := aws.StringValue(tg.TargetGroupArn)
+	if aws.Int64Value(tg.Port) != apiServerTargetPort || aws.StringValue(tg.Protocol) != elbv2.ProtocolEnumTcp {
+		return errors.Errorf("target group %q forwards to %s:%d instead of %s:%d, it must be deleted to be recreated",
+			arn, aws.StringValue(tg.Protocol), aws.Int64Value(tg.Port), elbv2.ProtocolEnumTcp, apiServerTargetPort)
+	}
+
+	port := fmt.Sprintf("%d", apiServerTargetPort)
+	interval := int64(healthCheck.Interval.Seconds())
+	if aws.StringValue(tg.HealthCheckProtocol) == elbv2.ProtocolEnumTcp &&
+		aws.StringValue(tg.HealthCheckPort) == port &&
+		aws.Int64Value(tg.HealthCheckIntervalSeconds) == interval &&
+		aws.Int64Value(tg.HealthyThresholdCount) == healthCheck.HealthyThreshold &&
+		aws.Int64Value(tg.UnhealthyThresholdCount) == healthCheck.UnhealthyThreshold {
+		return nil
+	}
+
+	if _, err := s.scope.ELBV2.ModifyTargetGroup(&elbv2.ModifyTargetGroupInput{
+		TargetGroupArn:             aws.String(arn),
+		HealthCheckProtocol:        aws.String(elbv2.ProtocolEnumTcp),
+		HealthCheckPort:            aws.String(port),
+		HealthCheckIntervalSeconds: aws.Int64(interval),
+		HealthyThresholdCount:      aws.Int64(healthCheck.HealthyThreshold),
+		UnhealthyThresholdCount:    aws.Int64(healthCheck.UnhealthyThreshold),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to modify health check of target group %q", arn)
+	}
+
+	return nil
+}
+
+// reconcileAPIServerListener ensures the load balancer has a TCP listener on the
+// API server port forwarding to the target group. An existing listener on
+// another port or with another action is modified rather than replaced.
+func (s *Service) reconcileAPIServerListener(loadBalancerARN, targetGroupARN string) error {
+	out, err := s.scope.ELBV2.DescribeListeners(&elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(loadBalancerARN),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe listeners of network load balancer %q", loadBalancerARN)
+	}
+
+	actions := []*elbv2.Action{
+		{
+			Type:           aws.String(elbv2.ActionTypeEnumForward),
+			TargetGroupArn: aws.String(targetGroupARN),
+		},
+	}
+
+	// Prefer the listener on the API server port, then the one forwarding to
+	// the target group, which was created for a previous API server port.
+	var existing *elbv2.Listener
+	for _, ln := range out.Listeners {
+		if aws.Int64Value(ln.Port) == s.scope.APIServerPort() {
+			existing = ln
+			break
+		}
+		if existing == nil && forwardsTo(ln, targetGroupARN) {
+			existing = ln
+		}
+	}
+
+	if existing == nil {
+		input := &elbv2.CreateListenerInput{
+			LoadBalancerArn: aws.String(loadBalancerARN),
+			Port:            aws.Int64(s.scope.APIServerPort()),
+			Protocol:        aws.String(elbv2.ProtocolEnumTcp),
+			DefaultActions:  actions,
+		}
+
+		if _, err := s.scope.ELBV2.CreateListener(input); err != nil {
+			return errors.Wrapf(err, "failed to create listener for network load balancer %q", loadBalancerARN)
+		}
+		return nil
+	}
+
+	if aws.Int64Value(existing.Port) == s.scope.APIServerPort() &&
+		aws.StringValue(existing.Protocol) == elbv2.ProtocolEnumTcp &&
+		forwardsTo(existing, targetGroupARN) {
+		return nil
+	}
+
+	if _, err := s.scope.ELBV2.ModifyListener(&elbv2.ModifyListenerInput{
+		ListenerArn:    existing.ListenerArn,
+		Port:           aws.Int64(s.scope.APIServerPort()),
+		Protocol:       aws.String(elbv2.ProtocolEnumTcp),
+		DefaultActions: actions,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to modify listener of network load balancer %q", loadBalancerARN)
+	}
+
+	return nil
+}
+
+// forwardsTo returns true if the listener only forwards to the given target group.
+func forwardsTo(ln *elbv2.Listener, targetGroupARN string) bool {
+	return len(ln.DefaultActions) == 1 &&
+		aws.StringValue(ln.DefaultActions[0].Type) == elbv2.ActionTypeEnumForward &&
+		aws.StringValue(ln.DefaultActions[0].TargetGroupArn) == targetGroupARN
+}
+
+func (s *Service) registerInstanceWithAPIServerNLB(i *infrav1.Instance) error {
+	name := GenerateELBName(s.scope.Name(), infrav1.APIServerRoleTagValue)
+	out, err := s.describeNLB(name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateInstanceZone(name, out.SubnetIDs, i); err != nil {
+		return err
+	}
+
+	tg, err := s.describeTargetGroup(name)
+	if err != nil {
+		return err
+	}
+
+	input := &elbv2.RegisterTargetsInput{
+		TargetGroupArn: tg.TargetGroupArn,
+		Targets: []*elbv2.TargetDescription{
+			{
+				Id:   aws.String(i.ID),
+				Port: aws.Int64(apiServerTargetPort),
+			},
+		},
+	}
+
+	if _, err := s.scope.ELBV2.RegisterTargets(input); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) describeNLB(name string) (*infrav1.ClassicELB, error) {
+	input := &elbv2.DescribeLoadBalancersInput{
+		Names: aws.StringSlice([]string{name}),
+	}
+
+	out, err := s.scope.ELBV2.DescribeLoadBalancers(input)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, NewNotFound(errors.Errorf("no network load balancer found with name %q", name))
+		}
+		return nil, errors.Wrapf(err, "failed to describe network load balancer: %s", name)
+	}
+
+	if len(out.LoadBalancers) == 0 {
+		return nil, NewNotFound(errors.Errorf("no network load balancer found with name %q", name))
+	}
+
+	if s.scope.VPC().ID != "" && s.scope.VPC().ID != aws.StringValue(out.LoadBalancers[0].VpcId) {
+		return nil, errors.Errorf(
+			"ELB names must be unique within a region: %q ELB already exists in this region in VPC %q",
+			name, aws.StringValue(out.LoadBalancers[0].VpcId))
+	}
+
+	return fromSDKTypeToNLB(out.LoadBalancers[0]), nil
+}
+
+func (s *Service) describeTargetGroup(name string) (*elbv2.TargetGroup, error) {
+	out, err := s.scope.ELBV2.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		Names: aws.StringSlice([]string{name}),
+	})
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, NewNotFound(errors.Errorf("no target group found with name %q", name))
+		}
+		return nil, errors.Wrapf(err, "failed to describe target group: %s", name)
+	}
+
+	if len(out.TargetGroups) == 0 {
+		return nil, NewNotFound(errors.Errorf("no target group found with name %q", name))
+	}
+
+	return out.TargetGroups[0], nil
+}
+
+func (s *Service) deleteNLB(arn string) error {
+	input := &elbv2.DeleteLoadBalancerInput{
+		LoadBalancerArn: aws.String(arn),
+	}
+
+	if _, err := s.scope.ELBV2.DeleteLoadBalancer(input); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Service) deleteOwnedTargetGroups() error {
+	arns, err := s.listOwned(targetGroupResourceType)
+	if err != nil {
+		return err
+	}
+
+	for _, arn := range arns {
+		s.scope.V(3).Info("deleting target group", "arn", arn)
+		if _, err := s.scope.ELBV2.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{
+			TargetGroupArn: aws.String(arn),
+		}); err != nil && !IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete target group %q", arn)
+		}
+	}
+
+	return nil
+}
+
+func fromSDKTypeToNLB(v *elbv2.LoadBalancer) *infrav1.ClassicELB {
+	res := &infrav1.ClassicELB{
+		Name:             aws.StringValue(v.LoadBalancerName),
+		ARN:              aws.StringValue(v.LoadBalancerArn),
+		DNSName:          aws.StringValue(v.DNSName),
+		LoadBalancerType: infrav1.LoadBalancerTypeNetwork,
+	}
+
+	// The elbv2 APIs report the scheme in lower case.
+	if aws.StringValue(v.Scheme) == elbv2.LoadBalancerSchemeEnumInternetFacing {
+		res.Scheme = infrav1.ClassicELBSchemeInternetFacing
+	} else {
+		res.Scheme = infrav1.ClassicELBSchemeInternal
+	}
+
+	for _, az := range v.AvailabilityZones {
+		res.SubnetIDs = append(res.SubnetIDs, aws.StringValue(az.SubnetId))
+	}
+
+	return res
+}
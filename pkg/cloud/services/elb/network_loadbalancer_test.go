@@ -0,0 +1,318 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb/mock_elbv2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func newNLBTestScope(t *testing.T, elbv2Mock *mock_elbv2iface.MockELBV2API) *scope.ClusterScope {
+	internal := infrav1.ClassicELBSchemeInternal
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+		AWSClients: scope.AWSClients{
+			ELBV2: elbv2Mock,
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					Scheme:           &internal,
+					LoadBalancerType: infrav1.LoadBalancerTypeNetwork,
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						ID: "vpc-1",
+					},
+					Subnets: infrav1.Subnets{
+						{ID: "subnet-private-a", AvailabilityZone: "us-east-1a"},
+						{ID: "subnet-private-b", AvailabilityZone: "us-east-1b"},
+						{ID: "subnet-public-a", AvailabilityZone: "us-east-1a", IsPublic: true},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	return cs
+}
+
+func TestReconcileAPIServerNLB(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name   string
+		expect func(m *mock_elbv2iface.MockELBV2APIMockRecorder)
+		check  func(lb *infrav1.ClassicELB, err error)
+	}{
+		{
+			name: "creates load balancer, target group and listener",
+			expect: func(m *mock_elbv2iface.MockELBV2APIMockRecorder) {
+				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+					Names: aws.StringSlice([]string{"test-cluster-apiserver"}),
+				})).
+					Return(nil, awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found", nil))
+				m.CreateLoadBalancer(gomock.AssignableToTypeOf(&elbv2.CreateLoadBalancerInput{})).
+					Do(func(input *elbv2.CreateLoadBalancerInput) {
+						if aws.StringValue(input.Type) != elbv2.LoadBalancerTypeEnumNetwork {
+							t.Fatalf("expected a network load balancer, got %q", aws.StringValue(input.Type))
+						}
+						if aws.StringValue(input.Scheme) != elbv2.LoadBalancerSchemeEnumInternal {
+							t.Fatalf("expected an internal scheme, got %q", aws.StringValue(input.Scheme))
+						}
+					}).
+					Return(&elbv2.CreateLoadBalancerOutput{
+						LoadBalancers: []*elbv2.LoadBalancer{
+							{
+								LoadBalancerArn:  aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/test-cluster-apiserver/1"),
+								LoadBalancerName: aws.String("test-cluster-apiserver"),
+								DNSName:          aws.String("nlb.example.com"),
+								Scheme:           aws.String(elbv2.LoadBalancerSchemeEnumInternal),
+								VpcId:            aws.String("vpc-1"),
+								AvailabilityZones: []*elbv2.AvailabilityZone{
+									{SubnetId: aws.String("subnet-private-a")},
+									{SubnetId: aws.String("subnet-private-b")},
+								},
+							},
+						},
+					}, nil)
+				m.ModifyLoadBalancerAttributes(gomock.AssignableToTypeOf(&elbv2.ModifyLoadBalancerAttributesInput{})).
+					Return(&elbv2.ModifyLoadBalancerAttributesOutput{}, nil)
+				m.DescribeTargetGroups(gomock.Eq(&elbv2.DescribeTargetGroupsInput{
+					Names: aws.StringSlice([]string{"test-cluster-apiserver"}),
+				})).
+					Return(nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil))
+				m.CreateTargetGroup(gomock.AssignableToTypeOf(&elbv2.CreateTargetGroupInput{})).
+					Do(func(input *elbv2.CreateTargetGroupInput) {
+						if aws.Int64Value(input.Port) != 6443 || aws.StringValue(input.HealthCheckPort) != "6443" {
+							t.Fatalf("expected target group and health check on port 6443, got %v", input)
+						}
+					}).
+					Return(&elbv2.CreateTargetGroupOutput{
+						TargetGroups: []*elbv2.TargetGroup{
+							{TargetGroupArn: aws.String("arn:tg")},
+						},
+					}, nil)
+				m.AddTags(gomock.AssignableToTypeOf(&elbv2.AddTagsInput{})).
+					Return(&elbv2.AddTagsOutput{}, nil)
+				m.DescribeListeners(gomock.AssignableToTypeOf(&elbv2.DescribeListenersInput{})).
+					Return(&elbv2.DescribeListenersOutput{}, nil)
+				m.CreateListener(gomock.AssignableToTypeOf(&elbv2.CreateListenerInput{})).
+					Do(func(input *elbv2.CreateListenerInput) {
+						if aws.StringValue(input.DefaultActions[0].TargetGroupArn) != "arn:tg" {
+							t.Fatalf("expected listener to forward to target group, got %v", input.DefaultActions)
+						}
+					}).
+					Return(&elbv2.CreateListenerOutput{}, nil)
+			},
+			check: func(lb *infrav1.ClassicELB, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if lb.DNSName != "nlb.example.com" || lb.LoadBalancerType != infrav1.LoadBalancerTypeNetwork {
+					t.Fatalf("unexpected load balancer: %+v", lb)
+				}
+
+				if lb.Scheme != infrav1.ClassicELBSchemeInternal {
+					t.Fatalf("expected internal scheme, got %q", lb.Scheme)
+				}
+			},
+		},
+		{
+			name: "existing load balancer with drifted health check and listener port",
+			expect: func(m *mock_elbv2iface.MockELBV2APIMockRecorder) {
+				m.DescribeLoadBalancers(gomock.AssignableToTypeOf(&elbv2.DescribeLoadBalancersInput{})).
+					Return(&elbv2.DescribeLoadBalancersOutput{
+						LoadBalancers: []*elbv2.LoadBalancer{
+							{
+								LoadBalancerArn:  aws.String("arn:nlb"),
+								LoadBalancerName: aws.String("test-cluster-apiserver"),
+								DNSName:          aws.String("nlb.example.com"),
+								Scheme:           aws.String(elbv2.LoadBalancerSchemeEnumInternal),
+								VpcId:            aws.String("vpc-1"),
+								AvailabilityZones: []*elbv2.AvailabilityZone{
+									{SubnetId: aws.String("subnet-private-a")},
+									{SubnetId: aws.String("subnet-private-b")},
+								},
+							},
+						},
+					}, nil)
+				m.DescribeTargetGroups(gomock.AssignableToTypeOf(&elbv2.DescribeTargetGroupsInput{})).
+					Return(&elbv2.DescribeTargetGroupsOutput{
+						TargetGroups: []*elbv2.TargetGroup{
+							{
+								TargetGroupArn:             aws.String("arn:tg"),
+								Port:                       aws.Int64(6443),
+								Protocol:                   aws.String(elbv2.ProtocolEnumTcp),
+								HealthCheckProtocol:        aws.String(elbv2.ProtocolEnumTcp),
+								HealthCheckPort:            aws.String("6443"),
+								HealthCheckIntervalSeconds: aws.Int64(30),
+								HealthyThresholdCount:      aws.Int64(3),
+								UnhealthyThresholdCount:    aws.Int64(3),
+							},
+						},
+					}, nil)
+				m.ModifyTargetGroup(gomock.Eq(&elbv2.ModifyTargetGroupInput{
+					TargetGroupArn:             aws.String("arn:tg"),
+					HealthCheckProtocol:        aws.String(elbv2.ProtocolEnumTcp),
+					HealthCheckPort:            aws.String("6443"),
+					HealthCheckIntervalSeconds: aws.Int64(10),
+					HealthyThresholdCount:      aws.Int64(3),
+					UnhealthyThresholdCount:    aws.Int64(3),
+				})).
+					Return(&elbv2.ModifyTargetGroupOutput{}, nil)
+				m.DescribeListeners(gomock.AssignableToTypeOf(&elbv2.DescribeListenersInput{})).
+					Return(&elbv2.DescribeListenersOutput{
+						Listeners: []*elbv2.Listener{
+							{
+								ListenerArn: aws.String("arn:listener"),
+								Port:        aws.Int64(443),
+								Protocol:    aws.String(elbv2.ProtocolEnumTcp),
+								DefaultActions: []*elbv2.Action{
+									{Type: aws.String(elbv2.ActionTypeEnumForward), TargetGroupArn: aws.String("arn:tg")},
+								},
+							},
+						},
+					}, nil)
+				m.ModifyListener(gomock.AssignableToTypeOf(&elbv2.ModifyListenerInput{})).
+					Do(func(input *elbv2.ModifyListenerInput) {
+						if aws.StringValue(input.ListenerArn) != "arn:listener" || aws.Int64Value(input.Port) != 6443 {
+							t.Fatalf("expected the listener to be moved to port 6443, got %v", input)
+						}
+					}).
+					Return(&elbv2.ModifyListenerOutput{}, nil)
+			},
+			check: func(lb *infrav1.ClassicELB, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "existing load balancer in another vpc",
+			expect: func(m *mock_elbv2iface.MockELBV2APIMockRecorder) {
+				m.DescribeLoadBalancers(gomock.AssignableToTypeOf(&elbv2.DescribeLoadBalancersInput{})).
+					Return(&elbv2.DescribeLoadBalancersOutput{
+						LoadBalancers: []*elbv2.LoadBalancer{
+							{
+								LoadBalancerName: aws.String("test-cluster-apiserver"),
+								VpcId:            aws.String("vpc-other"),
+							},
+						},
+					}, nil)
+			},
+			check: func(lb *infrav1.ClassicELB, err error) {
+				if err == nil {
+					t.Fatalf("expected an error but got none.")
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			elbv2Mock := mock_elbv2iface.NewMockELBV2API(mockCtrl)
+			cs := newNLBTestScope(t, elbv2Mock)
+
+			tc.expect(elbv2Mock.EXPECT())
+
+			s := NewService(cs)
+			lb, err := s.reconcileAPIServerNLB()
+			tc.check(lb, err)
+		})
+	}
+}
+
+func TestRegisterInstanceWithAPIServerNLB(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	elbv2Mock := mock_elbv2iface.NewMockELBV2API(mockCtrl)
+	cs := newNLBTestScope(t, elbv2Mock)
+
+	elbv2Mock.EXPECT().DescribeLoadBalancers(gomock.AssignableToTypeOf(&elbv2.DescribeLoadBalancersInput{})).
+		Return(&elbv2.DescribeLoadBalancersOutput{
+			LoadBalancers: []*elbv2.LoadBalancer{
+				{
+					LoadBalancerName: aws.String("test-cluster-apiserver"),
+					VpcId:            aws.String("vpc-1"),
+					AvailabilityZones: []*elbv2.AvailabilityZone{
+						{SubnetId: aws.String("subnet-private-a")},
+					},
+				},
+			},
+		}, nil)
+	elbv2Mock.EXPECT().DescribeTargetGroups(gomock.AssignableToTypeOf(&elbv2.DescribeTargetGroupsInput{})).
+		Return(&elbv2.DescribeTargetGroupsOutput{
+			TargetGroups: []*elbv2.TargetGroup{
+				{TargetGroupArn: aws.String("arn:tg")},
+			},
+		}, nil)
+	elbv2Mock.EXPECT().RegisterTargets(gomock.Eq(&elbv2.RegisterTargetsInput{
+		TargetGroupArn: aws.String("arn:tg"),
+		Targets: []*elbv2.TargetDescription{
+			{Id: aws.String("i-1"), Port: aws.Int64(6443)},
+		},
+	})).
+		Return(&elbv2.RegisterTargetsOutput{}, nil)
+
+	s := NewService(cs)
+	if err := s.RegisterInstanceWithAPIServerELB(&infrav1.Instance{ID: "i-1", SubnetID: "subnet-private-a"}); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+}
+
+func TestIsELBV2ARN(t *testing.T) {
+	testCases := []struct {
+		arn      string
+		expected bool
+		name     string
+	}{
+		{
+			arn:      "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/test-cluster-apiserver",
+			expected: false,
+			name:     "test-cluster-apiserver",
+		},
+		{
+			arn:      "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/test-cluster-apiserver/50dc6c495c0c9188",
+			expected: true,
+			name:     "50dc6c495c0c9188",
+		},
+	}
+
+	for _, tc := range testCases {
+		if actual := isELBV2ARN(tc.arn); actual != tc.expected {
+			t.Errorf("expected isELBV2ARN(%q) to be %v, got %v", tc.arn, tc.expected, actual)
+		}
+		if actual := nameFromARN(tc.arn); actual != tc.name {
+			t.Errorf("expected nameFromARN(%q) to be %q, got %q", tc.arn, tc.name, actual)
+		}
+	}
+}
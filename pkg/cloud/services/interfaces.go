@@ -35,5 +35,25 @@ type EC2MachineInterface interface {
 	UpdateResourceTags(resourceID *string, create map[string]string, remove map[string]string) error
 
 	TerminateInstanceAndWait(instanceID string) error
+	CancelSpotInstanceRequest(requestID string) error
 	DetachSecurityGroupsFromNetworkInterface(groups []string, interfaceID string) error
 }
+
+// EC2MachinePoolInterface encapsulates the EC2 methods exposed to the
+// machine pool reconciler
+type EC2MachinePoolInterface interface {
+	ReconcileLaunchTemplate(scope *scope.MachinePoolScope) (*infrav1.LaunchTemplate, error)
+	GetLaunchTemplate(name string) (*infrav1.LaunchTemplate, error)
+	DeleteLaunchTemplate(id string) error
+}
+
+// ASGInterface encapsulates the Auto Scaling methods exposed to the
+// machine pool reconciler
+type ASGInterface interface {
+	ASGIfExists(name *string) (*infrav1.AutoScalingGroup, error)
+	GetASGByName(scope *scope.MachinePoolScope) (*infrav1.AutoScalingGroup, error)
+	CreateASG(scope *scope.MachinePoolScope, launchTemplateID string) (*infrav1.AutoScalingGroup, error)
+	UpdateASG(scope *scope.MachinePoolScope, launchTemplateID string) error
+	ASGNeedsUpdate(scope *scope.MachinePoolScope, asg *infrav1.AutoScalingGroup, launchTemplateID string) (bool, error)
+	DeleteASGAndWait(name string) error
+}
@@ -17,4 +17,8 @@ limitations under the License.
 // Run go generate to regenerate this mock.
 //go:generate ../../../../hack/tools/bin/mockgen -destination ec2_machine_interface_mock.go -package mock_services sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services EC2MachineInterface
 //go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt ec2_machine_interface_mock.go > _ec2_machine_interface_mock.go && mv _ec2_machine_interface_mock.go ec2_machine_interface_mock.go"
+//go:generate ../../../../hack/tools/bin/mockgen -destination ec2_machinepool_interface_mock.go -package mock_services sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services EC2MachinePoolInterface
+//go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt ec2_machinepool_interface_mock.go > _ec2_machinepool_interface_mock.go && mv _ec2_machinepool_interface_mock.go ec2_machinepool_interface_mock.go"
+//go:generate ../../../../hack/tools/bin/mockgen -destination asg_interface_mock.go -package mock_services sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services ASGInterface
+//go:generate /usr/bin/env bash -c "cat ../../../../hack/boilerplate/boilerplate.generatego.txt asg_interface_mock.go > _asg_interface_mock.go && mv _asg_interface_mock.go asg_interface_mock.go"
 package mock_services //nolint
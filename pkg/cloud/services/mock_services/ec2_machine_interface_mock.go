@@ -50,6 +50,20 @@ func (m *MockEC2MachineInterface) EXPECT() *MockEC2MachineInterfaceMockRecorder
 	return m.recorder
 }
 
+// CancelSpotInstanceRequest mocks base method
+func (m *MockEC2MachineInterface) CancelSpotInstanceRequest(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSpotInstanceRequest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSpotInstanceRequest indicates an expected call of CancelSpotInstanceRequest
+func (mr *MockEC2MachineInterfaceMockRecorder) CancelSpotInstanceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSpotInstanceRequest", reflect.TypeOf((*MockEC2MachineInterface)(nil).CancelSpotInstanceRequest), arg0)
+}
+
 // CreateInstance mocks base method
 func (m *MockEC2MachineInterface) CreateInstance(arg0 *scope.MachineScope) (*v1alpha3.Instance, error) {
 	m.ctrl.T.Helper()
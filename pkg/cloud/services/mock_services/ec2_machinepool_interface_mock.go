@@ -0,0 +1,95 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services (interfaces: EC2MachinePoolInterface)
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	v1alpha3 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	scope "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+)
+
+// MockEC2MachinePoolInterface is a mock of EC2MachinePoolInterface interface
+type MockEC2MachinePoolInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEC2MachinePoolInterfaceMockRecorder
+}
+
+// MockEC2MachinePoolInterfaceMockRecorder is the mock recorder for MockEC2MachinePoolInterface
+type MockEC2MachinePoolInterfaceMockRecorder struct {
+	mock *MockEC2MachinePoolInterface
+}
+
+// NewMockEC2MachinePoolInterface creates a new mock instance
+func NewMockEC2MachinePoolInterface(ctrl *gomock.Controller) *MockEC2MachinePoolInterface {
+	mock := &MockEC2MachinePoolInterface{ctrl: ctrl}
+	mock.recorder = &MockEC2MachinePoolInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockEC2MachinePoolInterface) EXPECT() *MockEC2MachinePoolInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteLaunchTemplate mocks base method
+func (m *MockEC2MachinePoolInterface) DeleteLaunchTemplate(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLaunchTemplate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLaunchTemplate indicates an expected call of DeleteLaunchTemplate
+func (mr *MockEC2MachinePoolInterfaceMockRecorder) DeleteLaunchTemplate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLaunchTemplate", reflect.TypeOf((*MockEC2MachinePoolInterface)(nil).DeleteLaunchTemplate), arg0)
+}
+
+// GetLaunchTemplate mocks base method
+func (m *MockEC2MachinePoolInterface) GetLaunchTemplate(arg0 string) (*v1alpha3.LaunchTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLaunchTemplate", arg0)
+	ret0, _ := ret[0].(*v1alpha3.LaunchTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLaunchTemplate indicates an expected call of GetLaunchTemplate
+func (mr *MockEC2MachinePoolInterfaceMockRecorder) GetLaunchTemplate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLaunchTemplate", reflect.TypeOf((*MockEC2MachinePoolInterface)(nil).GetLaunchTemplate), arg0)
+}
+
+// ReconcileLaunchTemplate mocks base method
+func (m *MockEC2MachinePoolInterface) ReconcileLaunchTemplate(arg0 *scope.MachinePoolScope) (*v1alpha3.LaunchTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileLaunchTemplate", arg0)
+	ret0, _ := ret[0].(*v1alpha3.LaunchTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileLaunchTemplate indicates an expected call of ReconcileLaunchTemplate
+func (mr *MockEC2MachinePoolInterfaceMockRecorder) ReconcileLaunchTemplate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileLaunchTemplate", reflect.TypeOf((*MockEC2MachinePoolInterface)(nil).ReconcileLaunchTemplate), arg0)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sts
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/sts"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+const (
+	// DefaultSessionName is the role session name used when none is specified.
+	DefaultSessionName = "cluster-api-provider-aws"
+
+	// expiryWindow is how long before expiry assumed role credentials are renewed.
+	expiryWindow = 5 * time.Minute
+)
+
+// assumeRolerFactory returns a client able to assume roles using the given
+// source credentials, or the default credentials when nil.
+type assumeRolerFactory func(source *credentials.Credentials) stscreds.AssumeRoler
+
+// NewAssumeRoleCredentials returns credentials for the role identity. Each of
+// the source roles is assumed in order before the target role, and every link
+// of the chain is renewed automatically before it expires.
+func NewAssumeRoleCredentials(p client.ConfigProvider, identity *infrav1.AWSRoleIdentity) *credentials.Credentials {
+	return newAssumeRoleCredentials(func(source *credentials.Credentials) stscreds.AssumeRoler {
+		if source == nil {
+			return sts.New(p)
+		}
+		return sts.New(p, aws.NewConfig().WithCredentials(source))
+	}, identity)
+}
+
+func newAssumeRoleCredentials(newClient assumeRolerFactory, identity *infrav1.AWSRoleIdentity) *credentials.Credentials {
+	var creds *credentials.Credentials
+
+	chain := make([]infrav1.AWSRoleSpec, 0, len(identity.SourceRoles)+1)
+	chain = append(chain, identity.SourceRoles...)
+	chain = append(chain, identity.AWSRoleSpec)
+
+	for i := range chain {
+		creds = assumeRole(newClient(creds), chain[i])
+	}
+
+	return creds
+}
+
+func assumeRole(svc stscreds.AssumeRoler, role infrav1.AWSRoleSpec) *credentials.Credentials {
+	return stscreds.NewCredentialsWithClient(svc, role.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = DefaultSessionName
+		if role.SessionName != "" {
+			p.RoleSessionName = role.SessionName
+		}
+
+		if role.ExternalID != "" {
+			p.ExternalID = aws.String(role.ExternalID)
+		}
+
+		if role.DurationSeconds > 0 {
+			p.Duration = time.Duration(role.DurationSeconds) * time.Second
+		}
+
+		if len(role.SessionTags) > 0 {
+			keys := make([]string, 0, len(role.SessionTags))
+			for k := range role.SessionTags {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				p.Tags = append(p.Tags, &sts.Tag{Key: aws.String(k), Value: aws.String(role.SessionTags[k])})
+			}
+		}
+
+		if len(role.TransitiveTagKeys) > 0 {
+			p.TransitiveTagKeys = aws.StringSlice(role.TransitiveTagKeys)
+		}
+
+		p.ExpiryWindow = expiryWindow
+	})
+}
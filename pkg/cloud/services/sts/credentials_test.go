@@ -0,0 +1,159 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sts
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/sts"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+type fakeAssumeRoler struct {
+	source     *credentials.Credentials
+	expiration time.Duration
+	inputs     []*sts.AssumeRoleInput
+}
+
+func (f *fakeAssumeRoler) AssumeRole(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
+	// Signing the request resolves the source credentials, assuming the
+	// previous role of the chain.
+	if f.source != nil {
+		if _, err := f.source.Get(); err != nil {
+			return nil, err
+		}
+	}
+
+	f.inputs = append(f.inputs, input)
+	return &sts.AssumeRoleOutput{
+		Credentials: &sts.Credentials{
+			AccessKeyId:     input.RoleArn,
+			SecretAccessKey: aws.String("secret"),
+			SessionToken:    aws.String("token"),
+			Expiration:      aws.Time(time.Now().Add(f.expiration)),
+		},
+	}, nil
+}
+
+func TestNewAssumeRoleCredentials(t *testing.T) {
+	identity := &infrav1.AWSRoleIdentity{
+		AWSRoleSpec: infrav1.AWSRoleSpec{
+			RoleARN:         "arn:aws:iam::222222222222:role/team",
+			ExternalID:      "external",
+			DurationSeconds: 3600,
+		},
+		SourceRoles: []infrav1.AWSRoleSpec{
+			{
+				RoleARN:     "arn:aws:iam::111111111111:role/jump",
+				SessionName: "jump-session",
+			},
+		},
+	}
+
+	var clients []*fakeAssumeRoler
+	creds := newAssumeRoleCredentials(func(source *credentials.Credentials) stscreds.AssumeRoler {
+		c := &fakeAssumeRoler{source: source, expiration: time.Hour}
+		clients = append(clients, c)
+		return c
+	}, identity)
+
+	value, err := creds.Get()
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if value.AccessKeyID != "arn:aws:iam::222222222222:role/team" {
+		t.Fatalf("expected credentials for the target role, got %q", value.AccessKeyID)
+	}
+
+	if len(clients) != 2 {
+		t.Fatalf("expected 2 links in the role chain, got %d", len(clients))
+	}
+	if clients[0].source != nil {
+		t.Fatalf("expected the first role to be assumed with the default credentials")
+	}
+	if clients[1].source == nil {
+		t.Fatalf("expected the target role to be assumed with the source role credentials")
+	}
+
+	jump := clients[0].inputs[0]
+	if aws.StringValue(jump.RoleArn) != "arn:aws:iam::111111111111:role/jump" ||
+		aws.StringValue(jump.RoleSessionName) != "jump-session" ||
+		jump.ExternalId != nil {
+		t.Fatalf("unexpected source role input: %v", jump)
+	}
+
+	team := clients[1].inputs[0]
+	if aws.StringValue(team.RoleSessionName) != DefaultSessionName ||
+		aws.StringValue(team.ExternalId) != "external" ||
+		aws.Int64Value(team.DurationSeconds) != 3600 {
+		t.Fatalf("unexpected target role input: %v", team)
+	}
+}
+
+func TestAssumeRoleCredentialsSessionTags(t *testing.T) {
+	client := &fakeAssumeRoler{expiration: time.Hour}
+	creds := newAssumeRoleCredentials(func(_ *credentials.Credentials) stscreds.AssumeRoler {
+		return client
+	}, &infrav1.AWSRoleIdentity{
+		AWSRoleSpec: infrav1.AWSRoleSpec{
+			RoleARN:           "arn:aws:iam::222222222222:role/team",
+			SessionTags:       map[string]string{"team": "a", "cluster": "test"},
+			TransitiveTagKeys: []string{"team"},
+		},
+	})
+
+	if _, err := creds.Get(); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+
+	input := client.inputs[0]
+	expected := []*sts.Tag{
+		{Key: aws.String("cluster"), Value: aws.String("test")},
+		{Key: aws.String("team"), Value: aws.String("a")},
+	}
+	if !reflect.DeepEqual(input.Tags, expected) {
+		t.Fatalf("expected session tags %v, got %v", expected, input.Tags)
+	}
+	if !reflect.DeepEqual(aws.StringValueSlice(input.TransitiveTagKeys), []string{"team"}) {
+		t.Fatalf("expected transitive tag keys [team], got %v", aws.StringValueSlice(input.TransitiveTagKeys))
+	}
+}
+
+func TestAssumeRoleCredentialsRenewal(t *testing.T) {
+	client := &fakeAssumeRoler{expiration: expiryWindow - time.Minute}
+	creds := newAssumeRoleCredentials(func(_ *credentials.Credentials) stscreds.AssumeRoler {
+		return client
+	}, &infrav1.AWSRoleIdentity{AWSRoleSpec: infrav1.AWSRoleSpec{RoleARN: "arn:aws:iam::222222222222:role/team"}})
+
+	if _, err := creds.Get(); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if !creds.IsExpired() {
+		t.Fatalf("expected credentials within the expiry window to be considered expired")
+	}
+	if _, err := creds.Get(); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if len(client.inputs) != 2 {
+		t.Fatalf("expected credentials to be renewed before expiry, got %d calls", len(client.inputs))
+	}
+}
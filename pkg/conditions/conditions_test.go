@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+func TestMarkTrueAndFalse(t *testing.T) {
+	cluster := &infrav1.AWSCluster{}
+
+	MarkFalse(cluster, infrav1.VPCReadyCondition, infrav1.VPCReconciliationFailedReason, infrav1.ConditionSeverityWarning, "failed to create vpc: %v", "boom")
+	if !IsFalse(cluster, infrav1.VPCReadyCondition) {
+		t.Fatalf("expected %s to be false", infrav1.VPCReadyCondition)
+	}
+
+	c := Get(cluster, infrav1.VPCReadyCondition)
+	if c.Reason != infrav1.VPCReconciliationFailedReason || c.Severity != infrav1.ConditionSeverityWarning || c.Message != "failed to create vpc: boom" {
+		t.Fatalf("unexpected condition: %+v", c)
+	}
+
+	MarkTrue(cluster, infrav1.VPCReadyCondition)
+	MarkTrue(cluster, infrav1.SubnetsReadyCondition)
+	if !IsTrue(cluster, infrav1.VPCReadyCondition) || !IsTrue(cluster, infrav1.SubnetsReadyCondition) {
+		t.Fatalf("expected conditions to be true: %+v", cluster.Status.Conditions)
+	}
+	if len(cluster.Status.Conditions) != 2 {
+		t.Fatalf("expected 2 conditions, got %d", len(cluster.Status.Conditions))
+	}
+	if c := Get(cluster, infrav1.VPCReadyCondition); c.Reason != "" || c.Severity != infrav1.ConditionSeverityNone {
+		t.Fatalf("expected reason and severity to be cleared: %+v", c)
+	}
+
+	Delete(cluster, infrav1.VPCReadyCondition)
+	if Has(cluster, infrav1.VPCReadyCondition) || !Has(cluster, infrav1.SubnetsReadyCondition) {
+		t.Fatalf("unexpected conditions after delete: %+v", cluster.Status.Conditions)
+	}
+}
+
+func TestSetLastTransitionTime(t *testing.T) {
+	before := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	machine := &infrav1.AWSMachine{
+		Status: infrav1.AWSMachineStatus{
+			Conditions: infrav1.Conditions{
+				{
+					Type:               infrav1.InstanceReadyCondition,
+					Status:             "True",
+					LastTransitionTime: before,
+				},
+			},
+		},
+	}
+
+	MarkTrue(machine, infrav1.InstanceReadyCondition)
+	if c := Get(machine, infrav1.InstanceReadyCondition); !c.LastTransitionTime.Equal(&before) {
+		t.Fatalf("expected last transition time to be preserved when the state does not change")
+	}
+
+	MarkFalse(machine, infrav1.InstanceReadyCondition, infrav1.InstanceStoppedReason, infrav1.ConditionSeverityError, "")
+	if c := Get(machine, infrav1.InstanceReadyCondition); c.LastTransitionTime.Equal(&before) {
+		t.Fatalf("expected last transition time to be updated when the state changes")
+	}
+}
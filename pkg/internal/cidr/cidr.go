@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cidr provides helpers to carve subnets out of CIDR blocks.
+package cidr
+
+import (
+	"encoding/binary"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxSubnetPrefixIPv4 is the prefix length of the smallest subnet AWS allows.
+	maxSubnetPrefixIPv4 = 28
+
+	// subnetPrefixIPv6 is the prefix length of the IPv6 CIDR block of every AWS subnet.
+	subnetPrefixIPv6 = 64
+)
+
+// SplitIntoSubnetsIPv4 splits the given IPv4 CIDR block into numSubnets
+// non-overlapping subnets of equal size. The subnets are as large as possible,
+// so when numSubnets is not a power of two part of the block is left unused
+// at its end.
+func SplitIntoSubnetsIPv4(cidrBlock string, numSubnets int) ([]*net.IPNet, error) {
+	if numSubnets < 1 {
+		return nil, errors.Errorf("cannot split %q into %d subnets", cidrBlock, numSubnets)
+	}
+
+	_, parent, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse CIDR block %q", cidrBlock)
+	}
+
+	ip := parent.IP.To4()
+	if ip == nil {
+		return nil, errors.Errorf("CIDR block %q is not an IPv4 block", cidrBlock)
+	}
+
+	ones, _ := parent.Mask.Size()
+	bits := 0
+	for 1<<uint(bits) < numSubnets {
+		bits++
+	}
+
+	prefix := ones + bits
+	if prefix > maxSubnetPrefixIPv4 {
+		return nil, errors.Errorf("CIDR block %q is too small to be split into %d subnets", cidrBlock, numSubnets)
+	}
+
+	base := binary.BigEndian.Uint32(ip)
+	size := uint32(1) << uint(32-prefix)
+	mask := net.CIDRMask(prefix, 32)
+
+	subnets := make([]*net.IPNet, 0, numSubnets)
+	for i := 0; i < numSubnets; i++ {
+		subnetIP := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(subnetIP, base+uint32(i)*size)
+		subnets = append(subnets, &net.IPNet{IP: subnetIP, Mask: mask})
+	}
+
+	return subnets, nil
+}
+
+// SplitIntoSubnetsIPv6 returns the first numSubnets /64 blocks of the given
+// IPv6 CIDR block, the size AWS requires for the IPv6 CIDR block of a subnet.
+func SplitIntoSubnetsIPv6(cidrBlock string, numSubnets int) ([]*net.IPNet, error) {
+	if numSubnets < 1 {
+		return nil, errors.Errorf("cannot split %q into %d subnets", cidrBlock, numSubnets)
+	}
+
+	_, parent, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse CIDR block %q", cidrBlock)
+	}
+
+	if parent.IP.To4() != nil {
+		return nil, errors.Errorf("CIDR block %q is not an IPv6 block", cidrBlock)
+	}
+
+	ones, _ := parent.Mask.Size()
+	if ones > subnetPrefixIPv6 || (subnetPrefixIPv6-ones < 32 && numSubnets > 1<<uint(subnetPrefixIPv6-ones)) {
+		return nil, errors.Errorf("CIDR block %q is too small to be split into %d /%d subnets", cidrBlock, numSubnets, subnetPrefixIPv6)
+	}
+
+	base := binary.BigEndian.Uint64(parent.IP[:8])
+	mask := net.CIDRMask(subnetPrefixIPv6, 128)
+
+	subnets := make([]*net.IPNet, 0, numSubnets)
+	for i := 0; i < numSubnets; i++ {
+		subnetIP := make(net.IP, net.IPv6len)
+		binary.BigEndian.PutUint64(subnetIP[:8], base+uint64(i))
+		subnets = append(subnets, &net.IPNet{IP: subnetIP, Mask: mask})
+	}
+
+	return subnets, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cidr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitIntoSubnetsIPv4(t *testing.T) {
+	testCases := []struct {
+		name       string
+		cidrBlock  string
+		numSubnets int
+		expected   []string
+		wantErr    bool
+	}{
+		{
+			name:       "single subnet",
+			cidrBlock:  "10.0.0.0/16",
+			numSubnets: 1,
+			expected:   []string{"10.0.0.0/16"},
+		},
+		{
+			name:       "power of two",
+			cidrBlock:  "10.0.0.0/16",
+			numSubnets: 4,
+			expected:   []string{"10.0.0.0/18", "10.0.64.0/18", "10.0.128.0/18", "10.0.192.0/18"},
+		},
+		{
+			name:       "not a power of two",
+			cidrBlock:  "10.0.0.0/16",
+			numSubnets: 6,
+			expected:   []string{"10.0.0.0/19", "10.0.32.0/19", "10.0.64.0/19", "10.0.96.0/19", "10.0.128.0/19", "10.0.160.0/19"},
+		},
+		{
+			name:       "unaligned block",
+			cidrBlock:  "192.168.1.17/24",
+			numSubnets: 2,
+			expected:   []string{"192.168.1.0/25", "192.168.1.128/25"},
+		},
+		{
+			name:       "block too small",
+			cidrBlock:  "10.0.0.0/27",
+			numSubnets: 4,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid block",
+			cidrBlock:  "10.0.0.0",
+			numSubnets: 2,
+			wantErr:    true,
+		},
+		{
+			name:       "ipv6 block",
+			cidrBlock:  "2001:db8::/56",
+			numSubnets: 2,
+			wantErr:    true,
+		},
+		{
+			name:       "no subnets",
+			cidrBlock:  "10.0.0.0/16",
+			numSubnets: 0,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subnets, err := SplitIntoSubnetsIPv4(tc.cidrBlock, tc.numSubnets)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			actual := make([]string, 0, len(subnets))
+			for _, subnet := range subnets {
+				actual = append(actual, subnet.String())
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSplitIntoSubnetsIPv6(t *testing.T) {
+	testCases := []struct {
+		name       string
+		cidrBlock  string
+		numSubnets int
+		expected   []string
+		wantErr    bool
+	}{
+		{
+			name:       "amazon provided block",
+			cidrBlock:  "2600:1f18:abc:de00::/56",
+			numSubnets: 3,
+			expected:   []string{"2600:1f18:abc:de00::/64", "2600:1f18:abc:de01::/64", "2600:1f18:abc:de02::/64"},
+		},
+		{
+			name:       "whole block",
+			cidrBlock:  "2001:db8::/64",
+			numSubnets: 1,
+			expected:   []string{"2001:db8::/64"},
+		},
+		{
+			name:       "block too small",
+			cidrBlock:  "2001:db8::/63",
+			numSubnets: 3,
+			wantErr:    true,
+		},
+		{
+			name:       "block smaller than a subnet",
+			cidrBlock:  "2001:db8::/80",
+			numSubnets: 1,
+			wantErr:    true,
+		},
+		{
+			name:       "ipv4 block",
+			cidrBlock:  "10.0.0.0/16",
+			numSubnets: 1,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subnets, err := SplitIntoSubnetsIPv6(tc.cidrBlock, tc.numSubnets)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			actual := make([]string, 0, len(subnets))
+			for _, subnet := range subnets {
+				actual = append(actual, subnet.String())
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}